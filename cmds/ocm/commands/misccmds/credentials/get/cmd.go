@@ -5,6 +5,7 @@
 package get
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
@@ -28,10 +29,12 @@ var (
 type Command struct {
 	utils.BaseCommand
 
-	Consumer credentials.ConsumerIdentity
-	Matcher  credentials.IdentityMatcher
+	Consumer    credentials.ConsumerIdentity
+	Matcher     credentials.IdentityMatcher
+	MatcherName string
 
-	Type string
+	Type    string
+	Explain bool
 }
 
 var _ utils.OCMCommand = (*Command)(nil)
@@ -55,12 +58,21 @@ For the following usage contexts with matchers and standard identity matchers ex
 The used matcher is derived from the consumer attribute <code>type</code>.
 For all other consumer types a matcher matching all attributes will be used.
 The usage of a dedicated matcher can be enforced by the option <code>--matcher</code>.
+
+With the option <code>--explain</code> the resolution of the credentials is
+traced. All checked consumer providers (explicit credential settings, credential
+repositories and built-in providers) are listed in the order they are asked
+together with the consumer identities passed by them to the used matcher and
+whether the matcher accepted the identity as better match. Finally, the chosen
+credential source is shown together with the credential attributes. Hereby,
+secret values are masked.
 `,
 	}
 }
 
 func (o *Command) AddFlags(set *pflag.FlagSet) {
 	set.StringVarP(&o.Type, "matcher", "m", "", "matcher type override")
+	set.BoolVarP(&o.Explain, "explain", "x", false, "explain credential resolution")
 }
 
 func (o *Command) Complete(args []string) error {
@@ -70,6 +82,7 @@ func (o *Command) Complete(args []string) error {
 			return errors.ErrUnknown("identity matcher", o.Type)
 		}
 		o.Matcher = m
+		o.MatcherName = o.Type
 	}
 	o.Consumer = credentials.ConsumerIdentity{}
 	for _, s := range args {
@@ -88,25 +101,101 @@ func (o *Command) Complete(args []string) error {
 		m, _ := o.CredentialsContext().ConsumerIdentityMatchers().Get(t)
 		if m != nil {
 			o.Matcher = m
+			o.MatcherName = t
 		}
 	}
 	if o.Matcher == nil {
 		o.Matcher = credentials.PartialMatch
+		o.MatcherName = "partial"
 	}
 	return nil
 }
 
 func (o *Command) Run() error {
+	if o.Explain {
+		return o.explain()
+	}
 	creds, err := credentials.RequiredCredentialsForConsumer(o.CredentialsContext(), o.Consumer, o.Matcher)
 	if err != nil {
 		return err
 	}
 
+	o.printCredentials(creds, false)
+	return nil
+}
+
+// unmaskedAttributes are the credential attributes shown in
+// explain mode. All other attribute values are masked.
+var unmaskedAttributes = map[string]bool{
+	credentials.ATTR_USERNAME:       true,
+	credentials.ATTR_SERVER_ADDRESS: true,
+}
+
+func (o *Command) printCredentials(creds credentials.Credentials, mask bool) {
 	var list [][]string
 	for k, v := range creds.Properties() {
+		if mask && !unmaskedAttributes[k] {
+			v = "***"
+		}
 		list = append(list, []string{k, v})
 	}
 	sort.Slice(list, func(i, j int) bool { return strings.Compare(list[i][0], list[j][0]) < 0 })
 	output.FormatTable(o, "", append([][]string{{"ATTRIBUTE", "VALUE"}}, list...))
+}
+
+func providerName(c *credentials.CredentialsCandidate) string {
+	switch {
+	case c.Explicit && c.Provider == "":
+		return "explicit"
+	case c.Explicit:
+		return "explicit " + string(c.Provider)
+	default:
+		return string(c.Provider)
+	}
+}
+
+func (o *Command) explain() error {
+	e, err := o.CredentialsContext().ExplainCredentialsForConsumer(o.Consumer, o.Matcher)
+	if err != nil {
+		return err
+	}
+
+	out := o.Context.StdOut()
+	fmt.Fprintf(out, "consumer: %s\n", o.Consumer.String())
+	fmt.Fprintf(out, "matcher:  %s\n\n", o.MatcherName)
+
+	list := [][]string{{"PROVIDER", "IDENTITY", "RESULT"}}
+	for _, c := range e.Candidates {
+		id, result := "-", "no match"
+		if c.Identity != nil {
+			id = c.Identity.String()
+		}
+		if c.Matches {
+			result = "superseded"
+			if c.Selected {
+				result = "selected"
+			}
+		}
+		if c == e.Result {
+			result = "chosen"
+		}
+		list = append(list, []string{providerName(c), id, result})
+	}
+	output.FormatTable(o, "", list)
+	fmt.Fprintf(out, "\n")
+
+	switch {
+	case e.Result != nil:
+		fmt.Fprintf(out, "source: %s %s\n", providerName(e.Result), e.Result.Identity.String())
+	case e.Fallback:
+		fmt.Fprintf(out, "source: fallback for empty consumer identity\n")
+	default:
+		return credentials.ErrUnknownConsumer(o.Consumer.String())
+	}
+	creds, err := e.Source.Credentials(o.CredentialsContext())
+	if err != nil {
+		return err
+	}
+	o.printCredentials(creds, true)
 	return nil
 }
@@ -74,6 +74,47 @@ user      testuser
 ATTRIBUTE VALUE
 password  testpass
 username  testuser
+`))
+	})
+
+	It("explains credential resolution", func() {
+		env.CLI.CredentialsContext().SetCredentialsForConsumer(credentials.ConsumerIdentity{
+			identity.ID_TYPE:     identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME: "ghcr.io",
+		}, credentials.DirectCredentials{
+			"username": "other",
+			"password": "otherpass",
+		})
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("get", "credentials", "--explain", identity.ID_TYPE+"="+identity.CONSUMER_TYPE, identity.ID_HOSTNAME+"=ghcr.io", identity.ID_PATHPREFIX+"=a/b")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+consumer: {"hostname":"ghcr.io","pathprefix":"a/b","type":"OCIRegistry"}
+matcher:  OCIRegistry
+
+PROVIDER IDENTITY                                                     RESULT
+explicit {"hostname":"ghcr.io","pathprefix":"a","type":"OCIRegistry"} chosen
+explicit {"hostname":"ghcr.io","type":"OCIRegistry"}                  superseded
+explicit {"hostname":"ghcr.io","type":"test"}                         no match
+
+source: explicit {"hostname":"ghcr.io","pathprefix":"a","type":"OCIRegistry"}
+ATTRIBUTE VALUE
+password  ***
+username  testuser
+`))
+	})
+
+	It("explains failed credential resolution", func() {
+		buf := bytes.NewBuffer(nil)
+		err := env.CatchOutput(buf).Execute("get", "credentials", "--explain", identity.ID_TYPE+"=test", identity.ID_HOSTNAME+"=gcr.io")
+		Expect(err).To(MatchError(`consumer "{"hostname":"gcr.io","type":"test"}" is unknown`))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+consumer: {"hostname":"gcr.io","type":"test"}
+matcher:  partial
+
+PROVIDER IDENTITY                                                     RESULT
+explicit {"hostname":"ghcr.io","pathprefix":"a","type":"OCIRegistry"} no match
+explicit {"hostname":"ghcr.io","type":"test"}                         no match
 `))
 	})
 })
@@ -2,7 +2,7 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package rsakeypair
+package keypair
 
 import (
 	"crypto/x509"
@@ -21,6 +21,8 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
 	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
 	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/out"
 	"github.com/open-component-model/ocm/pkg/signing"
@@ -28,7 +30,7 @@ import (
 )
 
 var (
-	Names = names.RSAKeyPair
+	Names = names.KeyPair
 	Verb  = verbs.Create
 )
 
@@ -40,6 +42,9 @@ type Command struct {
 	priv        string
 	pub         string
 
+	algorithm string
+	handler   signing.KeyPairHandler
+
 	attrs  map[string]string
 	cacert string
 	cakey  string
@@ -59,11 +64,16 @@ func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
 func (o *Command) ForName(name string) *cobra.Command {
 	return &cobra.Command{
 		Use:   "[<private key file> [<public key file>]] {<subject-attribute>=<value>}",
-		Short: "create RSA public key pair",
+		Short: "create public key pair",
 		Long: `
-Create an RSA public key pair and save to files.
+Create a public key pair and save to files.
+
+The algorithm of the key pair can be selected with option <code>--algorithm</code>.
+The following algorithms are supported:
+` + utils.FormatList(rsa.Algorithm, keyPairAlgorithms()...) + `
 
-The default for the filename to store the private key is <code>rsa.priv</code>.
+The default for the filename to store the private key is <code>rsa.priv</code>
+for RSA keys and <code>&lt;algorithm>.priv</code> (lower case) for other algorithms.
 If no public key file is specified, its name will be derived from the filename for
 the private key (suffix <code>.pub</code> for public key or <code>.cert</code> for certificate).
 If a certificate authority is given (<code>--cacert</code>) the public key
@@ -82,12 +92,24 @@ For signing the public key the following subject attributes are supported:
 
 	`,
 		Example: `
-$ ocm create rsakeypair mandelsoft.priv mandelsoft.cert issuer=mandelsoft
+$ ocm create keypair mandelsoft.priv mandelsoft.cert issuer=mandelsoft
+$ ocm create keypair --algorithm ECDSA-P384 mandelsoft.priv
 `,
 	}
 }
 
+func keyPairAlgorithms() []string {
+	var list []string
+	for _, n := range signing.DefaultRegistry().SignerNames() {
+		if _, ok := signing.DefaultRegistry().GetSigner(n).(signing.KeyPairHandler); ok {
+			list = append(list, n)
+		}
+	}
+	return list
+}
+
 func (o *Command) AddFlags(set *pflag.FlagSet) {
+	set.StringVarP(&o.algorithm, "algorithm", "S", rsa.Algorithm, "signature algorithm of the key pair")
 	set.StringVarP(&o.cacert, "cacert", "", "", "certificate authority to sign public key")
 	set.StringVarP(&o.cakey, "cakey", "", "", "private key for certificate authority")
 	set.DurationVarP(&o.Validity, "validity", "", 10*24*365*time.Hour, "certificate validity")
@@ -103,6 +125,18 @@ func (o *Command) Complete(args []string) error {
 	if len(args) > 2 {
 		return errors.Newf("only a maximum of two filenames possible")
 	}
+	if o.algorithm == "" {
+		o.algorithm = rsa.Algorithm
+	}
+	signer := signingattr.Get(o.Context.OCMContext()).GetSigner(o.algorithm)
+	if signer == nil {
+		return errors.ErrUnknown(compdesc.KIND_SIGN_ALGORITHM, o.algorithm)
+	}
+	if h, ok := signer.(signing.KeyPairHandler); ok {
+		o.handler = h
+	} else {
+		return errors.ErrNotSupported("key pair creation", o.algorithm)
+	}
 	if o.attrs != nil && len(o.attrs) > 0 {
 		var subject pkix.Name
 		for k, v := range o.attrs {
@@ -177,7 +211,7 @@ func (o *Command) Complete(args []string) error {
 	if len(args) > 0 {
 		o.priv = args[0]
 	} else {
-		o.priv = "rsa.priv"
+		o.priv = o.kind() + ".priv"
 	}
 	if len(args) > 1 {
 		o.pub = args[1]
@@ -197,7 +231,7 @@ func (o *Command) Complete(args []string) error {
 }
 
 func (o *Command) Run() error {
-	priv, pub, err := rsa.Handler{}.CreateKeyPair()
+	priv, pub, err := o.handler.CreateKeyPair()
 	if err != nil {
 		return err
 	}
@@ -218,10 +252,17 @@ func (o *Command) Run() error {
 	if err := o.WriteKey(pub, o.pub); err != nil {
 		return errors.Wrapf(err, "failed to write public key file %q", o.pub)
 	}
-	out.Outf(o.Context, "created rsa key pair %s[%s]\n", o.priv, o.pub)
+	out.Outf(o.Context, "created %s key pair %s[%s]\n", o.kind(), o.priv, o.pub)
 	return nil
 }
 
+func (o *Command) kind() string {
+	if o.algorithm == rsa.Algorithm {
+		return "rsa"
+	}
+	return strings.ToLower(o.algorithm)
+}
+
 func (o *Command) WriteKey(key interface{}, path string) error {
 	fd, err := o.Context.FileSystem().OpenFile(path, vfs.O_CREATE|vfs.O_WRONLY, 0o600)
 	if err != nil {
@@ -232,7 +273,7 @@ func (o *Command) WriteKey(key interface{}, path string) error {
 		block := &pem.Block{Type: "CERTIFICATE", Bytes: certdata}
 		err = pem.Encode(fd, block)
 	} else {
-		err = rsa.WriteKeyData(key, fd)
+		err = o.handler.WriteKeyData(key, fd)
 	}
 	if err != nil {
 		fd.Close()
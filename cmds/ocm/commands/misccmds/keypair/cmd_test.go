@@ -2,7 +2,7 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package rsakeypair_test
+package keypair_test
 
 import (
 	"bytes"
@@ -15,6 +15,8 @@ import (
 	"github.com/opencontainers/go-digest"
 
 	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/ecdsa"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/ed25519"
 	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
 )
 
@@ -78,4 +80,41 @@ created rsa key pair key.priv[key.cert]
 		err = rsa.Handler{}.Verify(d.Hex(), 0, sig, pub)
 		Expect(err).To(Succeed())
 	})
+
+	It("create ecdsa key pair", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("create", "keypair", "--algorithm", ecdsa.AlgorithmP384)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+created ecdsa-p384 key pair ecdsa-p384.priv[ecdsa-p384.pub]
+`))
+		priv := Must(env.ReadFile("ecdsa-p384.priv"))
+		pub := Must(env.ReadFile("ecdsa-p384.pub"))
+		key := Must(ecdsa.GetPrivateKey(priv))
+		Expect(key.Curve.Params().Name).To(Equal("P-384"))
+
+		d := digest.FromBytes([]byte("digest"))
+		sig := Must(ecdsa.Handler{}.Sign(defaultContext, d.Hex(), 0, ISSUER, priv))
+		Expect(sig.Algorithm).To(Equal(ecdsa.Algorithm))
+		Expect(sig.MediaType).To(Equal(ecdsa.MediaType))
+		MustBeSuccessful(ecdsa.Handler{}.Verify(d.Hex(), 0, sig, pub))
+	})
+
+	It("create self-signed ed25519 key pair", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("create", "keypair", "--algorithm", ed25519.Algorithm, "key.priv", "CN=mandelsoft")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+created ed25519 key pair key.priv[key.cert]
+`))
+		priv := Must(env.ReadFile("key.priv"))
+		pub := Must(env.ReadFile("key.cert"))
+
+		d := digest.FromBytes([]byte("digest"))
+		sig := Must(ed25519.Handler{}.Sign(defaultContext, d.Hex(), 0, ISSUER, priv))
+		Expect(sig.Algorithm).To(Equal(ed25519.Algorithm))
+		MustBeSuccessful(ed25519.Handler{}.Verify(d.Hex(), 0, sig, pub))
+	})
+
+	It("rejects algorithms without key pair support", func() {
+		Expect(env.Execute("create", "keypair", "--algorithm", "rsa-signingservice")).To(HaveOccurred())
+	})
 })
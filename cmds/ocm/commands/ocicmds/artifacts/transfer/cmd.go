@@ -20,6 +20,7 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
 	"github.com/open-component-model/ocm/pkg/contexts/clictx"
 	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer"
 	"github.com/open-component-model/ocm/pkg/errors"
@@ -72,6 +73,8 @@ $ ocm oci artifact transfer ghcr.io/mandelsoft/kubelink:v1.0.0 gcr.io
 $ ocm oci artifact transfer ghcr.io/mandelsoft/kubelink gcr.io
 $ ocm oci artifact transfer ghcr.io/mandelsoft/kubelink gcr.io/my-project
 $ ocm oci artifact transfer /tmp/ctf gcr.io/my-project
+$ ocm oci artifact transfer ghcr.io/mandelsoft/kubelink:v1.0.0 OCILayout::/tmp/layout
+$ ocm oci artifact transfer ghcr.io/mandelsoft/kubelink:v1.0.0 DockerArchive::/tmp/kubelink.tar
 `,
 	}
 }
@@ -140,7 +143,15 @@ func NewAction(ctx clictx.Context, session oci.Session, target string, transferR
 		return nil, errors.Newf("repository names cannot be transferred for a given target version")
 	}
 	if ref.IsRegistry() {
-		transferRepo = true
+		// repositories providing only the anonymous namespace (like
+		// artifact sets or OCI image layouts) cannot host repository names.
+		if _, ok := repo.NamespaceLister().(*artifactset.NamespaceLister); ok {
+			if transferRepo {
+				return nil, errors.Newf("repository names cannot be transferred to a repository-less target")
+			}
+		} else {
+			transferRepo = true
+		}
 	}
 	return &action{
 		Context:      ctx,
@@ -220,6 +231,9 @@ func (a *action) handleArtifact(src *artifacthdlr.Object) error {
 	}
 	out.Outf(a.Context, "copying %s to %s...\n", &src.Spec, &tgt)
 	err = transfer.TransferArtifact(src.Artifact, ns, tag)
+	if err == nil && src.Namespace != nil {
+		err = transfer.TransferAttachedArtifacts(src.Namespace, src.Artifact.Digest(), ns)
+	}
 	if err == nil {
 		a.copied++
 	}
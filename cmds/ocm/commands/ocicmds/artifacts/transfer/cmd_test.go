@@ -12,8 +12,14 @@ import (
 	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
 	. "github.com/open-component-model/ocm/pkg/testutils"
 
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
 	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/dockerarchive"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocilayout"
 	"github.com/open-component-model/ocm/pkg/mime"
 )
 
@@ -21,6 +27,8 @@ const ARCH = "/tmp/ctf"
 const VERSION = "v1"
 const NS = "mandelsoft/test"
 const OUT = "/tmp/res"
+const LAYOUT = "/tmp/layout"
+const IMAGE = "/tmp/image.tar"
 
 var _ = Describe("Test Environment", func() {
 	var env *TestEnv
@@ -127,4 +135,72 @@ copied 1 from 1 artifact(s) and 1 repositories
 `))
 		Expect(env.ReadFile(OUT + "/" + ctf.ArtifactIndexFileName)).To(Equal([]byte("{\"schemaVersion\":1,\"artifacts\":[{\"repository\":\"mandelsoft/test\",\"tag\":\"v1\",\"digest\":\"sha256:2c3e2c59e0ac9c99864bf0a9f9727c09f21a66080f9f9b03b36a2dad3cce6ff9\"}]}")))
 	})
+
+	It("transfers an artifact into an OCI image layout", func() {
+		env.OCICommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Namespace(NS, func() {
+				env.Manifest(VERSION, func() {
+					env.Config(func() {
+						env.BlobStringData(mime.MIME_JSON, "{}")
+					})
+					env.Layer(func() {
+						env.BlobStringData(mime.MIME_TEXT, "testdata")
+					})
+				})
+			})
+		})
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "artifact", ARCH+"//"+NS+":"+VERSION, ocilayout.Type+"::"+LAYOUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+copying /tmp/ctf//mandelsoft/test:v1 to ` + ocilayout.Type + `::` + LAYOUT + `//:v1...
+copied 1 from 1 artifact(s) and 1 repositories
+`))
+		Expect(env.FileExists(LAYOUT + "/" + ocilayout.LayoutFileName)).To(BeTrue())
+		Expect(env.FileExists(LAYOUT + "/" + ocilayout.BlobsDirectoryName + "/sha256/2c3e2c59e0ac9c99864bf0a9f9727c09f21a66080f9f9b03b36a2dad3cce6ff9")).To(BeTrue())
+		Expect(env.ReadFile(LAYOUT + "/" + ocilayout.IndexFileName)).To(MatchJSON(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:2c3e2c59e0ac9c99864bf0a9f9727c09f21a66080f9f9b03b36a2dad3cce6ff9","size":342,"annotations":{"org.opencontainers.image.ref.name":"v1"}}]}`))
+
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("transfer", "artifact", ocilayout.Type+"::"+LAYOUT, "directory::"+OUT+"//"+NS)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+copying ` + ocilayout.Type + `::` + LAYOUT + `//:v1 to directory::` + OUT + `//mandelsoft/test:v1...
+copied 1 from 1 artifact(s) and 1 repositories
+`))
+		Expect(env.ReadFile(OUT + "/" + ctf.ArtifactIndexFileName)).To(Equal([]byte("{\"schemaVersion\":1,\"artifacts\":[{\"repository\":\"mandelsoft/test\",\"tag\":\"v1\",\"digest\":\"sha256:2c3e2c59e0ac9c99864bf0a9f9727c09f21a66080f9f9b03b36a2dad3cce6ff9\"}]}")))
+	})
+
+	It("transfers an artifact into a docker archive", func() {
+		env.OCICommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Namespace(NS, func() {
+				env.Manifest(VERSION, func() {
+					env.Config(func() {
+						env.BlobStringData(ociv1.MediaTypeImageConfig, `{"architecture":"amd64","os":"linux","rootfs":{"type":"layers","diff_ids":["`+digest.FromString("testdata").String()+`"]}}`)
+					})
+					env.Layer(func() {
+						env.BlobStringData(artdesc.MediaTypeImageLayer, "testdata")
+					})
+				})
+			})
+		})
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "artifact", ARCH+"//"+NS+":"+VERSION, dockerarchive.Type+"::"+IMAGE)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+copying /tmp/ctf//mandelsoft/test:v1 to ` + dockerarchive.Type + `::` + IMAGE + `//mandelsoft/test:v1...
+copied 1 from 1 artifact(s) and 1 repositories
+`))
+		Expect(env.FileExists(IMAGE)).To(BeTrue())
+
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("transfer", "artifact", dockerarchive.Type+"::"+IMAGE, "directory::"+OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+copying ` + dockerarchive.Type + `::` + IMAGE + `//mandelsoft/test:v1 to directory::` + OUT + `//mandelsoft/test:v1...
+copied 1 from 1 artifact(s) and 1 repositories
+`))
+		Expect(env.ReadFile(OUT + "/" + ctf.ArtifactIndexFileName)).To(ContainSubstring(`"repository":"mandelsoft/test","tag":"v1"`))
+	})
 })
@@ -18,6 +18,7 @@ import (
 	"github.com/open-component-model/ocm/pkg/common/accessio"
 	"github.com/open-component-model/ocm/pkg/contexts/clictx"
 	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/runtime"
 	"github.com/open-component-model/ocm/pkg/utils"
@@ -82,6 +83,9 @@ type InputResourceInfo struct {
 	ElementName string
 	// The path of the file the inputs description has been taken from.
 	InputFilePath string
+	// ComponentVersionAccess is the component version to generate, if available.
+	// It can be used to refer to elements already added to the component version.
+	ComponentVersionAccess ocm.ComponentVersionAccess
 }
 
 type InputSpec interface {
@@ -31,6 +31,8 @@ var (
 	VariantsOption       = flagsets.NewStringArrayOptionType("inputVariants", "(platform) variants for inputs")
 )
 
+var PlatformVariantsOption = flagsets.NewYAMLOptionType("inputPlatformVariants", "YAML based list of platform variant specifications for inputs")
+
 var LibrariesOption = flagsets.NewStringArrayOptionType("inputLibraries", "library path for inputs")
 
 var VersionOption = flagsets.NewStringArrayOptionType("inputVersion", "version info for inputs")
@@ -40,3 +42,7 @@ var ValuesOption = flagsets.NewValueMapYAMLOptionType("inputValues", "YAML based
 var DataOption = flagsets.NewBytesOptionType("inputData", "data (string, !!string or !<base64>")
 
 var TextOption = flagsets.NewStringOptionType("inputText", "utf8 text")
+
+var RevisionOption = flagsets.NewStringOptionType("inputRevision", "revision for inputs")
+
+var PathSpecOption = flagsets.NewStringOptionType("inputPathSpec", "path filter for repository inputs")
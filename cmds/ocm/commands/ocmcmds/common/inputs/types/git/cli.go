@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/cpi"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/options"
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return cpi.NewMediaFileSpecOptionType(
+		TYPE, AddConfig,
+		options.RevisionOption,
+		options.PathSpecOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	if err := cpi.AddMediaFileSpecConfig(opts, config); err != nil {
+		return err
+	}
+	flagsets.AddFieldByOptionP(opts, options.RevisionOption, config, "revision")
+	flagsets.AddFieldByOptionP(opts, options.PathSpecOption, config, "pathSpec")
+	return nil
+}
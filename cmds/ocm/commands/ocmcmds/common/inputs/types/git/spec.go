@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"compress/gzip"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/cpi"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/utils/gitutils"
+)
+
+type Spec struct {
+	cpi.MediaFileSpec `json:",inline"`
+	// Revision is the revision (commit, branch or tag) to archive.
+	// If not given, HEAD is used.
+	Revision string `json:"revision,omitempty"`
+	// PathSpec restricts the archive to a sub path of the repository.
+	PathSpec string `json:"pathSpec,omitempty"`
+}
+
+var _ inputs.InputSpec = (*Spec)(nil)
+
+func New(path, revision, mediatype string, compress bool) *Spec {
+	return &Spec{
+		MediaFileSpec: cpi.NewMediaFileSpec(TYPE, path, mediatype, compress),
+		Revision:      revision,
+	}
+}
+
+func (s *Spec) Validate(fldPath *field.Path, ctx inputs.Context, inputFilePath string) field.ErrorList {
+	fileInfo, filePath, allErrs := s.MediaFileSpec.ValidateFile(fldPath, ctx, inputFilePath)
+	if len(allErrs) == 0 {
+		if !fileInfo.Mode().IsDir() {
+			pathField := fldPath.Child("path")
+			allErrs = append(allErrs, field.Invalid(pathField, filePath, "no directory"))
+		}
+	}
+	return allErrs
+}
+
+func (s *Spec) GetBlob(ctx inputs.Context, info inputs.InputResourceInfo) (accessio.TemporaryBlobAccess, string, error) {
+	fs := ctx.FileSystem()
+	_, inputPath, err := inputs.FileInfo(ctx, s.Path, info.InputFilePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("resource git repository %s: %w", info.InputFilePath, err)
+	}
+	repo, err := gitutils.OpenRepository(fs, inputPath)
+	if err != nil {
+		return nil, "", err
+	}
+	commit, err := gitutils.ResolveCommit(repo, s.Revision)
+	if err != nil {
+		return nil, "", fmt.Errorf("git repository %s: %w", inputPath, err)
+	}
+
+	temp, err := accessio.NewTempFile(fs, "", "resourceblob*.tgz")
+	if err != nil {
+		return nil, "", err
+	}
+	defer temp.Close()
+
+	if s.Compress() {
+		s.SetMediaTypeIfNotDefined(mime.MIME_TGZ)
+		gw := gzip.NewWriter(temp.Writer())
+		if err := gitutils.Archive(commit, s.PathSpec, gw); err != nil {
+			return nil, "", fmt.Errorf("unable to archive commit %s: %w", commit.Hash, err)
+		}
+		if err := gw.Close(); err != nil {
+			return nil, "", fmt.Errorf("unable to close gzip writer: %w", err)
+		}
+	} else {
+		s.SetMediaTypeIfNotDefined(mime.MIME_TAR)
+		if err := gitutils.Archive(commit, s.PathSpec, temp.Writer()); err != nil {
+			return nil, "", fmt.Errorf("unable to archive commit %s: %w", commit.Hash, err)
+		}
+	}
+	return temp.AsBlob(s.MediaType), "", nil
+}
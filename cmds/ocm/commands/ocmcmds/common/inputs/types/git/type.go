@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const TYPE = "git"
+
+func init() {
+	inputs.DefaultInputTypeScheme.Register(TYPE, inputs.NewInputType(TYPE, &Spec{}, usage, ConfigHandler()))
+}
+
+const usage = `
+The path must denote a local git checkout or a bare git repository
+relative to the resources file. The file tree of a commit is packed
+with tar and optionally compressed if the <code>compress</code> field
+is set to <code>true</code>. Only committed content is used, local
+modifications of the work tree are ignored.
+This input type is typically used to describe the sources of a component
+version.
+
+This blob type specification supports the following fields: 
+- **<code>path</code>** *string*
+
+  This REQUIRED property describes the path to the git repository relative
+  to the resource file location.
+
+- **<code>revision</code>** *string*
+
+  This OPTIONAL property describes the revision (commit, branch or tag)
+  to archive. The default is <code>HEAD</code>.
+
+- **<code>pathSpec</code>** *string*
+
+  This OPTIONAL property describes a path in the repository. If given,
+  only the denoted file or the content of the denoted directory is archived.
+
+- **<code>mediaType</code>** *string*
+
+  This OPTIONAL property describes the media type to store with the local blob.
+  The default media type is ` + mime.MIME_TAR + ` and
+  ` + mime.MIME_TGZ + ` if compression is enabled.
+
+- **<code>compress</code>** *bool*
+
+  This OPTIONAL property describes whether the file content should be stored
+  compressed or not.
+`
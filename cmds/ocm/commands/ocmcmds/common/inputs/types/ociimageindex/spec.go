@@ -0,0 +1,347 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ociimageindex
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/open-component-model/ocm/pkg/finalizer"
+
+	"github.com/opencontainers/go-digest"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/ociimage"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/dockerarchive"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocilayout"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+type Spec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// Repository is the repository hint for the index artifact
+	Repository string `json:"repository,omitempty"`
+	// Variants describes the per-platform images used to compose the image index.
+	Variants []Variant `json:"variants"`
+	// Annotations are the annotations of the image index.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Variant describes the source of a single platform image.
+// Exactly one of the source fields OCILayout, DockerArchive or Resource
+// must be set.
+type Variant struct {
+	// OCILayout is the path of an OCI image layout.
+	OCILayout string `json:"ociLayout,omitempty"`
+	// DockerArchive is the path of an image archive written by docker save.
+	DockerArchive string `json:"dockerArchive,omitempty"`
+	// Resource is the identity of a resource of the same component version.
+	Resource metav1.Identity `json:"resource,omitempty"`
+	// Image selects the image in the source (tag or digest for OCI image layouts,
+	// image name and tag for docker archives).
+	Image string `json:"image,omitempty"`
+	// Platform overwrites the platform taken from the image config.
+	Platform *artdesc.Platform `json:"platform,omitempty"`
+	// Annotations are the annotations of the image descriptor in the index.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+var _ inputs.InputSpec = (*Spec)(nil)
+
+func New(variants ...Variant) *Spec {
+	return &Spec{
+		ObjectVersionedType: runtime.ObjectVersionedType{
+			Type: TYPE,
+		},
+		Variants: variants,
+	}
+}
+
+func (v *Variant) String() string {
+	switch {
+	case v.OCILayout != "":
+		return ocilayout.Type + "::" + v.OCILayout + optImage(v.Image)
+	case v.DockerArchive != "":
+		return dockerarchive.Type + "::" + v.DockerArchive + optImage(v.Image)
+	default:
+		return "resource " + v.Resource.String()
+	}
+}
+
+func optImage(image string) string {
+	if image == "" {
+		return ""
+	}
+	return "//" + image
+}
+
+func (s *Spec) Validate(fldPath *field.Path, ctx inputs.Context, inputFilePath string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = ociimage.ValidateRepository(fldPath.Child("repository"), allErrs, s.Repository)
+	variantsField := fldPath.Child("variants")
+	if len(s.Variants) == 0 {
+		allErrs = append(allErrs, field.Required(variantsField, fmt.Sprintf("variants is required for input of type %q and must has at least one entry", s.GetType())))
+	}
+	for i, variant := range s.Variants {
+		allErrs = append(allErrs, variant.Validate(variantsField.Index(i))...)
+	}
+	return allErrs
+}
+
+func (v *Variant) Validate(fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var sources []string
+	if v.OCILayout != "" {
+		sources = append(sources, "ociLayout")
+	}
+	if v.DockerArchive != "" {
+		sources = append(sources, "dockerArchive")
+	}
+	if len(v.Resource) > 0 {
+		sources = append(sources, "resource")
+		if v.Resource[metav1.SystemIdentityName] == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("resource", metav1.SystemIdentityName), "resource name is required"))
+		}
+		if v.Image != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("image"), "image selection not possible for resources"))
+		}
+	}
+	switch len(sources) {
+	case 0:
+		allErrs = append(allErrs, field.Required(fldPath, "one of ociLayout, dockerArchive or resource is required"))
+	case 1:
+	default:
+		allErrs = append(allErrs, field.Invalid(fldPath, strings.Join(sources, ", "), "only one image source possible"))
+	}
+
+	if v.DockerArchive != "" && v.Image != "" {
+		if _, err := oci.ParseArt(v.Image); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("image"), v.Image, err.Error()))
+		}
+	}
+	if v.Platform != nil {
+		if v.Platform.OS == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("platform", "os"), "operating system is required"))
+		}
+		if v.Platform.Architecture == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("platform", "architecture"), "architecture is required"))
+		}
+	}
+	return allErrs
+}
+
+// getArtifact provides the artifact for a variant. All
+// opened objects are registered at the given finalizer.
+func (v *Variant) getArtifact(ctx inputs.Context, info inputs.InputResourceInfo, finalize *Finalizer) (oci.ArtifactAccess, error) {
+	switch {
+	case v.OCILayout != "":
+		path, err := inputs.GetPath(ctx, v.OCILayout, info.InputFilePath)
+		if err != nil {
+			return nil, err
+		}
+		spec, err := ocilayout.NewRepositorySpec(accessobj.ACC_READONLY, path, accessio.PathFileSystem(ctx.FileSystem()))
+		if err != nil {
+			return nil, err
+		}
+		repo, err := ctx.OCIContext().RepositoryForSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		finalize.Close(repo)
+		return selectArtifact(finalize, repo, "", v.Image)
+
+	case v.DockerArchive != "":
+		path, err := inputs.GetPath(ctx, v.DockerArchive, info.InputFilePath)
+		if err != nil {
+			return nil, err
+		}
+		repo, err := ctx.OCIContext().RepositoryForSpec(dockerarchive.NewRepositorySpec(accessobj.ACC_READONLY, path, ctx.FileSystem()))
+		if err != nil {
+			return nil, err
+		}
+		finalize.Close(repo)
+		if v.Image != "" {
+			art, err := oci.ParseArt(v.Image)
+			if err != nil {
+				return nil, err
+			}
+			return selectArtifact(finalize, repo, art.Repository, art.Reference())
+		}
+		names, err := repo.NamespaceLister().GetNamespaces("", true)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) != 1 {
+			return nil, errors.Newf("image name required, archive contains %d images", len(names))
+		}
+		return selectArtifact(finalize, repo, names[0], "")
+
+	default:
+		cv := info.ComponentVersionAccess
+		if cv == nil {
+			return nil, errors.Newf("no component version available to resolve resource %s", v.Resource)
+		}
+		res, err := cv.GetResource(v.Resource)
+		if err != nil {
+			return nil, err
+		}
+		meth, err := res.AccessMethod()
+		if err != nil {
+			return nil, err
+		}
+		finalize.Close(meth)
+		mime := meth.MimeType()
+		if !artdesc.IsOCIMediaType(mime) {
+			return nil, errors.ErrInvalid("OCI artifact media type", mime)
+		}
+		set, err := artifactset.OpenFromBlob(accessobj.ACC_READONLY, accessio.BlobAccessForDataAccess(accessio.BLOB_UNKNOWN_DIGEST, accessio.BLOB_UNKNOWN_SIZE, mime, meth))
+		if err != nil {
+			return nil, err
+		}
+		finalize.Close(set)
+		art, err := set.GetArtifact(set.GetMain().String())
+		if err != nil {
+			return nil, err
+		}
+		finalize.Close(art)
+		return art, nil
+	}
+}
+
+// selectArtifact provides the artifact with the given version from a
+// repository namespace. If no version is given, the namespace must
+// contain exactly one tag.
+func selectArtifact(finalize *Finalizer, repo oci.Repository, name, version string) (oci.ArtifactAccess, error) {
+	ns, err := repo.LookupNamespace(name)
+	if err != nil {
+		return nil, err
+	}
+	finalize.Close(ns)
+	if version == "" {
+		tags, err := ns.ListTags()
+		if err != nil {
+			return nil, err
+		}
+		if len(tags) != 1 {
+			return nil, errors.Newf("image version required, found %d tags", len(tags))
+		}
+		version = tags[0]
+	}
+	art, err := ns.GetArtifact(version)
+	if err != nil {
+		return nil, artifactset.GetArtifactError{Original: err, Ref: name + ":" + version}
+	}
+	finalize.Close(art)
+	return art, nil
+}
+
+// platformKey provides a unique key for a platform.
+func platformKey(p *artdesc.Platform) string {
+	return strings.Join([]string{p.OS, p.Architecture, p.Variant, p.OSVersion, strings.Join(p.OSFeatures, ",")}, "/")
+}
+
+func (s *Spec) GetBlob(ctx inputs.Context, info inputs.InputResourceInfo) (accessio.TemporaryBlobAccess, string, error) {
+	index := artdesc.NewIndexArtifact()
+	index.Index().Annotations = s.Annotations
+	platforms := map[string]int{}
+	i := 0
+
+	feedback := func(n int) artifactset.ArtifactFeedback {
+		v := &s.Variants[n]
+		return func(blob accessio.BlobAccess, art cpi.ArtifactAccess) error {
+			if !art.IsManifest() {
+				return errors.Newf("variant %d (%s) is no image manifest", n, v)
+			}
+			desc := artdesc.DefaultBlobDescriptor(blob)
+			desc.Platform = v.Platform
+			if desc.Platform == nil {
+				cfgBlob, err := art.ManifestAccess().GetConfigBlob()
+				if err != nil {
+					return errors.Wrapf(err, "cannot get config blob")
+				}
+				cfg, err := artdesc.ParseImageConfig(cfgBlob)
+				if err != nil {
+					return errors.Wrapf(err, "cannot parse config blob")
+				}
+				if cfg.Architecture == "" || cfg.OS == "" {
+					return errors.Newf("variant %d (%s) does not describe a platform", n, v)
+				}
+				desc.Platform = &artdesc.Platform{
+					Architecture: cfg.Architecture,
+					OS:           cfg.OS,
+					OSVersion:    cfg.OSVersion,
+					OSFeatures:   cfg.OSFeatures,
+					Variant:      cfg.Variant,
+				}
+			}
+			key := platformKey(desc.Platform)
+			if o, ok := platforms[key]; ok {
+				return errors.Newf("variant %d (%s) uses the same platform as variant %d", n, v, o)
+			}
+			platforms[key] = n
+			desc.Annotations = v.Annotations
+			index.Index().AddManifest(desc)
+			return nil
+		}
+	}
+
+	blob, err := artifactset.SynthesizeArtifactBlobFor(info.ComponentVersion.GetVersion(), func() (fac artifactset.ArtifactFactory, main bool, err error) {
+		var art cpi.ArtifactAccess
+		var blob accessio.BlobAccess
+
+		switch {
+		case i > len(s.Variants):
+			// end loop
+		case i == len(s.Variants):
+			// provide index (main) artifact
+			ctx.Printf("image %d: INDEX\n", i)
+			fac = func(set *artifactset.ArtifactSet) (digest.Digest, string, error) {
+				art, err = set.NewArtifact(index)
+				if err != nil {
+					return "", "", errors.Wrapf(err, "cannot create index artifact")
+				}
+				defer art.Close()
+				blob, err = set.AddArtifact(art)
+				if err != nil {
+					return "", "", errors.Wrapf(err, "cannot add index artifact")
+				}
+				defer blob.Close()
+				return blob.Digest(), blob.MimeType(), nil
+			}
+			main = true
+		default:
+			// provide variant
+			v := &s.Variants[i]
+			ctx.Printf("image %d: %s\n", i, v)
+			var finalize Finalizer
+
+			art, err = v.getArtifact(ctx, info, &finalize)
+			if err != nil {
+				finalize.Finalize()
+				err = errors.Wrapf(err, "variant %d (%s)", i, v)
+			} else {
+				fac = artifactset.ArtifactTransferCreator(art, &finalize, feedback(i))
+			}
+		}
+		i++
+		return
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return blob, ociartifact.Hint(info.ComponentVersion, info.ElementName, s.Repository, info.ComponentVersion.GetVersion()), nil
+}
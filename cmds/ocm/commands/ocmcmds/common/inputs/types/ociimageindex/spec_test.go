@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ociimageindex_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/ociimageindex"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/dockerarchive"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocilayout"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+)
+
+const CTF = "/tmp/ctf"
+const LAYOUT = "/tmp/layout"
+const ARCHIVE = "/tmp/image.tar"
+const CA = "/tmp/ca"
+const NS = "mandelsoft/test"
+
+func configData(arch string) string {
+	return fmt.Sprintf(`{"architecture":%q,"os":"linux","rootfs":{"type":"layers","diff_ids":[%q]}}`, arch, digest.FromString(arch))
+}
+
+var _ = Describe("oci image index input", func() {
+	var env *TestEnv
+	var ictx inputs.Context
+	var info inputs.InputResourceInfo
+	var cv *comparch.ComponentArchive
+
+	nv := common.NewNameVersion("test.de/x", "v1")
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		ictx = inputs.NewContext(env.Context, common.NewPrinter(nil), nil)
+
+		env.OCICommonTransport(CTF, accessio.FormatDirectory, func() {
+			env.Namespace(NS, func() {
+				for _, arch := range []string{"amd64", "arm64", "s390x"} {
+					env.Manifest(arch, func() {
+						env.Config(func() {
+							env.BlobStringData(ociv1.MediaTypeImageConfig, configData(arch))
+						})
+						env.Layer(func() {
+							env.BlobStringData(artdesc.MediaTypeImageLayer, arch)
+						})
+					})
+				}
+			})
+		})
+
+		src := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, CTF, 0, env))
+		defer Close(src)
+
+		// amd64 is provided by an OCI image layout
+		art := Must(src.LookupArtifact(NS, "amd64"))
+		spec := Must(ocilayout.NewRepositorySpec(accessobj.ACC_CREATE, LAYOUT, accessio.PathFileSystem(env.FileSystem())))
+		repo := Must(env.OCIContext().RepositoryForSpec(spec))
+		ns := Must(repo.LookupNamespace(""))
+		MustBeSuccessful(transfer.TransferArtifact(art, ns, "v1"))
+		MustBeSuccessful(ns.Close())
+		MustBeSuccessful(repo.Close())
+		MustBeSuccessful(art.Close())
+
+		// arm64 is provided by a docker archive
+		art = Must(src.LookupArtifact(NS, "arm64"))
+		repo = Must(env.OCIContext().RepositoryForSpec(dockerarchive.NewRepositorySpec(accessobj.ACC_CREATE, ARCHIVE, env.FileSystem())))
+		ns = Must(repo.LookupNamespace(NS))
+		MustBeSuccessful(transfer.TransferArtifact(art, ns, "v1"))
+		MustBeSuccessful(ns.Close())
+		MustBeSuccessful(repo.Close())
+		MustBeSuccessful(art.Close())
+
+		// s390x is provided by a resource of the component version
+		art = Must(src.LookupArtifact(NS, "s390x"))
+		blob := Must(artifactset.SynthesizeArtifactBlobForArtifact(art, "v1"))
+		cv = Must(comparch.Open(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, CA, 0o700, env))
+		cv.SetName(nv.GetName())
+		cv.SetVersion(nv.GetVersion())
+		meta := compdesc.NewResourceMeta("s390x", resourcetypes.OCI_IMAGE, metav1.LocalRelation)
+		MustBeSuccessful(cv.SetResourceBlob(meta, blob, "", nil))
+		MustBeSuccessful(blob.Close())
+		MustBeSuccessful(art.Close())
+
+		info = inputs.InputResourceInfo{
+			ComponentVersion:       nv,
+			ElementName:            "image",
+			InputFilePath:          "/tmp/dummy",
+			ComponentVersionAccess: cv,
+		}
+	})
+
+	AfterEach(func() {
+		if cv != nil {
+			MustBeSuccessful(cv.Close())
+		}
+		env.Cleanup()
+	})
+
+	It("composes an index from all kinds of variants", func() {
+		spec := ociimageindex.New(
+			ociimageindex.Variant{
+				OCILayout:   "layout",
+				Annotations: map[string]string{"build": "amd64"},
+			},
+			ociimageindex.Variant{
+				DockerArchive: ARCHIVE,
+				Image:         NS + ":v1",
+				Platform:      &artdesc.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"},
+			},
+			ociimageindex.Variant{
+				Resource: metav1.NewIdentity("s390x"),
+			},
+		)
+		spec.Annotations = map[string]string{"org.opencontainers.image.title": "test"}
+		Expect(spec.Validate(field.NewPath("input"), ictx, info.InputFilePath)).To(BeEmpty())
+
+		blob, hint, err := spec.GetBlob(ictx, info)
+		MustBeSuccessful(err)
+		defer Close(blob)
+		Expect(hint).To(Equal("test.de/x/image:v1"))
+
+		set := Must(artifactset.OpenFromBlob(accessobj.ACC_READONLY, blob))
+		defer Close(set)
+		art := Must(set.GetArtifact(set.GetMain().String()))
+		defer Close(art)
+		Expect(art.IsIndex()).To(BeTrue())
+		idx := art.IndexAccess().GetDescriptor()
+		Expect(idx.Annotations).To(Equal(spec.Annotations))
+		Expect(len(idx.Manifests)).To(Equal(3))
+		Expect(idx.Manifests[0].Platform).To(Equal(&artdesc.Platform{OS: "linux", Architecture: "amd64"}))
+		Expect(idx.Manifests[0].Annotations).To(Equal(map[string]string{"build": "amd64"}))
+		Expect(idx.Manifests[1].Platform).To(Equal(&artdesc.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}))
+		Expect(idx.Manifests[2].Platform).To(Equal(&artdesc.Platform{OS: "linux", Architecture: "s390x"}))
+
+		for i, arch := range []string{"amd64", "arm64", "s390x"} {
+			m := Must(art.GetArtifact(idx.Manifests[i].Digest))
+			layers := m.ManifestAccess().GetDescriptor().Layers
+			Expect(len(layers)).To(Equal(1))
+			Expect(layers[0].Digest).To(Equal(digest.FromString(arch)))
+			MustBeSuccessful(m.Close())
+		}
+	})
+
+	It("rejects duplicate platforms", func() {
+		spec := ociimageindex.New(
+			ociimageindex.Variant{OCILayout: LAYOUT},
+			ociimageindex.Variant{DockerArchive: ARCHIVE, Platform: &artdesc.Platform{OS: "linux", Architecture: "amd64"}},
+		)
+		_, _, err := spec.GetBlob(ictx, info)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("variant 1 (DockerArchive::/tmp/image.tar) uses the same platform as variant 0"))
+	})
+
+	It("requires a component version for resource variants", func() {
+		info.ComponentVersionAccess = nil
+		_, _, err := ociimageindex.New(ociimageindex.Variant{Resource: metav1.NewIdentity("s390x")}).GetBlob(ictx, info)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("no component version available to resolve resource"))
+	})
+
+	It("validates variants", func() {
+		spec := ociimageindex.New(
+			ociimageindex.Variant{},
+			ociimageindex.Variant{OCILayout: LAYOUT, DockerArchive: ARCHIVE},
+			ociimageindex.Variant{Resource: metav1.NewIdentity("s390x"), Image: "v1"},
+			ociimageindex.Variant{OCILayout: LAYOUT, Platform: &artdesc.Platform{OS: "linux"}},
+		)
+		errs := spec.Validate(field.NewPath("input"), ictx, info.InputFilePath)
+		Expect(errs.ToAggregate().Error()).To(Equal("[" +
+			"input.variants[0]: Required value: one of ociLayout, dockerArchive or resource is required, " +
+			"input.variants[1]: Invalid value: \"ociLayout, dockerArchive\": only one image source possible, " +
+			"input.variants[2].image: Forbidden: image selection not possible for resources, " +
+			"input.variants[3].platform.architecture: Required value: architecture is required" +
+			"]"))
+	})
+})
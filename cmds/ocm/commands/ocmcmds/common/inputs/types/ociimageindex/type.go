@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ociimageindex
+
+import (
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+)
+
+const TYPE = "ociImageIndex"
+
+func init() {
+	inputs.DefaultInputTypeScheme.Register(TYPE, inputs.NewInputType(TYPE, &Spec{}, usage, ConfigHandler()))
+}
+
+const usage = `
+This input type describes the composition of a multi-platform OCI image index
+from separately built per-platform images. In contrast to the input type
+<code>dockermulti</code> no docker daemon is required.
+The denoted images, as well as the wrapping image index is packed as OCI artifact set.
+
+This blob type specification supports the following fields:
+- **<code>variants</code>** *[]variant*
+
+  This REQUIRED property describes the list of images used to compose the
+  resulting image index. Every variant must use exactly one of the fields
+  <code>ociLayout</code>, <code>dockerArchive</code> or <code>resource</code>
+  to describe the image source:
+
+  - **<code>ociLayout</code>** *string*
+
+    The path of an OCI image layout (directory or archive).
+
+  - **<code>dockerArchive</code>** *string*
+
+    The path of an image archive as written by <code>docker save</code>.
+
+  - **<code>resource</code>** *map[string]string*
+
+    The identity of a resource of the same component version providing the
+    image. The resource must have been added before.
+
+  - **<code>image</code>** *string*
+
+    This OPTIONAL property selects the image. For OCI image layouts it is the
+    tag or digest, for docker archives the image name with tag. If not given,
+    the source must contain exactly one tagged image.
+
+  - **<code>platform</code>** *platform*
+
+    This OPTIONAL property describes the platform (fields <code>os</code>,
+    <code>architecture</code>, <code>variant</code>, <code>os.version</code>
+    and <code>os.features</code>) of the image. By default, it is taken from
+    the image config. Every platform may only be used once.
+
+  - **<code>annotations</code>** *map[string]string*
+
+    This OPTIONAL property describes the annotations of the image descriptor
+    in the image index.
+
+- **<code>annotations</code>** *map[string]string*
+
+  This OPTIONAL property describes the annotations of the image index.
+
+- **<code>repository</code>** *string*
+
+  This OPTIONAL property can be used to specify the repository hint for the
+  generated local artifact access. It is prefixed by the component name if
+  it does not start with slash "/".
+`
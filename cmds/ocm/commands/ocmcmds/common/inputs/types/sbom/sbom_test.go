@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/sbom"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+var _ = Describe("sbom input", func() {
+	var env *TestEnv
+	var ictx inputs.Context
+	var info inputs.InputResourceInfo
+
+	nv := common.NewNameVersion("test", "v1")
+
+	BeforeEach(func() {
+		info = inputs.InputResourceInfo{
+			ComponentVersion: nv,
+			ElementName:      "elemname",
+			InputFilePath:    "/testdata/dummy",
+		}
+		env = NewTestEnv(TestData())
+		ictx = inputs.NewContext(env.Context, common.NewPrinter(env.Context.StdOut()), nil)
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("provides SPDX document", func() {
+		blob, _, err := sbom.New("spdx.json", "", false).GetBlob(ictx, info)
+		Expect(err).To(Succeed())
+		defer blob.Close()
+		Expect(blob.MimeType()).To(Equal(mime.MIME_SPDX_JSON))
+	})
+
+	It("provides CycloneDX document", func() {
+		blob, _, err := sbom.New("cyclonedx.json", "", false).GetBlob(ictx, info)
+		Expect(err).To(Succeed())
+		defer blob.Close()
+		Expect(blob.MimeType()).To(Equal(mime.MIME_CYCLONEDX_JSON))
+	})
+
+	It("rejects invalid document", func() {
+		_, _, err := sbom.New("invalid.json", "", false).GetBlob(ictx, info)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unknown SBOM format"))
+	})
+
+	It("rejects mismatching media type", func() {
+		_, _, err := sbom.New("spdx.json", mime.MIME_CYCLONEDX_JSON, false).GetBlob(ictx, info)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`media type "application/vnd.cyclonedx+json" does not match SPDX document`))
+	})
+})
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/cpi"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/file"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/sbom"
+)
+
+type Spec struct {
+	cpi.MediaFileSpec `json:",inline"`
+}
+
+var _ inputs.InputSpec = (*Spec)(nil)
+
+func New(path, mediatype string, compress bool) *Spec {
+	return &Spec{
+		MediaFileSpec: cpi.NewMediaFileSpec(TYPE, path, mediatype, compress),
+	}
+}
+
+func (s *Spec) Validate(fldPath *field.Path, ctx inputs.Context, inputFilePath string) field.ErrorList {
+	allErrs := (&file.FileProcessSpec{s.MediaFileSpec, nil}).Validate(fldPath, ctx, inputFilePath)
+	if s.MediaType != "" && sbom.FormatForMediaType(s.MediaType) == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("mediaType"), s.MediaType, "no SBOM media type"))
+	}
+	return allErrs
+}
+
+func (s *Spec) GetBlob(ctx inputs.Context, info inputs.InputResourceInfo) (accessio.TemporaryBlobAccess, string, error) {
+	spec := &file.FileProcessSpec{MediaFileSpec: s.MediaFileSpec}
+	spec.Transformer = func(ctx inputs.Context, inputDir string, data []byte) ([]byte, error) {
+		doc, err := sbom.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		if spec.MediaType == "" {
+			spec.MediaType = doc.MediaType()
+		} else if f := sbom.FormatForMediaType(spec.MediaType); f != doc.Format {
+			return nil, errors.Newf("media type %q does not match %s document", spec.MediaType, doc.Format)
+		}
+		return data, nil
+	}
+	return spec.GetBlob(ctx, info)
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/file"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const TYPE = "sbom"
+
+func init() {
+	inputs.DefaultInputTypeScheme.Register(TYPE, inputs.NewInputType(TYPE, &Spec{}, usage(), ConfigHandler()))
+}
+
+func usage() string {
+	return file.Usage("The path must denote an SBOM document in JSON format relative the resources file.") + `
+The document must either be an [SPDX](https://spdx.dev) or a
+[CycloneDX](https://cyclonedx.org) document. It is validated and its
+format is used to determine the default media type
+(<code>` + mime.MIME_SPDX_JSON + `</code> or <code>` + mime.MIME_CYCLONEDX_JSON + `</code>).
+A given media type must match the format of the document.
+`
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deltaoption
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	Delta bool
+}
+
+var _ transferhandler.TransferOption = (*Option)(nil)
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&o.Delta, "delta", "", false, "skip local blobs already present in the target")
+}
+
+func (o *Option) Usage() string {
+	s := `
+If the option <code>--delta</code> is given, local blobs already present in the
+target repository, for example for overwritten component versions, are not
+copied again. The blobs are identified by their digest, and the number of bytes
+saved this way is reported at the end of the transfer. OCI artifacts transported
+by value into an OCI registry are skipped, if the target namespace already
+contains a manifest with the same digest.
+`
+	return s
+}
+
+func (o *Option) ApplyTransferOption(opts transferhandler.TransferOptions) error {
+	if !o.Delta {
+		return nil
+	}
+	return standard.Delta(o.Delta).ApplyTransferOption(opts)
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package planoption
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	Plan bool
+}
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&o.Plan, "plan", "", false, "show transfer plan instead of transferring")
+}
+
+func (o *Option) Usage() string {
+	s := `
+If the option <code>--plan</code> is given, nothing is transferred. Instead,
+the decisions of the transfer handler are evaluated and printed as transfer plan:
+the action for every component version (<code>transfer</code>,
+<code>overwrite</code> or <code>skip</code>), the transport mode for every
+resource and source (<code>local</code>, <code>value</code>,
+<code>reference</code> or <code>none</code>), the repository used to resolve
+followed component references and the number of bytes to be transported.
+Blob contents are never read for the plan, artifacts whose size is not
+provided by the source repository are reported with an unknown size.
+A non-existing file based target repository is considered to be empty.
+`
+	return s
+}
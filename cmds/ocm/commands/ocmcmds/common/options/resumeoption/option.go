@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resumeoption
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	Path    string
+	Journal *journal.Journal
+}
+
+var _ transferhandler.TransferOption = (*Option)(nil)
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Path, "resume", "", "", "transfer journal file used to record and resume the transfer")
+}
+
+func (o *Option) Configure(ctx clictx.Context) error {
+	if o.Path == "" || o.Journal != nil {
+		return nil
+	}
+	j, err := journal.Open(o.Path, ctx.FileSystem())
+	if err != nil {
+		return err
+	}
+	o.Journal = j
+	return nil
+}
+
+func (o *Option) Usage() string {
+	s := `
+If the option <code>--resume</code> is given, the progress of the transfer is
+recorded in the given journal file. If the file already exists, for example
+after an interrupted transfer, component versions recorded as finished are
+skipped, if they are found in the target repository. For resources and sources
+already transferred by the previous run, the recorded blob digest is used, if
+the target repository confirms the existence of the blob, to avoid copying it
+again. A journal is bound to the target repository it has been recorded for,
+it cannot be used to resume a transfer into another target repository.
+`
+	return s
+}
+
+func (o *Option) ApplyTransferOption(opts transferhandler.TransferOptions) error {
+	if o.Journal == nil {
+		return nil
+	}
+	return standard.Journal(o.Journal).ApplyTransferOption(opts)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
 	"github.com/open-component-model/ocm/pkg/contexts/clictx"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/verificationpolicyattr"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/normalizations/jsonv1"
 	ocmsign "github.com/open-component-model/ocm/pkg/contexts/ocm/signing"
@@ -57,8 +58,12 @@ type Option struct {
 	// will be used for signing
 	SignatureNames []string
 	Update         bool
-	Signer         signing.Signer
-	Keys           signing.KeyRegistry
+	// VerifyOCISignatures verifies cosign signatures of OCI image resources
+	VerifyOCISignatures bool
+	// Policy is the verification policy configured for the context
+	Policy *ocmsign.VerificationPolicy
+	Signer signing.Signer
+	Keys   signing.KeyRegistry
 
 	Hash hashoption.Option
 }
@@ -75,6 +80,7 @@ func (o *Option) AddFlags(fs *pflag.FlagSet) {
 		fs.BoolVarP(&o.Recursively, "recursive", "R", false, "recursively sign component versions")
 	} else {
 		fs.BoolVarP(&o.local, "local", "L", false, "verification based on information found in component versions, only")
+		fs.BoolVarP(&o.VerifyOCISignatures, "verify-oci-signatures", "", false, "verify cosign signatures of OCI image resources")
 	}
 	fs.BoolVarP(&o.Verify, "verify", "V", o.SignMode, "verify existing digests")
 	fs.StringArrayVarP(&o.rootca, "ca-cert", "", o.rootca, "Additional root certificates")
@@ -109,6 +115,7 @@ func (o *Option) Configure(ctx clictx.Context) error {
 		}
 	} else {
 		o.Recursively = !o.local
+		o.Policy = verificationpolicyattr.Get(ctx)
 	}
 
 	err := o.handleKeys(ctx, "public key", o.publicKeys, o.Keys.RegisterPublicKey)
@@ -211,6 +218,20 @@ The following hash modes are supported with option <code>--hash</code>:
 ` + utils.FormatList(sha256.Algorithm, signing.DefaultRegistry().HasherNames()...)
 
 		signing.DefaultRegistry().HasherNames()
+	} else {
+		s += `
+With option <code>--verify-oci-signatures</code> additionally the cosign
+signatures attached to the OCI image resources of the verified component
+versions are checked. They are looked up by the cosign signature tag or as
+referrers of the image and must be verifiable with one of the given public keys.
+
+If a verification policy is configured with the config type
+<code>` + verificationpolicyattr.ConfigType + `</code>, the signature verification
+is always enforced and the requirements of the policy rule matching a component
+version (required signatures, allowed issuers and certificate subjects,
+digest verification and skipped access types) are checked additionally
+to the given options.
+`
 	}
 	return s
 }
@@ -237,4 +258,8 @@ func (o *Option) ApplySigningOption(opts *ocmsign.Options) {
 		opts.VerifySignature = o.Keys.GetPublicKey(o.SignatureNames[0]) != nil
 	}
 	opts.Update = o.Update
+	opts.VerifyOCISignatures = o.VerifyOCISignatures
+	if o.Policy != nil {
+		opts.Policy = o.Policy
+	}
 }
@@ -118,6 +118,34 @@ are configured for the transport target. It has the following format
 The uploader name may be a path expression with the following possibilities:
 - <code>ocm/ociRegistry</code>: oci Registry upload for local OCI artifact blobs.
   The media type is optional. If given ist must be an OCI artifact media type.
+- <code>ocm/azureBlob</code>: upload of blobs into an Azure Blob Storage container.
+  The config requires the fields <code>account</code> and <code>container</code>
+  and optionally accepts <code>prefix</code> and <code>endpoint</code>.
+  The blobs are stored under their digest. Without artifact and media type
+  it is used for all resources.
+- <code>ocm/gcs</code>: upload of blobs into a Google Cloud Storage bucket.
+  The config requires the field <code>bucket</code> and optionally accepts
+  <code>prefix</code> and <code>endpoint</code>. The blobs are stored under
+  their digest. Without artifact and media type it is used for all resources.
+- <code>ocm/helmChartRepository</code>: upload of resources of type <code>helmChart</code>
+  into a ChartMuseum style helm chart repository. The config requires the field
+  <code>repoUrl</code>. The optional field <code>uploadUrl</code> overrides the
+  upload URL, which is otherwise derived as <code>&lt;server>/api/&lt;repo path>/charts</code>.
+  The artifact type defaults to <code>helmChart</code>.
+- <code>ocm/mavenRepository</code>: upload of resources of type <code>mavenArtifact</code>
+  into a Maven repository layout. The config requires the field <code>repoUrl</code>,
+  which may be a <code>file://</code> URL for a local file system repository.
+  The artifact type defaults to <code>mavenArtifact</code>.
+- <code>ocm/pypiRepository</code>: upload of resources of type <code>pythonPackage</code>
+  into a Python package index. The config requires the fields <code>url</code>
+  (upload URL of the legacy upload API) and <code>registry</code> (base URL of
+  the simple repository API). The artifact type defaults to <code>pythonPackage</code>.
+- <code>ocm/s3</code>: upload of blobs into an S3 bucket. The config requires
+  the field <code>bucket</code> and optionally accepts <code>region</code>,
+  <code>prefix</code> and <code>endpoint</code> (URL of an S3 compatible
+  service like MinIO). The blobs are stored under their digest, large blobs
+  are uploaded with multipart uploads. Without artifact and media type it is
+  used for all resources.
 - <code>plugin/<plugin name>[/<uploader name]</code>: uploader provided by plugin.
 `
 	return s
@@ -454,9 +454,10 @@ func ProcessElements(ictx inputs.Context, cv ocm.ComponentVersionAccess, elems [
 				var acc ocm.AccessSpec
 				// Local Blob
 				info := inputs.InputResourceInfo{
-					ComponentVersion: common.VersionedElementKey(cv),
-					ElementName:      elem.Spec().GetName(),
-					InputFilePath:    elem.Source().Origin(),
+					ComponentVersion:       common.VersionedElementKey(cv),
+					ElementName:            elem.Spec().GetName(),
+					InputFilePath:          elem.Source().Origin(),
+					ComponentVersionAccess: cv,
 				}
 				blob, hint, berr := elem.Input().Input.GetBlob(ictx, info)
 				if berr != nil {
@@ -14,6 +14,7 @@ import (
 	. "github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
 	. "github.com/open-component-model/ocm/pkg/testutils"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/mandelsoft/vfs/pkg/vfs"
 
 	"github.com/open-component-model/ocm/pkg/common/accessio"
@@ -26,6 +27,7 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
 	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/pgp"
 	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
 )
 
@@ -45,6 +47,9 @@ const SIGN_ALGO = rsa.Algorithm
 const PUBKEY = "/tmp/pub"
 const PRIVKEY = "/tmp/priv"
 
+const PGPPRIVKEY = "/tmp/pgp.priv"
+const PGPKEYRING = "/tmp/pubring.gpg"
+
 var _ = Describe("access method", func() {
 	var env *TestEnv
 
@@ -166,6 +171,41 @@ successfully signed github.com/mandelsoft/ref:v1 (digest SHA-256:` + digest + `)
 			Expect(cv.GetDescriptor().Signatures[0].Digest.Value).To(Equal(digest))
 		})
 
+		It("sign and verify component archive with pgp keys", func() {
+			prepareEnv(env, ARCH, ARCH)
+
+			e := Must(pgp.NewEntity("mandelsoft", "", "mandelsoft@example.com"))
+			MustBeSuccessful(vfs.WriteFile(env.FileSystem(), PGPPRIVKEY, Must(pgp.KeyData(e)), os.ModePerm))
+			keyring := bytes.NewBuffer(nil)
+			MustBeSuccessful(e.Serialize(keyring))
+			MustBeSuccessful(vfs.WriteFile(env.FileSystem(), PGPKEYRING, keyring.Bytes(), os.ModePerm))
+
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("sign", "components", "-S", pgp.Algorithm, "-s", SIGNATURE, "-K", PGPPRIVKEY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("successfully signed github.com/mandelsoft/ref:v1"))
+
+			session := datacontext.NewSession()
+			defer session.Close()
+
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+			session.AddCloser(src)
+			cv := Must(src.LookupComponentVersion(COMPONENTB, VERSION))
+			session.AddCloser(cv)
+			sig := cv.GetDescriptor().Signatures[0].Signature
+			Expect(sig.Algorithm).To(Equal(pgp.Algorithm))
+			Expect(sig.MediaType).To(Equal(pgp.MediaType))
+			Expect(sig.Issuer).To(Equal(pgp.Fingerprint(e)))
+			Expect(sig.Value).To(HavePrefix("-----BEGIN PGP SIGNATURE-----"))
+
+			buf.Reset()
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "-s", SIGNATURE, "-k", PGPKEYRING, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("successfully verified github.com/mandelsoft/ref:v1"))
+
+			other := Must(pgp.NewEntity("other", "", ""))
+			MustBeSuccessful(vfs.WriteFile(env.FileSystem(), PGPKEYRING, Must(pgp.KeyData(openpgp.EntityList{other})), os.ModePerm))
+			buf.Reset()
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "-s", SIGNATURE, "-k", PGPKEYRING, "--repo", ARCH, COMPONENTB+":"+VERSION)).NotTo(Succeed())
+		})
 	})
 
 	Context("incomplete ctf", func() {
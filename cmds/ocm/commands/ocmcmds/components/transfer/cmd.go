@@ -7,15 +7,21 @@ package transfer
 import (
 	"fmt"
 
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
 	"github.com/spf13/cobra"
 
 	"github.com/open-component-model/ocm/cmds/ocm/commands/common/options/closureoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/common/options/formatoption"
 	ocmcommon "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/deltaoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/overwriteoption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/paralleloption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/planoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/resumeoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/rscbyvalueoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/scriptoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/stoponexistingoption"
@@ -26,12 +32,16 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/output"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
 	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
 	"github.com/open-component-model/ocm/pkg/contexts/clictx"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/spiff"
 	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/out"
 )
 
 var (
@@ -59,6 +69,11 @@ func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
 		stoponexistingoption.New(),
 		uploaderoption.New(),
 		scriptoption.New(),
+		paralleloption.New(),
+		resumeoption.New(),
+		deltaoption.New(),
+		planoption.New(),
+		output.OutputOptions(planOutputs),
 	)}, utils.Names(Names, names...)...)
 }
 
@@ -75,6 +90,7 @@ are transferred.
 		Example: `
 $ ocm transfer components -t tgz ghcr.io/mandelsoft/kubelink ctf.tgz
 $ ocm transfer components -t tgz --repo OCIRegistry:ghcr.io mandelsoft/kubelink ctf.tgz
+$ ocm transfer components --plan -o yaml --copy-resources ghcr.io/mandelsoft/kubelink ghcr.io/target
 `,
 	}
 }
@@ -85,6 +101,9 @@ func (o *Command) Complete(args []string) error {
 		return fmt.Errorf("a repository or at least one argument that defines the reference is required")
 	}
 	o.TargetName = args[len(args)-1]
+	if output.From(o).OutputMode != "" && !planoption.From(o).Plan {
+		return fmt.Errorf("--output only usable for plan mode")
+	}
 	return nil
 }
 
@@ -103,7 +122,12 @@ func (o *Command) Run() error {
 		return err
 	}
 
-	target, err := ocm.AssureTargetRepository(session, o.Context.OCMContext(), o.TargetName, ocm.CommonTransportFormat, formatoption.From(o).ChangedFormat(), o.Context.FileSystem())
+	var target ocm.Repository
+	if planoption.From(o).Plan {
+		target, err = o.planTarget(session)
+	} else {
+		target, err = ocm.AssureTargetRepository(session, o.Context.OCMContext(), o.TargetName, ocm.CommonTransportFormat, formatoption.From(o).ChangedFormat(), o.Context.FileSystem())
+	}
 	if err != nil {
 		return err
 	}
@@ -114,6 +138,9 @@ func (o *Command) Run() error {
 		overwriteoption.From(o),
 		rscbyvalueoption.From(o),
 		stoponexistingoption.From(o),
+		paralleloption.From(o),
+		resumeoption.From(o),
+		deltaoption.From(o),
 		spiff.Script(scriptoption.From(o).ScriptData),
 		spiff.ScriptFilesystem(o.FileSystem()),
 	)
@@ -121,6 +148,20 @@ func (o *Command) Run() error {
 		return err
 	}
 	hdlr := comphdlr.NewTypeHandler(o.Context.OCM(), session, repooption.From(o).Repository, comphdlr.OptionsFor(o))
+	if planoption.From(o).Plan {
+		err = utils.HandleOutput(&planAction{
+			cmd:     o,
+			out:     output.From(o).Output,
+			target:  target,
+			handler: thdlr,
+			closure: transfer.TransportClosure{},
+			errors:  errors.ErrListf("plan errors"),
+		}, hdlr, utils.StringElemSpecs(o.Refs...)...)
+		if err != nil {
+			return err
+		}
+		return session.Close()
+	}
 	err = utils.HandleOutput(&action{
 		cmd:     o,
 		printer: common.NewPrinter(o.Context.StdOut()),
@@ -135,6 +176,29 @@ func (o *Command) Run() error {
 	return session.Close()
 }
 
+// planTarget provides the target repository for plan mode without creating
+// anything. A non-existing file based target is replaced by an empty
+// in-memory transport archive.
+func (o *Command) planTarget(session ocm.Session) (ocm.Repository, error) {
+	ref, err := ocm.ParseRepo(o.TargetName)
+	if err == nil && ref.Info != "" {
+		if ok, err := vfs.Exists(o.Context.FileSystem(), ref.Info); err == nil && !ok {
+			fs := memoryfs.New()
+			err = fs.MkdirAll(vfs.Dir(fs, ref.Info), 0o770)
+			if err != nil {
+				return nil, err
+			}
+			target, err := ctf.Create(o.Context.OCMContext(), accessobj.ACC_CREATE, ref.Info, 0o770, accessio.PathFileSystem(fs))
+			if err != nil {
+				return nil, err
+			}
+			session.Closer(target)
+			return target, nil
+		}
+	}
+	return ocm.AssureTargetRepository(session, o.Context.OCMContext(), o.TargetName, ocm.CommonTransportFormat, formatoption.From(o).ChangedFormat(), o.Context.FileSystem())
+}
+
 /////////////////////////////////////////////////////////////////////////////
 
 type action struct {
@@ -167,8 +231,133 @@ func (a *action) Close() error {
 
 func (a *action) Out() error {
 	a.printer.Printf("%d versions transferred\n", len(a.closure))
+	if deltaoption.From(a.cmd).Delta {
+		a.printer.Printf("%d bytes saved by delta transfer\n", transferhandler.GetSavedBytes(a.handler))
+	}
 	if a.errors.Result() != nil {
 		return fmt.Errorf("transfer finished with %d error(s)", a.errors.Len())
 	}
 	return nil
 }
+
+/////////////////////////////////////////////////////////////////////////////
+
+type planAction struct {
+	cmd     *Command
+	out     output.Output
+	target  ocm.Repository
+	handler transferhandler.TransferHandler
+	closure transfer.TransportClosure
+	errors  *errors.ErrorList
+	count   int
+	size    int64
+	unknown int
+}
+
+var _ output.Output = (*planAction)(nil)
+
+func (a *planAction) Add(e interface{}) error {
+	o, ok := e.(*comphdlr.Object)
+	if !ok {
+		return fmt.Errorf("object of type %T is not a valid comphdlr.Object", e)
+	}
+	plan, err := transfer.PlanVersion(a.closure, o.ComponentVersion, a.target, a.handler)
+	if err != nil {
+		a.errors.Add(err)
+		out.Errf(a.cmd.Context, "Error: %s\n", err)
+		return nil
+	}
+	for _, v := range plan.Versions {
+		a.count++
+		a.size += v.Size()
+		a.unknown += v.UnknownSizes()
+		err = a.out.Add(&planEntry{v})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *planAction) Close() error {
+	return a.out.Close()
+}
+
+func (a *planAction) Out() error {
+	err := a.out.Out()
+	if err != nil {
+		return err
+	}
+	if output.From(a.cmd).OutputMode == "" {
+		if a.unknown > 0 {
+			out.Outf(a.cmd.Context, "%d versions planned, %d bytes and %d artifact(s) of unknown size to transfer\n", a.count, a.size, a.unknown)
+		} else {
+			out.Outf(a.cmd.Context, "%d versions planned, %d bytes to transfer\n", a.count, a.size)
+		}
+	}
+	if a.errors.Result() != nil {
+		return fmt.Errorf("planning finished with %d error(s)", a.errors.Len())
+	}
+	return nil
+}
+
+// planSize formats the planned transfer size of a version,
+// artifacts with unknown size are marked separately.
+func planSize(v *transfer.VersionPlan) string {
+	if n := v.UnknownSizes(); n > 0 {
+		return fmt.Sprintf("%d+%d unknown", v.Size(), n)
+	}
+	return fmt.Sprintf("%d", v.Size())
+}
+
+type planEntry struct {
+	*transfer.VersionPlan
+}
+
+var _ output.Manifest = (*planEntry)(nil)
+
+func (e *planEntry) AsManifest() interface{} {
+	return e.VersionPlan
+}
+
+var planOutputs = output.NewOutputs(getPlanRegular).AddManifestOutputs()
+
+// planTable hides the sort fields of the table output, the --sort option
+// would collide with the transfer script options.
+type planTable struct {
+	output.Output
+}
+
+func getPlanRegular(opts *output.Options) output.Output {
+	return &planTable{(&output.TableOutput{
+		Headers: output.Fields("COMPONENT", "VERSION", "ACTION", "BY VALUE", "REFERENCES", "SIZE"),
+		Options: opts,
+		Mapping: mapPlanRegularOutput,
+	}).New()}
+}
+
+func mapPlanRegularOutput(e interface{}) interface{} {
+	p := e.(*planEntry)
+
+	value, all := 0, 0
+	for _, list := range [][]*transfer.ArtifactPlan{p.Resources, p.Sources} {
+		for _, a := range list {
+			all++
+			if a.Mode == transfer.MODE_LOCAL || a.Mode == transfer.MODE_VALUE {
+				value++
+			}
+		}
+	}
+	follow := 0
+	for _, r := range p.References {
+		if r.Transfer {
+			follow++
+		}
+	}
+	return []string{
+		p.Component, p.Version, p.Action,
+		fmt.Sprintf("%d/%d", value, all),
+		fmt.Sprintf("%d/%d", follow, len(p.References)),
+		planSize(p.VersionPlan),
+	}
+}
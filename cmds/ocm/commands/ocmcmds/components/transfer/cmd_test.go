@@ -15,6 +15,9 @@ import (
 	. "github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
 	. "github.com/open-component-model/ocm/pkg/testutils"
 
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common"
 	"github.com/open-component-model/ocm/pkg/common/accessio"
 	"github.com/open-component-model/ocm/pkg/common/accessobj"
 	"github.com/open-component-model/ocm/pkg/contexts/oci"
@@ -25,6 +28,7 @@ import (
 	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 	ctfocm "github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
 	"github.com/open-component-model/ocm/pkg/mime"
 )
 
@@ -37,6 +41,7 @@ const COMPONENT2 = "github.com/mandelsoft/test2"
 const OUT = "/tmp/res"
 const OCIPATH = "/tmp/oci"
 const OCIHOST = "alias"
+const JOURNAL = "/tmp/journal"
 
 func Check(env *TestEnv, ldesc *artdesc.Descriptor, out string) {
 	tgt, err := ctfocm.Open(env.OCMContext(), accessobj.ACC_READONLY, out, 0, accessio.PathFileSystem(env.FileSystem()))
@@ -151,6 +156,188 @@ transferring version "github.com/mandelsoft/test:v1"...
 		Check(env, ldesc, OUT)
 	})
 
+	It("transfers ctf with --parallel", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--parallel", "3", "--recursive", "--lookup", ARCH, ARCH2, ARCH2, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test2:v1"...
+  transferring version "github.com/mandelsoft/test:v1"...
+  ...resource 0...
+  ...resource 1(ocm/value:v2.0)...
+  ...resource 2(ocm/ref:v2.0)...
+  ...adding component version...
+...adding component version...
+2 versions transferred
+`))
+
+		Expect(env.DirExists(OUT)).To(BeTrue())
+		tgt, err := ctfocm.Open(env.OCMContext(), accessobj.ACC_READONLY, OUT, 0, accessio.PathFileSystem(env.FileSystem()))
+		Expect(err).To(Succeed())
+		defer Close(tgt, "ctf")
+		CheckComponent(env, ldesc, tgt)
+	})
+
+	It("resumes transfer with --resume", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--resume", JOURNAL, ARCH, ARCH, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+...resource 0...
+...resource 1(ocm/value:v2.0)...
+...resource 2(ocm/ref:v2.0)...
+...adding component version...
+1 versions transferred
+`))
+		Expect(env.FileExists(JOURNAL)).To(BeTrue())
+		j, err := journal.Open(JOURNAL, env.FileSystem())
+		Expect(err).To(Succeed())
+		Expect(j.IsFinished(common.NewNameVersion(COMPONENT, VERSION))).To(BeTrue())
+
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--resume", JOURNAL, ARCH, ARCH, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+  version "github.com/mandelsoft/test:v1" already transferred according to journal -> skip transport
+1 versions transferred
+`))
+		Check(env, ldesc, OUT)
+	})
+
+	It("reuses recorded blob digests with --resume", func() {
+		j, err := journal.Open(JOURNAL, env.FileSystem())
+		Expect(err).To(Succeed())
+		nv := common.NewNameVersion(COMPONENT, VERSION)
+		Expect(j.AddArtifact(nv, journal.KIND_RESOURCE, metav1.NewIdentity("testdata"), &journal.Artifact{
+			Access:    []byte(`{"localReference":"sha256:810ff2fb242a5dee4220f2cb0e6a519891fb67f2f828a6cab4ef8894633b1f50","mediaType":"text/plain","type":"localBlob"}`),
+			Digest:    digest.FromString("testdata"),
+			Size:      8,
+			MediaType: mime.MIME_TEXT,
+		})).To(Succeed())
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--resume", JOURNAL, ARCH, ARCH, OUT)).To(Succeed())
+		Check(env, ldesc, OUT)
+
+		tgt, err := ctfocm.Open(env.OCMContext(), accessobj.ACC_READONLY, OUT, 0, accessio.PathFileSystem(env.FileSystem()))
+		Expect(err).To(Succeed())
+		defer Close(tgt, "ctf")
+		cv, err := tgt.LookupComponentVersion(COMPONENT, VERSION)
+		Expect(err).To(Succeed())
+		defer Close(cv, "compvers")
+		data, err := json.Marshal(cv.GetDescriptor().Resources[0].Access)
+		Expect(err).To(Succeed())
+		Expect(string(data)).To(ContainSubstring(digest.FromString("testdata").Hex()))
+
+		j, err = journal.Open(JOURNAL, env.FileSystem())
+		Expect(err).To(Succeed())
+		Expect(j.IsFinished(nv)).To(BeTrue())
+	})
+
+	It("ignores recorded blob digests not found in target", func() {
+		j, err := journal.Open(JOURNAL, env.FileSystem())
+		Expect(err).To(Succeed())
+		nv := common.NewNameVersion(COMPONENT, VERSION)
+		Expect(j.AddArtifact(nv, journal.KIND_RESOURCE, metav1.NewIdentity("testdata"), &journal.Artifact{
+			Access:    []byte(`{"localReference":"sha256:810ff2fb242a5dee4220f2cb0e6a519891fb67f2f828a6cab4ef8894633b1f50","mediaType":"text/plain","type":"localBlob"}`),
+			Digest:    digest.FromString("changed"),
+			Size:      7,
+			MediaType: mime.MIME_TEXT,
+		})).To(Succeed())
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--resume", JOURNAL, ARCH, ARCH, OUT)).To(Succeed())
+		Check(env, ldesc, OUT)
+
+		tgt, err := ctfocm.Open(env.OCMContext(), accessobj.ACC_READONLY, OUT, 0, accessio.PathFileSystem(env.FileSystem()))
+		Expect(err).To(Succeed())
+		defer Close(tgt, "ctf")
+		cv, err := tgt.LookupComponentVersion(COMPONENT, VERSION)
+		Expect(err).To(Succeed())
+		defer Close(cv, "compvers")
+		data, err := json.Marshal(cv.GetDescriptor().Resources[0].Access)
+		Expect(err).To(Succeed())
+		Expect(string(data)).To(ContainSubstring(digest.FromString("testdata").Hex()))
+		Expect(string(data)).NotTo(ContainSubstring(digest.FromString("changed").Hex()))
+	})
+
+	It("rejects a journal recorded for another target", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--resume", JOURNAL, ARCH, ARCH, OUT)).To(Succeed())
+
+		buf.Reset()
+		err := env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--resume", JOURNAL, ARCH, ARCH, OUT+"2")
+		Expect(err).To(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring("has been recorded for a different target repository"))
+	})
+
+	It("skips unchanged blobs with --delta", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", ARCH, ARCH, OUT)).To(Succeed())
+
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--overwrite", "--delta", ARCH, ARCH, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+...resource 0...
+...resource 1(ocm/value:v2.0)...
+...resource 2(ocm/ref:v2.0)...
+...adding component version...
+1 versions transferred
+1271 bytes saved by delta transfer
+`))
+		Check(env, ldesc, OUT)
+	})
+
+	It("plans transfer with --plan", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--plan", "--copy-resources", "--recursive", "--lookup", ARCH, ARCH2, ARCH2, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+COMPONENT                   VERSION ACTION   BY VALUE REFERENCES SIZE
+github.com/mandelsoft/test2 v1      transfer 0/0      1/1        0
+github.com/mandelsoft/test  v1      transfer 3/3      0/0        721
+2 versions planned, 721 bytes to transfer
+`))
+		Expect(env.DirExists(OUT)).To(BeFalse())
+	})
+
+	It("plans transfer with --plan as yaml", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--plan", "-o", "yaml", ARCH, ARCH, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+---
+action: transfer
+component: github.com/mandelsoft/test
+resources:
+- accessType: localBlob
+  index: 0
+  mode: local
+  name: testdata
+  size: 8
+  type: PlainText
+  version: v1
+- accessType: ociArtifact
+  index: 1
+  mode: reference
+  name: value
+  size: 0
+  type: ociImage
+  version: v1
+- accessType: ociArtifact
+  index: 2
+  mode: reference
+  name: ref
+  size: 0
+  type: ociImage
+  version: v1
+version: v1
+`))
+		Expect(env.DirExists(OUT)).To(BeFalse())
+	})
+
+	It("rejects --output without --plan", func() {
+		Expect(env.Execute("transfer", "components", "-o", "yaml", ARCH, ARCH, OUT)).To(MatchError("--output only usable for plan mode"))
+	})
+
 	It("transfers ctf with --closure --lookup", func() {
 		buf := bytes.NewBuffer(nil)
 		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--recursive", "--lookup", ARCH, ARCH2, ARCH2, OUT)).To(Succeed())
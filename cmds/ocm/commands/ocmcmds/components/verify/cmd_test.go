@@ -6,11 +6,14 @@ package verify_test
 
 import (
 	"bytes"
+	"net/http"
 	"os"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf/testhelper"
 	. "github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
 	. "github.com/open-component-model/ocm/pkg/contexts/ocm/signing"
 	. "github.com/open-component-model/ocm/pkg/testutils"
@@ -22,9 +25,13 @@ import (
 	"github.com/open-component-model/ocm/pkg/common/accessobj"
 	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
 	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cosign"
+	ocictf "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocireg"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/verificationpolicyattr"
 	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
@@ -41,6 +48,7 @@ const COMPONENTB = "github.com/mandelsoft/ref"
 const OUT = "/tmp/res"
 const OCIPATH = "/tmp/oci"
 const OCIHOST = "alias"
+const REGARCH = "/tmp/regctf"
 
 const SIGNATURE = "test"
 const SIGN_ALGO = rsa.Algorithm
@@ -48,6 +56,16 @@ const SIGN_ALGO = rsa.Algorithm
 const PUBKEY = "/tmp/pub"
 const PRIVKEY = "/tmp/priv"
 
+func cosignImage(env *TestEnv, namespace string, key interface{}) {
+	repo := Must(ocictf.Open(env.OCIContext(), accessobj.ACC_WRITABLE, OCIPATH, 0, env))
+	defer Close(repo)
+	ns := Must(repo.LookupNamespace(namespace))
+	defer Close(ns)
+	art := Must(ns.GetArtifact(OCIVERSION))
+	defer Close(art)
+	MustBeSuccessful(cosign.Sign(ns, art.Digest(), oci.StandardOCIRef(OCIHOST+".alias", namespace, OCIVERSION), key))
+}
+
 var _ = Describe("access method", func() {
 	var (
 		env *TestEnv
@@ -155,4 +173,167 @@ applying to version "github.com/mandelsoft/ref:v1"...
 successfully verified github.com/mandelsoft/ref:v1 (digest SHA-256:` + digest + `)
 `))
 	})
+
+	Context("OCI signatures", func() {
+		BeforeEach(func() {
+			session := datacontext.NewSession()
+			defer session.Close()
+
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			session.AddCloser(src)
+			cv := Must(src.LookupComponentVersion(COMPONENTA, VERSION))
+			session.AddCloser(cv)
+
+			opts := NewOptions(
+				Sign(signing.DefaultHandlerRegistry().GetSigner(SIGN_ALGO), SIGNATURE),
+				PrivateKey(SIGNATURE, priv),
+				Update(), VerifyDigests(),
+			)
+			MustBeSuccessful(opts.Complete(signingattr.Get(DefaultContext)))
+			Must(Apply(nil, nil, cv, opts))
+		})
+
+		It("verifies cosign signatures of OCI image resources", func() {
+			buf := bytes.NewBuffer(nil)
+			cosignImage(env, OCINAMESPACE, priv)
+			cosignImage(env, OCINAMESPACE2, priv)
+
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--verify-oci-signatures", "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTA+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`resource 1:  "name"="value": OCI signature verified`))
+			Expect(buf.String()).To(ContainSubstring(`resource 2:  "name"="ref": OCI signature verified`))
+			Expect(buf.String()).To(ContainSubstring("successfully verified " + COMPONENTA + ":" + VERSION))
+		})
+
+		It("verifies cosign signatures without fetching the image content", func() {
+			registry := NewFakeRegistry()
+			defer registry.Close()
+			host := strings.TrimPrefix(registry.URL, "http://")
+			env.OCIContext().SetAlias(host, ocireg.NewRepositorySpec(registry.URL))
+
+			repo := Must(env.OCIContext().RepositoryForSpec(ocireg.NewRepositorySpec(registry.URL)))
+			defer Close(repo)
+			ns := Must(repo.LookupNamespace(OCINAMESPACE))
+			defer Close(ns)
+			DefaultManifestFill(ns)
+			art := Must(ns.GetArtifact(TAG))
+			defer Close(art)
+			MustBeSuccessful(cosign.Sign(ns, art.Digest(), oci.StandardOCIRef(host, OCINAMESPACE, TAG), priv))
+
+			env.OCMCommonTransport(REGARCH, accessio.FormatDirectory, func() {
+				env.Component(COMPONENTA, func() {
+					env.Version(VERSION, func() {
+						env.Provider(PROVIDER)
+						env.Resource("image", "", resourcetypes.OCI_IMAGE, metav1.LocalRelation, func() {
+							env.Access(ociartifact.New(oci.StandardOCIRef(host, OCINAMESPACE, TAG)))
+						})
+					})
+				})
+			})
+
+			session := datacontext.NewSession()
+			defer session.Close()
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, REGARCH, 0, env))
+			session.AddCloser(src)
+			cv := Must(src.LookupComponentVersion(COMPONENTA, VERSION))
+			session.AddCloser(cv)
+			opts := NewOptions(
+				Sign(signing.DefaultHandlerRegistry().GetSigner(SIGN_ALGO), SIGNATURE),
+				PrivateKey(SIGNATURE, priv),
+				Update(), VerifyDigests(),
+			)
+			MustBeSuccessful(opts.Complete(signingattr.Get(DefaultContext)))
+			Must(Apply(nil, nil, cv, opts))
+			MustBeSuccessful(session.Close())
+
+			// the image layers must not be required for the verification
+			registry.Filter = func(w http.ResponseWriter, r *http.Request) bool {
+				if strings.HasSuffix(r.URL.Path, "/blobs/sha256:"+DIGEST_LAYER) {
+					w.WriteHeader(http.StatusForbidden)
+					return false
+				}
+				return true
+			}
+
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--verify-oci-signatures", "-s", SIGNATURE, "-k", PUBKEY, "--repo", REGARCH, COMPONENTA+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`resource 0:  "name"="image": OCI signature verified`))
+		})
+
+		It("fails for unsigned OCI image resources", func() {
+			buf := bytes.NewBuffer(nil)
+			cosignImage(env, OCINAMESPACE, priv)
+
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--verify-oci-signatures", "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTA+":"+VERSION)).NotTo(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`resource 1:  "name"="value": OCI signature verified`))
+			Expect(buf.String()).To(ContainSubstring(`failed verifying OCI signature for resource ref:v1`))
+			Expect(buf.String()).To(ContainSubstring("cosign signature"))
+		})
+
+		It("fails for OCI signatures of other keys", func() {
+			buf := bytes.NewBuffer(nil)
+			other, _, err := rsa.Handler{}.CreateKeyPair()
+			MustBeSuccessful(err)
+			cosignImage(env, OCINAMESPACE, other)
+			cosignImage(env, OCINAMESPACE2, priv)
+
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--verify-oci-signatures", "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTA+":"+VERSION)).NotTo(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`failed verifying OCI signature for resource value:v1`))
+		})
+	})
+
+	Context("verification policy", func() {
+		BeforeEach(func() {
+			session := datacontext.NewSession()
+			defer session.Close()
+
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			session.AddCloser(src)
+			cv := Must(src.LookupComponentVersion(COMPONENTA, VERSION))
+			session.AddCloser(cv)
+
+			opts := NewOptions(
+				Sign(signing.DefaultHandlerRegistry().GetSigner(SIGN_ALGO), SIGNATURE),
+				Issuer("acme"),
+				PrivateKey(SIGNATURE, priv),
+				Update(), VerifyDigests(),
+			)
+			MustBeSuccessful(opts.Complete(signingattr.Get(DefaultContext)))
+			Must(Apply(nil, nil, cv, opts))
+		})
+
+		It("verifies signatures required by policy", func() {
+			buf := bytes.NewBuffer(nil)
+			MustBeSuccessful(env.ConfigContext().ApplyConfig(verificationpolicyattr.New(PolicyRule{
+				Component:  "github.com/mandelsoft/*",
+				Signatures: []string{SIGNATURE},
+				Issuers:    []string{"acme"},
+			}), "policy"))
+
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "-k", SIGNATURE+"="+PUBKEY, "--repo", ARCH, COMPONENTA+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`using verification policy rule "github.com/mandelsoft/*"`))
+			Expect(buf.String()).To(ContainSubstring("successfully verified " + COMPONENTA + ":" + VERSION))
+		})
+
+		It("fails for missing signature required by policy", func() {
+			buf := bytes.NewBuffer(nil)
+			MustBeSuccessful(env.ConfigContext().ApplyConfig(verificationpolicyattr.New(PolicyRule{
+				Component:  "github.com/mandelsoft/*",
+				Signatures: []string{"other"},
+			}), "policy"))
+
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTA+":"+VERSION)).NotTo(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`signature "other" not found`))
+		})
+
+		It("fails for issuer not allowed by policy", func() {
+			buf := bytes.NewBuffer(nil)
+			MustBeSuccessful(env.ConfigContext().ApplyConfig(verificationpolicyattr.New(PolicyRule{
+				Component: "*",
+				Issuers:   []string{"mandelsoft"},
+			}), "policy"))
+
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTA+":"+VERSION)).NotTo(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`issuer "acme" of signature "test" not allowed by policy`))
+		})
+	})
 })
@@ -10,8 +10,10 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/commands/common/options/closureoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/common/options/formatoption"
 	ocmcommon "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/deltaoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/overwriteoption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/resumeoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/rscbyvalueoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/scriptoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/stoponexistingoption"
@@ -53,6 +55,8 @@ func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
 		stoponexistingoption.New(),
 		uploaderoption.New(),
 		scriptoption.New(),
+		resumeoption.New(),
+		deltaoption.New(),
 	)}, utils.Names(Names, names...)...)
 }
 
@@ -106,12 +110,15 @@ func (o *Command) Run() error {
 		rscbyvalueoption.From(o),
 		stoponexistingoption.From(o),
 		overwriteoption.From(o),
+		resumeoption.From(o),
+		deltaoption.From(o),
 		spiff.ScriptFilesystem(o.FileSystem()),
 	)
 	if err != nil {
 		return err
 	}
 	a := &action{
+		delta:   deltaoption.From(o).Delta,
 		printer: common.NewPrinter(o.Context.StdOut()),
 		target:  target,
 		handler: thdlr,
@@ -124,6 +131,7 @@ func (o *Command) Run() error {
 /////////////////////////////////////////////////////////////////////////////
 
 type action struct {
+	delta   bool
 	printer common.Printer
 	target  ocm.Repository
 	handler transferhandler.TransferHandler
@@ -132,5 +140,9 @@ type action struct {
 }
 
 func (a *action) Execute(src ocm.Repository) error {
-	return transfer.TransferComponents(a.printer, a.closure, src, "", true, a.target, a.handler)
+	err := transfer.TransferComponents(a.printer, a.closure, src, "", true, a.target, a.handler)
+	if a.delta {
+		a.printer.Printf("%d bytes saved by delta transfer\n", transferhandler.GetSavedBytes(a.handler))
+	}
+	return err
 }
@@ -7,6 +7,7 @@ package add_test
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -24,11 +25,13 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localblob"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/wget"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
 	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
 	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
 )
 
 const ARCH = "/tmp/ca"
@@ -279,6 +282,37 @@ imageReference: ghcr.io/mandelsoft/pause:v0.1.0
 			Expect(acc.(*ociartifact.AccessSpec).ImageReference).To(Equal("ghcr.io/mandelsoft/pause:v0.1.0"))
 		})
 
+		It("adds http resource by options", func() {
+			Expect(env.Execute("add", "resources", "--file", ARCH,
+				"--type", "blob",
+				"--name", "blob",
+				"--version", "v0.1.0",
+				"--accessType", "http",
+				"--url", "https://example.com/blob.tgz",
+				"--mediaType", "application/x-tgz",
+				"--header", "X-Test=value",
+				"--digest", "sha256:"+strings.Repeat("0", 64))).To(Succeed())
+			data, err := env.ReadFile(env.Join(ARCH, comparch.ComponentDescriptorFileName))
+			Expect(err).To(Succeed())
+			cd, err := compdesc.Decode(data)
+			Expect(err).To(Succeed())
+			Expect(len(cd.Resources)).To(Equal(1))
+
+			r, err := cd.GetResourceByIdentity(metav1.NewIdentity("blob"))
+			Expect(err).To(Succeed())
+			Expect(r.Relation).To(Equal(metav1.ResourceRelation("external")))
+
+			acc, err := env.OCMContext().AccessSpecForSpec(r.Access)
+			Expect(err).To(Succeed())
+			Expect(acc).To(Equal(&wget.AccessSpec{
+				ObjectVersionedType: runtime.NewVersionedObjectType("http"),
+				URL:                 "https://example.com/blob.tgz",
+				MediaType:           "application/x-tgz",
+				Header:              map[string]string{"X-Test": "value"},
+				Digest:              "sha256:" + strings.Repeat("0", 64),
+			}))
+		})
+
 		It("adds simple text blob with metadata via explicit options", func() {
 			input := `
 { "type": "file", "path": "testdata/testcontent", "mediaType": "text/plain" }
@@ -33,9 +33,11 @@ import (
 	v1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/consts"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/download"
+	sbomhdlr "github.com/open-component-model/ocm/pkg/contexts/ocm/download/handlers/sbom"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
 	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/out"
+	"github.com/open-component-model/ocm/pkg/sbom"
 )
 
 var (
@@ -126,6 +128,9 @@ func (o *Command) Complete(args []string) error {
 
 func (o *Command) handlerOptions() []elemhdlr.Option {
 	hopts := common.OptionsFor(o)
+	if From(output.From(o)).MergeSBOMs && len(o.ResourceTypes) == 0 {
+		o.ResourceTypes = []string{resourcetypes.SBOM}
+	}
 	if len(o.ResourceTypes) > 0 {
 		hopts = append(hopts, common.WithTypes(o.ResourceTypes))
 	}
@@ -178,6 +183,9 @@ func (d *action) Close() error {
 func (d *action) Out() error {
 	list := errors.ErrListf("downloading resources")
 	dest := destoption.From(d.opts)
+	if From(d.opts).MergeSBOMs {
+		return d.MergeSBOMs(dest.Destination)
+	}
 	if len(d.data) == 1 {
 		if dest.Destination == "" {
 			_, _ = common.Elem(d.data[0]).Labels.GetValue("downloadName", &dest.Destination)
@@ -216,6 +224,54 @@ func (d *action) Out() error {
 	return list.Result()
 }
 
+func (d *action) MergeSBOMs(f string) error {
+	if len(d.data) == 0 {
+		return errors.Newf("no SBOM resources found")
+	}
+	printer := common2.NewPrinter(d.opts.Context.StdOut())
+	dest := destoption.From(d.opts)
+
+	root := common2.VersionedElementKey(d.data[0].Version)
+	if len(d.data[0].History) > 0 {
+		root = d.data[0].History[0]
+	}
+	merger := sbomhdlr.NewMerger(sbom.Info{Name: root.GetName(), Version: root.GetVersion()})
+	for _, e := range d.data {
+		r := common.Elem(e)
+		if r.GetType() != resourcetypes.SBOM {
+			return errors.Newf("resource %q of %s is no SBOM (type %q)", r.GetName(), common2.VersionedElementKey(e.Version), r.GetType())
+		}
+		racc, err := e.Version.GetResource(r.GetIdentity(e.Version.GetDescriptor().Resources))
+		if err != nil {
+			return err
+		}
+		_, _, err = merger.Download(printer, racc, "", dest.PathFilesystem)
+		if err != nil {
+			return err
+		}
+	}
+	if f == "-" {
+		doc, err := merger.Merge()
+		if err != nil {
+			return err
+		}
+		data, err := doc.Data()
+		if err != nil {
+			return err
+		}
+		_, err = d.opts.Context.StdOut().Write(append(data, '\n'))
+		return err
+	}
+	if f == "" {
+		f = "sbom.json"
+	}
+	err := dest.PathFilesystem.MkdirAll(path.Dir(f), 0o770)
+	if err != nil {
+		return err
+	}
+	return merger.Write(printer, f, dest.PathFilesystem)
+}
+
 func (d *action) Save(o *elemhdlr.Object, f string) error {
 	printer := common2.NewPrinter(d.opts.Context.StdOut())
 	dest := destoption.From(d.opts)
@@ -16,7 +16,9 @@ import (
 
 	"github.com/open-component-model/ocm/pkg/common/accessio"
 	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
 	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/sbom"
 )
 
 const ARCH = "/tmp/ca"
@@ -119,4 +121,60 @@ var _ = Describe("Test Environment", func() {
 			Expect(env.ReadFile(vfs.Join(env.FileSystem(), OUT, COMP2+"/"+VERSION+"/"+COMP+"/"+VERSION+"/testdata"))).To(Equal([]byte("testdata")))
 		})
 	})
+
+	Context("with SBOMs", func() {
+		bom := func(name string) string {
+			return `{"bomFormat": "CycloneDX", "specVersion": "1.4", "version": 1,
+  "metadata": {"component": {"type": "application", "name": "` + name + `", "bom-ref": "` + name + `"}},
+  "components": [{"type": "library", "name": "lib", "bom-ref": "lib"}]}`
+		}
+
+		BeforeEach(func() {
+			env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+				env.Component(COMP, func() {
+					env.Version(VERSION, func() {
+						env.Provider(PROVIDER)
+						env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+							env.BlobStringData(mime.MIME_TEXT, "testdata")
+						})
+						env.Resource("sbom", "", resourcetypes.SBOM, metav1.LocalRelation, func() {
+							env.BlobStringData(mime.MIME_CYCLONEDX_JSON, bom("x"))
+						})
+					})
+				})
+				env.Component(COMP2, func() {
+					env.Version(VERSION, func() {
+						env.Provider(PROVIDER)
+						env.Resource("sbom", "", resourcetypes.SBOM, metav1.LocalRelation, func() {
+							env.BlobStringData(mime.MIME_CYCLONEDX_JSON, bom("y"))
+						})
+						env.Reference("base", COMP, VERSION)
+					})
+				})
+			})
+		})
+
+		It("merges SBOMs of closure", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("download", "resources", "-r", "--merge-sboms", "-O", OUT, "--repo", ARCH, COMP2+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("/tmp/res: CycloneDX SBOM with "))
+
+			doc := Must(sbom.Parse(Must(env.ReadFile(OUT))))
+			Expect(doc.Format).To(Equal(sbom.FORMAT_CYCLONEDX))
+			Expect(doc.Content["metadata"].(map[string]interface{})["component"]).To(Equal(map[string]interface{}{
+				"type":    "application",
+				"name":    COMP2,
+				"version": VERSION,
+				"bom-ref": COMP2 + "@" + VERSION,
+			}))
+			Expect(len(doc.Content["components"].([]interface{}))).To(Equal(3))
+		})
+
+		It("downloads validated SBOM with download handler", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("download", "resources", "-d", "-O", OUT, "--repo", ARCH, COMP+":"+VERSION, "sbom")).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("/tmp/res: CycloneDX SBOM with "))
+			Expect(Must(sbom.Parse(Must(env.ReadFile(OUT)))).Format).To(Equal(sbom.FORMAT_CYCLONEDX))
+		})
+	})
 })
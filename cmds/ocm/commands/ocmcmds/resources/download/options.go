@@ -22,12 +22,14 @@ func NewOptions() *Option {
 
 type Option struct {
 	UseHandlers   bool
+	MergeSBOMs    bool
 	Executable    bool
 	ResourceTypes []string
 }
 
 func (o *Option) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVarP(&o.UseHandlers, "download-handlers", "d", false, "use download handler if possible")
+	fs.BoolVarP(&o.MergeSBOMs, "merge-sboms", "", false, "merge downloaded SBOM resources into a single document")
 }
 
 func (o *Option) Usage() string {
@@ -36,6 +38,13 @@ The library supports some downloads with semantics based on resource types. For
 can be download directly as helm chart archive, even if stored as OCI artifact.
 This is handled by download handler. Their usage can be enabled with the <code>--download-handlers</code>
 option. Otherwise the resource as returned by the access method is stored.
+
+With option <code>--merge-sboms</code> all selected resources of type
+<code>sbom</code> are merged into a single SBOM document written to the
+output destination (default <code>sbom.json</code>). Together with the
+closure option <code>-c</code> this provides an aggregated SBOM for a
+component version and all its referenced component versions. All
+documents must have the same format (SPDX or CycloneDX).
 `
 	return s
 }
@@ -9,10 +9,18 @@ import (
 	"compress/gzip"
 	"io"
 	"sort"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/mandelsoft/vfs/pkg/vfs"
 
 	"github.com/open-component-model/ocm/pkg/common"
 	"github.com/open-component-model/ocm/pkg/common/accessio"
@@ -21,6 +29,7 @@ import (
 	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
 	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/utils/gitutils"
 )
 
 const ARCH = "/tmp/ca"
@@ -134,6 +143,56 @@ var _ = Describe("Add sources", func() {
 		CheckTextSource(env, cd, "testdata")
 	})
 
+	It("adds git repository snapshot", func() {
+		fs := env.FileSystem()
+		MustBeSuccessful(fs.MkdirAll("/repo/src", 0o755))
+		repo := Must(git.Init(filesystem.NewStorage(gitutils.NewFileSystem(fs, "/repo/.git"), cache.NewObjectLRUDefault()), gitutils.NewFileSystem(fs, "/repo")))
+		MustBeSuccessful(vfs.WriteFile(fs, "/repo/README.md", []byte("readme"), 0o644))
+		MustBeSuccessful(vfs.WriteFile(fs, "/repo/src/main.go", []byte("package main"), 0o644))
+		wt := Must(repo.Worktree())
+		Must(wt.Add("README.md"))
+		Must(wt.Add("src/main.go"))
+		Must(wt.Commit("initial", &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@acme.org", When: time.Unix(1680000000, 0)},
+		}))
+		MustBeSuccessful(vfs.WriteFile(fs, "/repo/README.md", []byte("modified"), 0o644))
+
+		meta := `
+name: repo
+type: git
+`
+		Expect(env.Execute("add", "sources", "--file", ARCH, "--source", meta, "--inputType", "git", "--inputPath", "/repo")).To(Succeed())
+		data, err := env.ReadFile(env.Join(ARCH, comparch.ComponentDescriptorFileName))
+		Expect(err).To(Succeed())
+		cd, err := compdesc.Decode(data)
+		Expect(err).To(Succeed())
+		Expect(len(cd.Sources)).To(Equal(1))
+
+		r, err := cd.GetSourceByIdentity(metav1.NewIdentity("repo"))
+		Expect(err).To(Succeed())
+		spec, err := env.OCMContext().AccessSpecForSpec(r.Access)
+		Expect(err).To(Succeed())
+		Expect(spec.(*localblob.AccessSpec).MediaType).To(Equal(mime.MIME_TAR))
+
+		file, err := env.Open(env.Join(ARCH, comparch.BlobsDirectoryName, spec.(*localblob.AccessSpec).LocalReference))
+		Expect(err).To(Succeed())
+		defer file.Close()
+		tr := tar.NewReader(file)
+		files := map[string]string{}
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).To(Succeed())
+			files[header.Name] = string(Must(io.ReadAll(tr)))
+		}
+		Expect(files).To(Equal(map[string]string{
+			"README.md":   "readme",
+			"src/main.go": "package main",
+		}))
+	})
+
 	Context("resource by options", func() {
 		It("adds simple text blob", func() {
 			meta := `
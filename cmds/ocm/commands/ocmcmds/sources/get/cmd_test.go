@@ -25,7 +25,7 @@ const PROVIDER = "mandelsoft"
 var _ = Describe("Test Environment", func() {
 	var env *TestEnv
 
-	spec, err := ocm.NewGenericAccessSpec("{\"type\":\"git\"}")
+	spec, err := ocm.NewGenericAccessSpec("{\"type\":\"git\",\"repoUrl\":\"https://github.com/open-component-model/ocm\",\"commit\":\"0123456789abcdef0123456789abcdef01234567\"}")
 	Expect(err).To(Succeed())
 
 	BeforeEach(func() {
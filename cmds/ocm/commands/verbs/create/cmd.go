@@ -7,7 +7,7 @@ package create
 import (
 	"github.com/spf13/cobra"
 
-	rsakeypair "github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/rsakeypair"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keypair"
 	ctf "github.com/open-component-model/ocm/cmds/ocm/commands/ocicmds/ctf/create"
 	comparch "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/componentarchive/create"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
@@ -22,6 +22,6 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 	}, verbs.Create)
 	cmd.AddCommand(comparch.NewCommand(ctx))
 	cmd.AddCommand(ctf.NewCommand(ctx))
-	cmd.AddCommand(rsakeypair.NewCommand(ctx))
+	cmd.AddCommand(keypair.NewCommand(ctx))
 	return cmd
 }
@@ -215,6 +215,21 @@ type AnnotatedBlobAccess[T DataAccess] interface {
 	Source() T
 }
 
+// StorageObserver is an optional interface of a BlobAccess passed
+// to a storage implementation. It is notified, if the storage of the
+// blob content has been skipped, because it is already present in the target.
+type StorageObserver interface {
+	BlobSkipped(size int64)
+}
+
+// NotifySkipped notifies the given blob about a skipped storage,
+// if it implements the StorageObserver interface.
+func NotifySkipped(blob BlobAccess, size int64) {
+	if o, ok := blob.(StorageObserver); ok {
+		o.BlobSkipped(size)
+	}
+}
+
 type blobAccess[T DataAccess] struct {
 	lock     sync.RWMutex
 	digest   digest.Digest
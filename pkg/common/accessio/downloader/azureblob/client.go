@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+// API_VERSION is the version of the Blob service REST API used for requests.
+const API_VERSION = "2021-08-06"
+
+// Credentials groups Azure related credential values together.
+// Only one of the credential types is used, the priority is
+// account key, SAS token and OAuth token.
+type Credentials struct {
+	// AccountKey is the base64 encoded storage account key used for
+	// Shared Key authorization.
+	AccountKey string
+	// SASToken is a shared access signature appended to the request URL.
+	SASToken string
+	// Token is an OAuth bearer token.
+	Token string
+}
+
+// Location describes a blob in an Azure Blob Storage account.
+type Location struct {
+	// Endpoint is the blob service endpoint. If empty, the default
+	// endpoint https://<account>.blob.core.windows.net is used.
+	// For path-style endpoints like the Azurite emulator it includes the
+	// account, for example http://127.0.0.1:10000/devstoreaccount1.
+	Endpoint  string
+	Account   string
+	Container string
+	Blob      string
+}
+
+// GetEndpoint provides the effective blob service endpoint.
+func (l Location) GetEndpoint() string {
+	if l.Endpoint == "" {
+		return fmt.Sprintf("https://%s.blob.core.windows.net", l.Account)
+	}
+	return strings.TrimSuffix(l.Endpoint, "/")
+}
+
+// URL provides the URL of the blob.
+func (l Location) URL() string {
+	segs := strings.Split(l.Blob, "/")
+	for i, s := range segs {
+		segs[i] = url.PathEscape(s)
+	}
+	return l.GetEndpoint() + "/" + url.PathEscape(l.Container) + "/" + strings.Join(segs, "/")
+}
+
+func request(ctx context.Context, method string, loc *Location, creds *Credentials, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	u := loc.URL()
+	q := query.Encode()
+	if creds != nil && creds.AccountKey == "" && creds.SASToken != "" {
+		if q != "" {
+			q += "&"
+		}
+		q += strings.TrimPrefix(creds.SASToken, "?")
+	}
+	if q != "" {
+		u += "?" + q
+	}
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, r)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if body != nil {
+		req.ContentLength = int64(len(body))
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", API_VERSION)
+	if creds != nil {
+		switch {
+		case creds.AccountKey != "":
+			sig, err := signSharedKey(req, loc.Account, creds.AccountKey)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Authorization", "SharedKey "+loc.Account+":"+sig)
+		case creds.SASToken != "":
+		case creds.Token != "":
+			httputils.SetBearerToken(req, creds.Token)
+		}
+	}
+	return http.DefaultClient.Do(req)
+}
+
+// signSharedKey calculates the Shared Key signature of a request for
+// the Blob service.
+func signSharedKey(req *http.Request, account, key string) (string, error) {
+	k, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", fmt.Errorf("invalid account key: %w", err)
+	}
+	length := ""
+	if req.ContentLength > 0 {
+		length = strconv.FormatInt(req.ContentLength, 10)
+	}
+	h := req.Header
+	lines := []string{
+		req.Method,
+		h.Get("Content-Encoding"),
+		h.Get("Content-Language"),
+		length,
+		h.Get("Content-MD5"),
+		h.Get("Content-Type"),
+		"", // Date, x-ms-date is used instead
+		h.Get("If-Modified-Since"),
+		h.Get("If-Match"),
+		h.Get("If-None-Match"),
+		h.Get("If-Unmodified-Since"),
+		h.Get("Range"),
+	}
+	s := strings.Join(lines, "\n") + "\n" + canonicalizedHeaders(h) + canonicalizedResource(req.URL, account)
+	mac := hmac.New(sha256.New, k)
+	mac.Write([]byte(s))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func canonicalizedHeaders(h http.Header) string {
+	var names []string
+	for k := range h {
+		if n := strings.ToLower(k); strings.HasPrefix(n, "x-ms-") {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	buf := &strings.Builder{}
+	for _, n := range names {
+		buf.WriteString(n + ":" + strings.TrimSpace(h.Get(n)) + "\n")
+	}
+	return buf.String()
+}
+
+func canonicalizedResource(u *url.URL, account string) string {
+	res := "/" + account + u.EscapedPath()
+	q := u.Query()
+	var names []string
+	for k := range q {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		v := q[n]
+		sort.Strings(v)
+		res += "\n" + strings.ToLower(n) + ":" + strings.Join(v, ",")
+	}
+	return res
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	ACCOUNT = "devstoreaccount1"
+	// KEY is the well-known account key of the Azurite emulator.
+	KEY = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+)
+
+type fakeServer struct {
+	lock   sync.Mutex
+	blobs  map[string][]byte
+	types  map[string]string
+	blocks map[string][]byte
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{
+		blobs:  map[string][]byte{},
+		types:  map[string]string{},
+		blocks: map[string][]byte{},
+	}
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	auth := r.Header.Get("Authorization")
+	sig, err := signSharedKey(r, ACCOUNT, KEY)
+	if err != nil || auth != "SharedKey "+ACCOUNT+":"+sig {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	data, _ := io.ReadAll(r.Body)
+	q := r.URL.Query()
+	switch r.Method {
+	case http.MethodGet:
+		b, ok := s.blobs[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(b)
+	case http.MethodPut:
+		switch q.Get("comp") {
+		case "block":
+			s.blocks[r.URL.Path+"/"+q.Get("blockid")] = data
+		case "blocklist":
+			var list blockList
+			if xml.Unmarshal(data, &list) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			buf := &bytes.Buffer{}
+			for _, id := range list.Latest {
+				buf.Write(s.blocks[r.URL.Path+"/"+id])
+			}
+			s.blobs[r.URL.Path] = buf.Bytes()
+			s.types[r.URL.Path] = r.Header.Get("x-ms-blob-content-type")
+		default:
+			if r.Header.Get("x-ms-blob-type") != "BlockBlob" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.blobs[r.URL.Path] = data
+			s.types[r.URL.Path] = r.Header.Get("Content-Type")
+		}
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+var _ = Describe("azure blob client", func() {
+	var fake *fakeServer
+	var server *httptest.Server
+	var loc Location
+	creds := &Credentials{AccountKey: KEY}
+
+	BeforeEach(func() {
+		fake = newFakeServer()
+		server = httptest.NewServer(fake)
+		loc = Location{
+			Endpoint:  server.URL + "/" + ACCOUNT,
+			Account:   ACCOUNT,
+			Container: "artifacts",
+			Blob:      "some/blob name",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("provides default endpoint", func() {
+		l := Location{Account: "acme", Container: "c", Blob: "a/b c"}
+		Expect(l.URL()).To(Equal("https://acme.blob.core.windows.net/c/a/b%20c"))
+	})
+
+	It("uploads and downloads small blob", func() {
+		Expect(Upload(loc, creds, strings.NewReader("test content"), "text/plain")).To(Succeed())
+		Expect(fake.types["/"+ACCOUNT+"/artifacts/some/blob name"]).To(Equal("text/plain"))
+
+		buf := &bytes.Buffer{}
+		Expect(NewDownloader(loc, creds).Download(&writerAt{buf})).To(Succeed())
+		Expect(buf.String()).To(Equal("test content"))
+	})
+
+	It("uploads large blob as blocks", func() {
+		data := bytes.Repeat([]byte("0123456789abcdef"), (BLOCK_SIZE*2+100)/16)
+		Expect(Upload(loc, creds, bytes.NewReader(data), "application/octet-stream")).To(Succeed())
+		Expect(len(fake.blocks)).To(Equal(3))
+		Expect(fake.blobs["/"+ACCOUNT+"/artifacts/some/blob name"]).To(Equal(data))
+		Expect(fake.types["/"+ACCOUNT+"/artifacts/some/blob name"]).To(Equal("application/octet-stream"))
+	})
+
+	It("fails for wrong key", func() {
+		err := Upload(loc, &Credentials{AccountKey: base64.StdEncoding.EncodeToString([]byte("wrong"))}, strings.NewReader("test content"), "text/plain")
+		Expect(err).To(MatchError(ContainSubstring("403 Forbidden")))
+	})
+
+	It("fails for missing blob", func() {
+		err := NewDownloader(loc, creds).Download(&writerAt{&bytes.Buffer{}})
+		Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+	})
+
+	Context("azurite", func() {
+		// set AZURITE_ENDPOINT to the blob endpoint of an Azurite instance
+		// (for example http://127.0.0.1:10000/devstoreaccount1) with an
+		// existing container "ocm".
+		It("uploads and downloads blob", func() {
+			endpoint := os.Getenv("AZURITE_ENDPOINT")
+			if endpoint == "" {
+				Skip("no Azurite emulator configured")
+			}
+			loc := Location{Endpoint: endpoint, Account: ACCOUNT, Container: "ocm", Blob: "test/blob"}
+			Expect(Upload(loc, creds, strings.NewReader("test content"), "text/plain")).To(Succeed())
+			buf := &bytes.Buffer{}
+			Expect(NewDownloader(loc, creds).Download(&writerAt{buf})).To(Succeed())
+			Expect(buf.String()).To(Equal("test content"))
+		})
+	})
+})
+
+type writerAt struct {
+	buf *bytes.Buffer
+}
+
+func (w *writerAt) WriteAt(p []byte, off int64) (int, error) {
+	return w.buf.Write(p)
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader"
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+// Downloader is a downloader capable of downloading blobs from
+// Azure Blob Storage.
+type Downloader struct {
+	loc   Location
+	creds *Credentials
+}
+
+func NewDownloader(loc Location, creds *Credentials) *Downloader {
+	return &Downloader{
+		loc:   loc,
+		creds: creds,
+	}
+}
+
+func (d *Downloader) Download(w io.WriterAt) error {
+	resp, err := request(context.Background(), http.MethodGet, &d.loc, d.creds, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get blob: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download blob: %w", httputils.ResponseError(d.loc.URL(), resp))
+	}
+	err = downloader.CopyTo(w, resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to download blob: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+// BLOCK_SIZE is the size of the blocks used to upload large blobs.
+const BLOCK_SIZE = 8 * 1024 * 1024
+
+type blockList struct {
+	XMLName xml.Name `xml:"BlockList"`
+	Latest  []string `xml:"Latest"`
+}
+
+// Upload stores the content of the given reader as block blob. Content
+// not exceeding the block size is uploaded with a single request,
+// larger content is uploaded as sequence of blocks.
+func Upload(loc Location, creds *Credentials, r io.Reader, mediaType string) error {
+	ctx := context.Background()
+	buf := make([]byte, BLOCK_SIZE)
+
+	var blocks []string
+	for {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return fmt.Errorf("failed to read blob content: %w", err)
+		}
+		last := err != nil
+		if last && len(blocks) == 0 {
+			header := http.Header{}
+			header.Set("x-ms-blob-type", "BlockBlob")
+			header.Set("Content-Type", mediaType)
+			return checkResponse(&loc, http.StatusCreated)(request(ctx, http.MethodPut, &loc, creds, nil, header, buf[:n]))
+		}
+		if n > 0 {
+			id := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("block-%08d", len(blocks))))
+			query := url.Values{"comp": {"block"}, "blockid": {id}}
+			err = checkResponse(&loc, http.StatusCreated)(request(ctx, http.MethodPut, &loc, creds, query, nil, buf[:n]))
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, id)
+		}
+		if last {
+			break
+		}
+	}
+
+	data, err := xml.Marshal(&blockList{Latest: blocks})
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set("x-ms-blob-content-type", mediaType)
+	header.Set("Content-Type", "application/xml")
+	return checkResponse(&loc, http.StatusCreated)(request(ctx, http.MethodPut, &loc, creds, url.Values{"comp": {"blocklist"}}, header, append([]byte(xml.Header), data...)))
+}
+
+func checkResponse(loc *Location, status int) func(resp *http.Response, err error) error {
+	return func(resp *http.Response, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to upload blob: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != status {
+			return fmt.Errorf("failed to upload blob: %w", httputils.ResponseError(loc.URL(), resp))
+		}
+		return nil
+	}
+}
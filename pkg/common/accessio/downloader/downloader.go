@@ -11,3 +11,24 @@ import "io"
 type Downloader interface {
 	Download(w io.WriterAt) error
 }
+
+// CopyTo copies the content of a reader to a WriterAt starting at offset 0.
+func CopyTo(w io.WriterAt, r io.Reader) error {
+	var off int64
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if _, werr := w.WriteAt(buf[:n], off); werr != nil {
+				return werr
+			}
+			off += int64(n)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
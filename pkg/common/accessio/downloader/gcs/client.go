@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+// DEFAULT_ENDPOINT is the endpoint of the Google Cloud Storage JSON API.
+const DEFAULT_ENDPOINT = "https://storage.googleapis.com"
+
+// Credentials groups Google Cloud related credential values together.
+type Credentials struct {
+	// Token is an OAuth2 access token.
+	Token string
+}
+
+// Location describes an object in a Google Cloud Storage bucket.
+type Location struct {
+	// Endpoint is the storage API endpoint. If empty, the default endpoint
+	// is used. For emulators like fake-gcs-server it is the server URL.
+	Endpoint   string
+	Bucket     string
+	Object     string
+	Generation string
+}
+
+// GetEndpoint provides the effective storage API endpoint.
+func (l Location) GetEndpoint() string {
+	if l.Endpoint == "" {
+		return DEFAULT_ENDPOINT
+	}
+	return strings.TrimSuffix(l.Endpoint, "/")
+}
+
+// URL provides the metadata URL of the object.
+func (l Location) URL() string {
+	return l.GetEndpoint() + "/storage/v1/b/" + url.PathEscape(l.Bucket) + "/o/" + url.PathEscape(l.Object)
+}
+
+func request(ctx context.Context, method, u string, creds *Credentials, header http.Header, body []byte) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, r)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if creds != nil && creds.Token != "" {
+		httputils.SetBearerToken(req, creds.Token)
+	}
+	return http.DefaultClient.Do(req)
+}
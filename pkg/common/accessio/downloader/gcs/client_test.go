@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const TOKEN = "test-token"
+
+type fakeServer struct {
+	lock       sync.Mutex
+	url        string
+	objects    map[string][]byte
+	types      map[string]string
+	sessions   map[string]*bytes.Buffer
+	generation int
+	chunks     int
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{
+		objects:  map[string][]byte{},
+		types:    map[string]string{},
+		sessions: map[string]*bytes.Buffer{},
+	}
+}
+
+func (s *fakeServer) store(w http.ResponseWriter, name, mediaType string, data []byte) {
+	s.generation++
+	s.objects[name] = data
+	s.types[name] = mediaType
+	fmt.Fprintf(w, `{"name":%q,"generation":"%d"}`, name, s.generation)
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+TOKEN {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	data, _ := io.ReadAll(r.Body)
+	q := r.URL.Query()
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/storage/v1/b/ocm/o/"):
+		b, ok := s.objects[strings.TrimPrefix(r.URL.Path, "/storage/v1/b/ocm/o/")]
+		if !ok || q.Get("alt") != "media" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(b)
+	case r.Method == http.MethodPost && r.URL.Path == "/upload/storage/v1/b/ocm/o":
+		switch q.Get("uploadType") {
+		case "media":
+			s.store(w, q.Get("name"), r.Header.Get("Content-Type"), data)
+		case "resumable":
+			id := fmt.Sprintf("%d", len(s.sessions))
+			s.sessions[id] = &bytes.Buffer{}
+			s.types[q.Get("name")] = r.Header.Get("X-Upload-Content-Type")
+			w.Header().Set("Location", s.url+"/upload/session/"+id+"?name="+q.Get("name"))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/upload/session/"):
+		buf := s.sessions[strings.TrimPrefix(r.URL.Path, "/upload/session/")]
+		var start, end int
+		var total string
+		if buf == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := fmt.Sscanf(r.Header.Get("Content-Range"), "bytes %d-%d/%s", &start, &end, &total); err != nil || start != buf.Len() || end-start+1 != len(data) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.chunks++
+		buf.Write(data)
+		if total == "*" {
+			w.WriteHeader(http.StatusPermanentRedirect)
+			return
+		}
+		name := q.Get("name")
+		s.store(w, name, s.types[name], buf.Bytes())
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var _ = Describe("gcs client", func() {
+	var fake *fakeServer
+	var server *httptest.Server
+	var loc Location
+	creds := &Credentials{Token: TOKEN}
+
+	BeforeEach(func() {
+		fake = newFakeServer()
+		server = httptest.NewServer(fake)
+		fake.url = server.URL
+		loc = Location{
+			Endpoint: server.URL,
+			Bucket:   "ocm",
+			Object:   "some/object",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("provides default endpoint", func() {
+		l := Location{Bucket: "b", Object: "a/b c"}
+		Expect(l.URL()).To(Equal("https://storage.googleapis.com/storage/v1/b/b/o/a%2Fb%20c"))
+	})
+
+	It("uploads and downloads small object", func() {
+		gen, err := Upload(loc, creds, strings.NewReader("test content"), "text/plain")
+		Expect(err).To(Succeed())
+		Expect(gen).To(Equal("1"))
+		Expect(fake.types["some/object"]).To(Equal("text/plain"))
+
+		loc.Generation = gen
+		buf := &bytes.Buffer{}
+		Expect(NewDownloader(loc, creds).Download(&writerAt{buf})).To(Succeed())
+		Expect(buf.String()).To(Equal("test content"))
+	})
+
+	It("uploads large object with resumable upload", func() {
+		data := bytes.Repeat([]byte("0123456789abcdef"), (CHUNK_SIZE*2+100)/16)
+		gen, err := Upload(loc, creds, bytes.NewReader(data), "application/octet-stream")
+		Expect(err).To(Succeed())
+		Expect(gen).To(Equal("1"))
+		Expect(fake.chunks).To(Equal(3))
+		Expect(fake.objects["some/object"]).To(Equal(data))
+		Expect(fake.types["some/object"]).To(Equal("application/octet-stream"))
+	})
+
+	It("uploads object with size of chunk", func() {
+		data := bytes.Repeat([]byte("0123456789abcdef"), CHUNK_SIZE/16)
+		_, err := Upload(loc, creds, bytes.NewReader(data), "application/octet-stream")
+		Expect(err).To(Succeed())
+		Expect(fake.chunks).To(Equal(1))
+		Expect(fake.objects["some/object"]).To(Equal(data))
+	})
+
+	It("fails for wrong token", func() {
+		_, err := Upload(loc, &Credentials{Token: "wrong"}, strings.NewReader("test content"), "text/plain")
+		Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+	})
+
+	It("fails for missing object", func() {
+		err := NewDownloader(loc, creds).Download(&writerAt{&bytes.Buffer{}})
+		Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+	})
+
+	Context("fake-gcs-server", func() {
+		// set STORAGE_EMULATOR_HOST to the URL of a fake-gcs-server
+		// instance (for example http://127.0.0.1:4443) with an existing
+		// bucket "ocm".
+		It("uploads and downloads object", func() {
+			endpoint := os.Getenv("STORAGE_EMULATOR_HOST")
+			if endpoint == "" {
+				Skip("no GCS emulator configured")
+			}
+			loc := Location{Endpoint: endpoint, Bucket: "ocm", Object: "test/object"}
+			gen, err := Upload(loc, nil, strings.NewReader("test content"), "text/plain")
+			Expect(err).To(Succeed())
+			loc.Generation = gen
+			buf := &bytes.Buffer{}
+			Expect(NewDownloader(loc, nil).Download(&writerAt{buf})).To(Succeed())
+			Expect(buf.String()).To(Equal("test content"))
+		})
+	})
+})
+
+type writerAt struct {
+	buf *bytes.Buffer
+}
+
+func (w *writerAt) WriteAt(p []byte, off int64) (int, error) {
+	return w.buf.Write(p)
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader"
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+// Downloader is a downloader capable of downloading objects from
+// Google Cloud Storage.
+type Downloader struct {
+	loc   Location
+	creds *Credentials
+}
+
+func NewDownloader(loc Location, creds *Credentials) *Downloader {
+	return &Downloader{
+		loc:   loc,
+		creds: creds,
+	}
+}
+
+func (d *Downloader) Download(w io.WriterAt) error {
+	query := url.Values{"alt": {"media"}}
+	if d.loc.Generation != "" {
+		query.Set("generation", d.loc.Generation)
+	}
+	u := d.loc.URL() + "?" + query.Encode()
+	resp, err := request(context.Background(), http.MethodGet, u, d.creds, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get object: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download object: %w", httputils.ResponseError(u, resp))
+	}
+	err = downloader.CopyTo(w, resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to download object: %w", err)
+	}
+	return nil
+}
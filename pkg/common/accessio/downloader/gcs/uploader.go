@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+// CHUNK_SIZE is the size of the chunks used to upload large objects
+// with a resumable upload. It must be a multiple of 256 KiB.
+const CHUNK_SIZE = 8 * 1024 * 1024
+
+type objectMeta struct {
+	Generation string `json:"generation"`
+}
+
+// Upload stores the content of the given reader as object and
+// returns the generation of the created object. Content not exceeding
+// the chunk size is uploaded with a single request, larger content
+// is uploaded with a resumable upload.
+func Upload(loc Location, creds *Credentials, r io.Reader, mediaType string) (string, error) {
+	ctx := context.Background()
+	buf := make([]byte, CHUNK_SIZE)
+
+	base := loc.GetEndpoint() + "/upload/storage/v1/b/" + url.PathEscape(loc.Bucket) + "/o"
+	header := http.Header{}
+	header.Set("Content-Type", mediaType)
+
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read object content: %w", err)
+	}
+	if err != nil {
+		u := base + "?" + url.Values{"uploadType": {"media"}, "name": {loc.Object}}.Encode()
+		return result(u, http.StatusOK)(request(ctx, http.MethodPost, u, creds, header, buf[:n]))
+	}
+
+	// resumable upload
+	u := base + "?" + url.Values{"uploadType": {"resumable"}, "name": {loc.Object}}.Encode()
+	init := http.Header{}
+	init.Set("X-Upload-Content-Type", mediaType)
+	init.Set("Content-Type", "application/json")
+	resp, err := request(ctx, http.MethodPost, u, creds, init, []byte("{}"))
+	if err != nil {
+		return "", fmt.Errorf("failed to initiate upload: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to initiate upload: %s", resp.Status)
+	}
+	session := resp.Header.Get("Location")
+	if session == "" {
+		return "", fmt.Errorf("failed to initiate upload: no session URL")
+	}
+
+	var offset int64
+	for {
+		next := make([]byte, CHUNK_SIZE)
+		m, err := io.ReadFull(r, next)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return "", fmt.Errorf("failed to read object content: %w", err)
+		}
+		last := m == 0
+		total := "*"
+		if last {
+			total = fmt.Sprint(offset + int64(n))
+		}
+		h := http.Header{}
+		h.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%s", offset, offset+int64(n)-1, total))
+		if last {
+			return result(session, http.StatusOK, http.StatusCreated)(request(ctx, http.MethodPut, session, creds, h, buf[:n]))
+		}
+		resp, err := request(ctx, http.MethodPut, session, creds, h, buf[:n])
+		if err != nil {
+			return "", fmt.Errorf("failed to upload object: %w", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusPermanentRedirect {
+			return "", fmt.Errorf("failed to upload object chunk: %s", resp.Status)
+		}
+		offset += int64(n)
+		buf, n = next, m
+	}
+}
+
+func result(u string, status ...int) func(resp *http.Response, err error) (string, error) {
+	return func(resp *http.Response, err error) (string, error) {
+		if err != nil {
+			return "", fmt.Errorf("failed to upload object: %w", err)
+		}
+		defer resp.Body.Close()
+		for _, s := range status {
+			if resp.StatusCode == s {
+				var meta objectMeta
+				err = json.NewDecoder(resp.Body).Decode(&meta)
+				if err != nil {
+					return "", fmt.Errorf("invalid upload response: %w", err)
+				}
+				return meta.Generation, nil
+			}
+		}
+		return "", fmt.Errorf("failed to upload object: %w", httputils.ResponseError(u, resp))
+	}
+}
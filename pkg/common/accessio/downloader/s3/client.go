@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package s3
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awscreds "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const (
+	defaultRegion = "us-west-1"
+	// defaultEndpointRegion is used for S3 compatible services like MinIO
+	// if no region is given.
+	defaultEndpointRegion = "us-east-1"
+)
+
+// AWSCreds groups AWS related credential values together.
+type AWSCreds struct {
+	AccessKeyID  string
+	AccessSecret string
+	SessionToken string
+}
+
+// newClient creates a client for the given bucket. If no endpoint is given,
+// AWS S3 is used and a missing region is determined from the bucket.
+// Otherwise, the endpoint is accessed with path-style addressing, as it is
+// required by S3 compatible services like MinIO.
+func newClient(ctx context.Context, endpoint, region, bucket string, creds *AWSCreds) (*s3.Client, error) {
+	opts := []func(*config.LoadOptions) error{
+		config.WithRegion(region),
+	}
+	var awsCred aws.CredentialsProvider = aws.AnonymousCredentials{}
+	if creds != nil {
+		awsCred = awscreds.StaticCredentialsProvider{
+			Value: aws.Credentials{
+				AccessKeyID:     creds.AccessKeyID,
+				SecretAccessKey: creds.AccessSecret,
+				SessionToken:    creds.SessionToken,
+			},
+		}
+	}
+	opts = append(opts, config.WithCredentialsProvider(awsCred))
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration for AWS: %w", err)
+	}
+
+	if region == "" {
+		if endpoint != "" {
+			region = defaultEndpointRegion
+		} else {
+			var err error
+			// deliberately use a different client so the real one will use the right region.
+			// Region has to be provided to get the region of the specified bucket. We use the
+			// global "default" of us-west-1 here. This will be updated to the right region
+			// once we retrieve it or die trying.
+			cfg.Region = defaultRegion
+			region, err = manager.GetBucketRegion(ctx, s3.NewFromConfig(cfg), bucket, func(o *s3.Options) {
+				o.Region = defaultRegion
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to find bucket region: %w", err)
+			}
+		}
+		cfg.Region = region
+	}
+
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		// Pass in creds because of https://github.com/aws/aws-sdk-go-v2/issues/1797
+		o.Credentials = awsCred
+		o.Region = region
+		if endpoint != "" {
+			o.EndpointResolver = s3.EndpointResolverFromURL(endpoint)
+			o.UsePathStyle = true
+		}
+	}), nil
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// PART_SIZE is the part size used for uploads. Content exceeding
+// this size is uploaded with a multipart upload.
+const PART_SIZE = manager.DefaultUploadPartSize
+
+// Uploader is an uploader capable of storing S3 objects.
+type Uploader struct {
+	endpoint       string
+	region, bucket string
+	creds          *AWSCreds
+}
+
+// NewUploader creates an uploader for a bucket. If the endpoint is
+// empty, AWS S3 is used.
+func NewUploader(endpoint, region, bucket string, creds *AWSCreds) *Uploader {
+	return &Uploader{
+		endpoint: endpoint,
+		region:   region,
+		bucket:   bucket,
+		creds:    creds,
+	}
+}
+
+// Upload stores the content of the given reader under the given key.
+// It returns the version id of the object, which is empty, if
+// the bucket is not versioned.
+func (u *Uploader) Upload(key, mediaType string, r io.Reader) (string, error) {
+	ctx := context.Background()
+	client, err := newClient(ctx, u.endpoint, u.region, u.bucket, u.creds)
+	if err != nil {
+		return "", err
+	}
+	uploader := manager.NewUploader(client, func(u *manager.Uploader) {
+		u.PartSize = PART_SIZE
+	})
+
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(key),
+		Body:   r,
+	}
+	if mediaType != "" {
+		input.ContentType = aws.String(mediaType)
+	}
+	out, err := uploader.Upload(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload object: %w", err)
+	}
+	return aws.ToString(out.VersionID), nil
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"sync"
 
+	"github.com/mandelsoft/filepath/pkg/filepath"
 	"github.com/mandelsoft/vfs/pkg/vfs"
 	"github.com/opencontainers/go-digest"
 
@@ -17,14 +18,28 @@ import (
 	"github.com/open-component-model/ocm/pkg/common/accessio"
 )
 
+// DigestFileNameMapper maps a blob digest to a file path
+// relative to the element directory.
+type DigestFileNameMapper func(digest digest.Digest) string
+
 type FileSystemBlobAccess struct {
 	sync.RWMutex
-	base *AccessObject
+	base   *AccessObject
+	mapper DigestFileNameMapper
 }
 
-func NewFileSystemBlobAccess(access *AccessObject) *FileSystemBlobAccess {
+// NewFileSystemBlobAccess provides a blob access for an access object.
+// By default, blobs are stored in flat files named by the digest (see
+// common.DigestToFileName). An optional mapper can be used to
+// provide another (potentially nested) file layout.
+func NewFileSystemBlobAccess(access *AccessObject, mapper ...DigestFileNameMapper) *FileSystemBlobAccess {
+	m := common.DigestToFileName
+	if len(mapper) > 0 && mapper[0] != nil {
+		m = mapper[0]
+	}
 	return &FileSystemBlobAccess{
-		base: access,
+		base:   access,
+		mapper: m,
 	}
 }
 
@@ -58,7 +73,7 @@ func (a *FileSystemBlobAccess) GetState() State {
 
 // DigestPath returns the path to the blob for a given name.
 func (a *FileSystemBlobAccess) DigestPath(digest digest.Digest) string {
-	return a.BlobPath(common.DigestToFileName(digest))
+	return a.BlobPath(a.mapper(digest))
 }
 
 // BlobPath returns the path to the blob for a given name.
@@ -81,6 +96,22 @@ func (a *FileSystemBlobAccess) GetBlobData(digest digest.Digest) (int64, accessi
 	}
 }
 
+// HasBlob checks whether a blob with the given digest is stored
+// and returns its size.
+func (a *FileSystemBlobAccess) HasBlob(digest digest.Digest) (bool, int64, error) {
+	if a.IsClosed() {
+		return false, accessio.BLOB_UNKNOWN_SIZE, accessio.ErrClosed
+	}
+	fi, err := a.base.GetFileSystem().Stat(a.DigestPath(digest))
+	if err != nil {
+		if vfs.IsErrNotExist(err) {
+			return false, accessio.BLOB_UNKNOWN_SIZE, nil
+		}
+		return false, accessio.BLOB_UNKNOWN_SIZE, err
+	}
+	return true, fi.Size(), nil
+}
+
 func (a *FileSystemBlobAccess) GetBlobDataByName(name string) (accessio.DataAccess, error) {
 	if a.IsClosed() {
 		return nil, accessio.ErrClosed
@@ -107,7 +138,11 @@ func (a *FileSystemBlobAccess) AddBlob(blob accessio.BlobAccess) error {
 
 	path := a.DigestPath(blob.Digest())
 
+	if err := a.base.GetFileSystem().MkdirAll(filepath.Dir(path), a.base.GetMode()); err != nil {
+		return fmt.Errorf("unable to create directory for '%s': %w", path, err)
+	}
 	if ok, err := vfs.FileExists(a.base.GetFileSystem(), path); ok {
+		accessio.NotifySkipped(blob, blob.Size())
 		return nil
 	} else if err != nil {
 		return fmt.Errorf("failed to check if '%s' file exists: %w", path, err)
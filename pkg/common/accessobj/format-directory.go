@@ -102,37 +102,32 @@ func (_ DirectoryHandler) Write(obj *AccessObject, path string, opts accessio.Op
 	}
 
 	// copy all content
-	fileInfos, err := vfs.ReadDir(obj.fs, obj.info.GetElementDirectoryName())
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
-		}
-		return errors.Wrapf(err, "unable to read '%s'", obj.info.GetElementDirectoryName())
-	}
-
-	for _, fileInfo := range fileInfos {
-		if fileInfo.IsDir() {
-			continue
-		}
-		inpath := obj.info.SubPath(fileInfo.Name())
+	err = walkElements(obj, func(name string, info os.FileInfo) error {
+		inpath := obj.info.SubPath(name)
 		outpath := filepath.Join(path, inpath)
+		if info.IsDir() {
+			return opts.GetPathFileSystem().MkdirAll(outpath, mode|0o400)
+		}
 		content, err := obj.fs.Open(inpath)
 		if err != nil {
 			return errors.Wrapf(err, "unable to open input %s %q", obj.info.GetElementTypeName(), inpath)
 		}
+		defer content.Close()
 		out, err := opts.GetPathFileSystem().OpenFile(outpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode|0o666)
 		if err != nil {
 			return errors.Wrapf(err, "unable to open output %s %q", obj.info.GetElementTypeName(), outpath)
 		}
 		if _, err := io.Copy(out, content); err != nil {
+			out.Close()
 			return errors.Wrapf(err, "unable to copy %s from %q to %q", obj.info.GetElementTypeName(), inpath, outpath)
 		}
 		if err := out.Close(); err != nil {
 			return errors.Wrapf(err, "unable to close output %s %s", obj.info.GetElementTypeName(), outpath)
 		}
-		if err := content.Close(); err != nil {
-			return errors.Wrapf(err, "unable to close input %s %s", obj.info.GetElementTypeName(), outpath)
-		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to copy '%s'", obj.info.GetElementDirectoryName())
 	}
 
 	return nil
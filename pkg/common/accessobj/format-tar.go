@@ -162,19 +162,23 @@ func (h TarHandler) WriteToStream(obj *AccessObject, writer io.Writer, opts acce
 		return fmt.Errorf("unable to write %s directory: %w", obj.info.GetElementTypeName(), err)
 	}
 
-	fileInfos, err := vfs.ReadDir(obj.fs, obj.info.GetElementDirectoryName())
-	if err != nil {
-		if os.IsNotExist(err) {
+	err = walkElements(obj, func(name string, info os.FileInfo) error {
+		path := obj.info.SubPath(name)
+		if info.IsDir() {
+			err := tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     path,
+				Mode:     DirMode,
+				ModTime:  ModTime,
+			})
+			if err != nil {
+				return fmt.Errorf("unable to write %s directory %s: %w", obj.info.GetElementTypeName(), path, err)
+			}
 			return nil
 		}
-		return fmt.Errorf("unable to read %s directory: %w", obj.info.GetElementTypeName(), err)
-	}
-
-	for _, fileInfo := range fileInfos {
-		path := obj.info.SubPath(fileInfo.Name())
 		header := &tar.Header{
 			Name:    path,
-			Size:    fileInfo.Size(),
+			Size:    info.Size(),
 			Mode:    FileMode,
 			ModTime: ModTime,
 		}
@@ -187,11 +191,16 @@ func (h TarHandler) WriteToStream(obj *AccessObject, writer io.Writer, opts acce
 			return fmt.Errorf("unable to open %s: %w", obj.info.GetElementTypeName(), err)
 		}
 		if _, err := io.Copy(tw, content); err != nil {
+			content.Close()
 			return fmt.Errorf("unable to write %s content: %w", obj.info.GetElementTypeName(), err)
 		}
 		if err := content.Close(); err != nil {
 			return fmt.Errorf("unable to close %s %s: %w", obj.info.GetElementTypeName(), path, err)
 		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to add %s content: %w", obj.info.GetElementTypeName(), err)
 	}
 
 	return tw.Close()
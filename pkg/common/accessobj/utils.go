@@ -5,6 +5,9 @@
 package accessobj
 
 import (
+	"os"
+
+	"github.com/mandelsoft/filepath/pkg/filepath"
 	"github.com/mandelsoft/vfs/pkg/osfs"
 	"github.com/mandelsoft/vfs/pkg/vfs"
 
@@ -60,3 +63,38 @@ func HandleAccessMode(acc AccessMode, path string, opts accessio.Options, olist
 	err = o.DefaultForPath(path)
 	return o, false, err
 }
+
+// walkElements calls the given function for all files and directories
+// found in the element directory of an access object, including nested
+// directories. The given path is relative to the element directory and
+// directories are reported before their content. A missing element
+// directory is handled like an empty one.
+func walkElements(obj *AccessObject, fn func(path string, info os.FileInfo) error) error {
+	ok, err := vfs.DirExists(obj.fs, obj.info.GetElementDirectoryName())
+	if !ok || err != nil {
+		return err
+	}
+	return walkElementDir(obj.fs, obj.info.GetElementDirectoryName(), "", fn)
+}
+
+func walkElementDir(fs vfs.FileSystem, base, rel string, fn func(path string, info os.FileInfo) error) error {
+	fileInfos, err := vfs.ReadDir(fs, filepath.Join(base, rel))
+	if err != nil {
+		return err
+	}
+	for _, fi := range fileInfos {
+		p := fi.Name()
+		if rel != "" {
+			p = filepath.Join(rel, p)
+		}
+		if err := fn(p, fi); err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			if err := walkElementDir(fs, base, p, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/cloud"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const NAME = "aws"
+
+var hostExp = regexp.MustCompile(`^([0-9]{12})\.dkr\.ecr(-fips)?\.([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+
+func init() {
+	Register(cpi.DefaultContext)
+}
+
+// Register registers the ECR token provider at the given credential context.
+func Register(ctx cpi.Context) {
+	cloud.Register(ctx, NAME, IsECRHost, &TokenSource{})
+}
+
+// IsECRHost checks whether the given host is an ECR registry host.
+func IsECRHost(host string) bool {
+	return hostExp.MatchString(host)
+}
+
+// TokenSource provides ECR authorization tokens based on the ambient AWS
+// credentials (environment, shared configuration, web identity token file
+// or instance metadata).
+type TokenSource struct {
+	// Endpoint optionally overwrites the ECR API endpoint.
+	Endpoint string
+}
+
+var _ cloud.TokenSource = (*TokenSource)(nil)
+
+func (s *TokenSource) Token(host string) (*cloud.Token, error) {
+	m := hostExp.FindStringSubmatch(host)
+	if m == nil {
+		return nil, fmt.Errorf("unknown ecr host %q", host)
+	}
+	account, region := m[1], m[3]
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load configuration for AWS")
+	}
+	client := ecr.NewFromConfig(cfg, func(o *ecr.Options) {
+		if s.Endpoint != "" {
+			o.EndpointResolver = ecr.EndpointResolverFromURL(s.Endpoint)
+		}
+	})
+	out, err := client.GetAuthorizationToken(ctx, &ecr.GetAuthorizationTokenInput{RegistryIds: []string{account}})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get ECR authorization token for %s", host)
+	}
+	if len(out.AuthorizationData) == 0 || out.AuthorizationData[0].AuthorizationToken == nil {
+		return nil, fmt.Errorf("no ECR authorization token for %s", host)
+	}
+	data := out.AuthorizationData[0]
+	auth, err := base64.StdEncoding.DecodeString(*data.AuthorizationToken)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ECR authorization token")
+	}
+	user, pass, ok := strings.Cut(string(auth), ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid ECR authorization token")
+	}
+	t := &cloud.Token{
+		Username: user,
+		Password: pass,
+	}
+	if data.ExpiresAt != nil {
+		t.Expires = *data.ExpiresAt
+	}
+	return t, nil
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aws_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/aws"
+)
+
+const HOST = "123456789012.dkr.ecr.eu-central-1.amazonaws.com"
+
+var _ = Describe("ECR token source", func() {
+	var server *httptest.Server
+	var target, body string
+	var expires time.Time
+
+	env := map[string]string{
+		"AWS_ACCESS_KEY_ID":           "key",
+		"AWS_SECRET_ACCESS_KEY":       "secret",
+		"AWS_CONFIG_FILE":             "/nonexistent",
+		"AWS_SHARED_CREDENTIALS_FILE": "/nonexistent",
+		"AWS_EC2_METADATA_DISABLED":   "true",
+	}
+
+	BeforeEach(func() {
+		for k, v := range env {
+			os.Setenv(k, v)
+		}
+		expires = time.Now().Add(12 * time.Hour).Truncate(time.Second)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			target = r.Header.Get("X-Amz-Target")
+			body = string(data)
+			if !strings.Contains(r.Header.Get("Authorization"), "Credential=key/") {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+			token := base64.StdEncoding.EncodeToString([]byte("AWS:password"))
+			fmt.Fprintf(w, `{"authorizationData":[{"authorizationToken":%q,"expiresAt":%d,"proxyEndpoint":"https://%s"}]}`, token, expires.Unix(), HOST)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		for k := range env {
+			os.Unsetenv(k)
+		}
+	})
+
+	It("matches ECR hosts", func() {
+		Expect(aws.IsECRHost(HOST)).To(BeTrue())
+		Expect(aws.IsECRHost("123456789012.dkr.ecr-fips.us-east-1.amazonaws.com")).To(BeTrue())
+		Expect(aws.IsECRHost("123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn")).To(BeTrue())
+		Expect(aws.IsECRHost("ghcr.io")).To(BeFalse())
+		Expect(aws.IsECRHost("public.ecr.aws")).To(BeFalse())
+	})
+
+	It("gets authorization token", func() {
+		src := &aws.TokenSource{Endpoint: server.URL}
+		t := Must(src.Token(HOST))
+		Expect(t.Username).To(Equal("AWS"))
+		Expect(t.Password).To(Equal("password"))
+		Expect(t.Expires.Equal(expires)).To(BeTrue())
+		Expect(target).To(Equal("AmazonEC2ContainerRegistry_V20150921.GetAuthorizationToken"))
+		Expect(body).To(ContainSubstring(`"registryIds":["123456789012"]`))
+	})
+
+	It("fails without credentials", func() {
+		os.Setenv("AWS_ACCESS_KEY_ID", "other")
+		src := &aws.TokenSource{Endpoint: server.URL}
+		_, err := src.Token(HOST)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/cloud"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const NAME = "azure"
+
+const (
+	// USERNAME is the username used for ACR refresh tokens.
+	USERNAME = "00000000-0000-0000-0000-000000000000"
+
+	SCOPE             = "https://management.azure.com/.default"
+	RESOURCE          = "https://management.azure.com/"
+	DEFAULT_AUTHORITY = "https://login.microsoftonline.com/"
+	IMDS_ENDPOINT     = "http://169.254.169.254"
+	IMDS_TIMEOUT      = 2 * time.Second
+
+	ENV_TENANT_ID            = "AZURE_TENANT_ID"
+	ENV_CLIENT_ID            = "AZURE_CLIENT_ID"
+	ENV_CLIENT_SECRET        = "AZURE_CLIENT_SECRET"
+	ENV_FEDERATED_TOKEN_FILE = "AZURE_FEDERATED_TOKEN_FILE"
+	ENV_AUTHORITY_HOST       = "AZURE_AUTHORITY_HOST"
+)
+
+var registrySuffixes = []string{".azurecr.io", ".azurecr.cn", ".azurecr.us"}
+
+func init() {
+	Register(cpi.DefaultContext)
+}
+
+// Register registers the ACR token provider at the given credential context.
+func Register(ctx cpi.Context) {
+	cloud.Register(ctx, NAME, IsACRHost, &TokenSource{})
+}
+
+// IsACRHost checks whether the given host is an Azure Container Registry host.
+func IsACRHost(host string) bool {
+	for _, s := range registrySuffixes {
+		if strings.HasSuffix(host, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenSource provides ACR refresh tokens. It requests an Azure AD access
+// token based on the ambient Azure credentials and exchanges it at the
+// registry. The following sources are checked in this order:
+//   - a workload identity token file (AZURE_FEDERATED_TOKEN_FILE)
+//   - a client secret (AZURE_CLIENT_SECRET)
+//   - the managed identity provided by the instance metadata service.
+type TokenSource struct {
+	// IMDSEndpoint optionally overwrites the instance metadata service endpoint.
+	IMDSEndpoint string
+	// Scheme optionally overwrites the scheme used to access the registry.
+	Scheme string
+	// Client is an optional HTTP client.
+	Client *http.Client
+}
+
+var _ cloud.TokenSource = (*TokenSource)(nil)
+
+func (s *TokenSource) Token(host string) (*cloud.Token, error) {
+	aad, tenant, err := s.accessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := s.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	values := url.Values{
+		"grant_type":   {"access_token"},
+		"service":      {host},
+		"access_token": {aad.AccessToken},
+	}
+	if tenant != "" {
+		values.Set("tenant", tenant)
+	}
+	var result cloud.OAuthToken
+	if err := cloud.PostForm(s.Client, scheme+"://"+host+"/oauth2/exchange", values, &result); err != nil {
+		return nil, errors.Wrapf(err, "ACR token exchange failed for %s", host)
+	}
+	if result.RefreshToken == "" {
+		return nil, fmt.Errorf("ACR token exchange for %s provided no refresh token", host)
+	}
+	expires, err := cloud.JWTExpiration(result.RefreshToken)
+	if err != nil || expires.IsZero() {
+		expires = aad.ExpiresIn.Expires()
+	}
+	return &cloud.Token{
+		Username: USERNAME,
+		Password: result.RefreshToken,
+		Expires:  expires,
+	}, nil
+}
+
+func (s *TokenSource) accessToken() (*cloud.OAuthToken, string, error) {
+	tenant := os.Getenv(ENV_TENANT_ID)
+	client := os.Getenv(ENV_CLIENT_ID)
+
+	if file := os.Getenv(ENV_FEDERATED_TOKEN_FILE); file != "" && tenant != "" && client != "" {
+		assertion, err := os.ReadFile(file)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "cannot read federated token")
+		}
+		t, err := s.clientCredentials(tenant, url.Values{
+			"client_id":             {client},
+			"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+			"client_assertion":      {strings.TrimSpace(string(assertion))},
+		})
+		return t, tenant, err
+	}
+	if secret := os.Getenv(ENV_CLIENT_SECRET); secret != "" && tenant != "" && client != "" {
+		t, err := s.clientCredentials(tenant, url.Values{
+			"client_id":     {client},
+			"client_secret": {secret},
+		})
+		return t, tenant, err
+	}
+	t, err := s.managedIdentity(client)
+	return t, tenant, err
+}
+
+func (s *TokenSource) clientCredentials(tenant string, values url.Values) (*cloud.OAuthToken, error) {
+	authority := os.Getenv(ENV_AUTHORITY_HOST)
+	if authority == "" {
+		authority = DEFAULT_AUTHORITY
+	}
+	values.Set("grant_type", "client_credentials")
+	values.Set("scope", SCOPE)
+
+	var t cloud.OAuthToken
+	u := strings.TrimSuffix(authority, "/") + "/" + tenant + "/oauth2/v2.0/token"
+	if err := cloud.PostForm(s.Client, u, values, &t); err != nil {
+		return nil, errors.Wrapf(err, "cannot get Azure AD token")
+	}
+	return &t, nil
+}
+
+func (s *TokenSource) managedIdentity(client string) (*cloud.OAuthToken, error) {
+	endpoint := s.IMDSEndpoint
+	if endpoint == "" {
+		endpoint = IMDS_ENDPOINT
+	}
+	values := url.Values{
+		"api-version": {"2018-02-01"},
+		"resource":    {RESOURCE},
+	}
+	if client != "" {
+		values.Set("client_id", client)
+	}
+	req, err := http.NewRequest(http.MethodGet, endpoint+"/metadata/identity/oauth2/token?"+values.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+
+	c := s.Client
+	if c == nil {
+		c = &http.Client{Timeout: IMDS_TIMEOUT}
+	}
+	var t cloud.OAuthToken
+	if err := cloud.Do(c, req, &t); err != nil {
+		return nil, errors.Wrapf(err, "no managed identity token")
+	}
+	return &t, nil
+}
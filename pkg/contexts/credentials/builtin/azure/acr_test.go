@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azure_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/azure"
+)
+
+func refreshToken(exp time.Time) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix()))) + ".sig"
+}
+
+var _ = Describe("ACR token source", func() {
+	var server *httptest.Server
+	var host string
+	var exchanged string
+	var expires time.Time
+
+	env := []string{azure.ENV_TENANT_ID, azure.ENV_CLIENT_ID, azure.ENV_CLIENT_SECRET, azure.ENV_FEDERATED_TOKEN_FILE, azure.ENV_AUTHORITY_HOST}
+
+	BeforeEach(func() {
+		exchanged = ""
+		expires = time.Now().Add(3 * time.Hour).Truncate(time.Second)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/tenant/oauth2/v2.0/token":
+				switch {
+				case r.Form.Get("client_secret") == "secret":
+					fmt.Fprint(w, `{"access_token":"aad-secret","expires_in":3600,"token_type":"Bearer"}`)
+				case r.Form.Get("client_assertion") == "federated":
+					fmt.Fprint(w, `{"access_token":"aad-federated","expires_in":3600,"token_type":"Bearer"}`)
+				default:
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+			case r.URL.Path == "/metadata/identity/oauth2/token":
+				if r.Header.Get("Metadata") != "true" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				fmt.Fprint(w, `{"access_token":"aad-msi","expires_in":"3599","token_type":"Bearer"}`)
+			case r.URL.Path == "/oauth2/exchange":
+				if r.Form.Get("service") != host || !strings.HasPrefix(r.Form.Get("access_token"), "aad-") {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				exchanged = r.Form.Get("access_token")
+				fmt.Fprintf(w, `{"refresh_token":%q}`, refreshToken(expires))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		host = strings.TrimPrefix(server.URL, "http://")
+		os.Setenv(azure.ENV_AUTHORITY_HOST, server.URL)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		for _, k := range env {
+			os.Unsetenv(k)
+		}
+	})
+
+	source := func() *azure.TokenSource {
+		return &azure.TokenSource{IMDSEndpoint: server.URL, Scheme: "http"}
+	}
+
+	It("matches ACR hosts", func() {
+		Expect(azure.IsACRHost("ocm.azurecr.io")).To(BeTrue())
+		Expect(azure.IsACRHost("ocm.azurecr.cn")).To(BeTrue())
+		Expect(azure.IsACRHost("ghcr.io")).To(BeFalse())
+	})
+
+	It("exchanges client secret token", func() {
+		os.Setenv(azure.ENV_TENANT_ID, "tenant")
+		os.Setenv(azure.ENV_CLIENT_ID, "client")
+		os.Setenv(azure.ENV_CLIENT_SECRET, "secret")
+		t := Must(source().Token(host))
+		Expect(t.Username).To(Equal(azure.USERNAME))
+		Expect(t.Password).To(Equal(refreshToken(expires)))
+		Expect(t.Expires.Equal(expires)).To(BeTrue())
+		Expect(exchanged).To(Equal("aad-secret"))
+	})
+
+	It("exchanges workload identity token", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "token")
+		MustBeSuccessful(os.WriteFile(file, []byte("federated\n"), 0o600))
+		os.Setenv(azure.ENV_TENANT_ID, "tenant")
+		os.Setenv(azure.ENV_CLIENT_ID, "client")
+		os.Setenv(azure.ENV_FEDERATED_TOKEN_FILE, file)
+		Must(source().Token(host))
+		Expect(exchanged).To(Equal("aad-federated"))
+	})
+
+	It("exchanges managed identity token", func() {
+		Must(source().Token(host))
+		Expect(exchanged).To(Equal("aad-msi"))
+	})
+
+	It("fails without managed identity", func() {
+		_, err := (&azure.TokenSource{IMDSEndpoint: server.URL + "/none", Scheme: "http"}).Token(host)
+		Expect(err).To(MatchError(ContainSubstring("no managed identity token")))
+	})
+})
@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cloud provides the common handling for built-in consumer providers,
+// which exchange ambient cloud credentials for short-lived OCI registry
+// credentials (for example ECR authorization tokens, GCP access tokens or
+// ACR refresh tokens).
+//
+// The tokens are requested on demand for registry hosts of the dedicated
+// cloud, when the credentials are used, and renewed before they expire.
+// The providers are asked after all other consumer providers, so the tokens
+// are only used, if no other credentials are configured for a registry.
+package cloud
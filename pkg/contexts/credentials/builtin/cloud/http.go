@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cloud
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// Seconds is a duration in seconds, which might be
+// provided as JSON number or string.
+type Seconds int64
+
+func (s *Seconds) UnmarshalJSON(data []byte) error {
+	str := strings.Trim(string(data), `"`)
+	if str == "" {
+		*s = 0
+		return nil
+	}
+	v, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "invalid duration %s", string(data))
+	}
+	*s = Seconds(v)
+	return nil
+}
+
+func (s Seconds) Expires() time.Time {
+	if s <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Duration(s) * time.Second)
+}
+
+// OAuthToken is the token response of an OAuth2 token endpoint.
+type OAuthToken struct {
+	AccessToken  string  `json:"access_token"`
+	RefreshToken string  `json:"refresh_token"`
+	TokenType    string  `json:"token_type"`
+	ExpiresIn    Seconds `json:"expires_in"`
+}
+
+// Do executes an HTTP request and decodes the JSON response.
+func Do(client *http.Client, req *http.Request, result interface{}) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s failed: %s: %s", req.Method, req.URL.Redacted(), resp.Status, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return errors.Wrapf(err, "invalid response from %s", req.URL.Redacted())
+	}
+	return nil
+}
+
+// PostForm posts form values and decodes the JSON response.
+func PostForm(client *http.Client, u string, values url.Values, result interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return Do(client, req, result)
+}
+
+// JWTExpiration provides the expiration time of a JWT.
+// The signature is not validated.
+func JWTExpiration(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("invalid JWT")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid JWT payload")
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid JWT claims")
+	}
+	if claims.Exp == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(claims.Exp, 0), nil
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cloud
+
+import (
+	"sync"
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/internal"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/identity"
+	ocmlog "github.com/open-component-model/ocm/pkg/logging"
+)
+
+var REALM = ocmlog.DefineSubRealm("cloud registry token exchange", "credentials/cloud")
+
+const PROVIDER = "ocm.software/credentialprovider/cloud"
+
+// RETRY_PERIOD is the period failed token requests are not retried.
+const RETRY_PERIOD = time.Minute
+
+// PRIORITY is the priority of the cloud consumer providers. It is
+// higher than the default priority, so they are asked after all
+// other consumer providers.
+const PRIORITY = 100
+
+// Token is a short-lived registry credential.
+// A zero expiration time means that the token does not expire.
+type Token struct {
+	Username string
+	Password string
+	Expires  time.Time
+}
+
+// TokenSource provides short-lived registry credentials for a registry host
+// based on ambient cloud credentials.
+type TokenSource interface {
+	Token(host string) (*Token, error)
+}
+
+type entry struct {
+	token   *Token
+	err     error
+	fetched time.Time
+}
+
+// valid checks whether a cached entry can still be used. Tokens are renewed
+// after three quarters of their lifetime.
+func (e *entry) valid(now time.Time) bool {
+	if e.err != nil {
+		return now.Sub(e.fetched) < RETRY_PERIOD
+	}
+	if e.token.Expires.IsZero() {
+		return true
+	}
+	return now.Before(e.fetched.Add(e.token.Expires.Sub(e.fetched) * 3 / 4))
+}
+
+// ConsumerProvider provides OCI registry credentials for registry hosts
+// of a cloud provider. The credentials are obtained by a TokenSource and
+// renewed before they expire. It is used only, if no other
+// credentials are found for a requested consumer identity.
+type ConsumerProvider struct {
+	lock   sync.Mutex
+	ctx    cpi.Context
+	name   string
+	match  func(host string) bool
+	source TokenSource
+	tokens map[string]*entry
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func NewConsumerProvider(ctx cpi.Context, name string, match func(host string) bool, source TokenSource) *ConsumerProvider {
+	return &ConsumerProvider{
+		ctx:    ctx,
+		name:   name,
+		match:  match,
+		source: source,
+		tokens: map[string]*entry{},
+	}
+}
+
+// Register registers a consumer provider for the given cloud at a credential context.
+func Register(ctx cpi.Context, name string, match func(host string) bool, source TokenSource) {
+	ctx.RegisterConsumerProvider(cpi.ProviderIdentity(PROVIDER+"/"+name), NewConsumerProvider(ctx, name, match, source))
+}
+
+func (p *ConsumerProvider) Unregister(id internal.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) GetPriority() int {
+	return PRIORITY
+}
+
+func (p *ConsumerProvider) Match(req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+func (p *ConsumerProvider) get(req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	if cur != nil || req.Type() != identity.CONSUMER_TYPE {
+		return nil, cur
+	}
+	host := req[identity.ID_HOSTNAME]
+	if host == "" || !p.match(host) {
+		return nil, cur
+	}
+	// the provided credentials are described by an unspecific identity,
+	// therefore any host specific credentials provided by other sources
+	// are preferred by the identity matchers.
+	id := cpi.ConsumerIdentity{
+		identity.ID_TYPE: identity.CONSUMER_TYPE,
+	}
+	if !m(req, cur, id) {
+		return nil, cur
+	}
+	// the token is requested lazily by the credentials source,
+	// only recently failed requests are considered here.
+	if p.failed(host) {
+		return nil, cur
+	}
+	return credentialGetter{p, host}, id
+}
+
+// failed checks whether the last token request for the given
+// host failed recently.
+func (p *ConsumerProvider) failed(host string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	e := p.tokens[host]
+	return e != nil && e.err != nil && e.valid(time.Now())
+}
+
+// Token provides a valid token for the given host.
+func (p *ConsumerProvider) Token(host string) (*Token, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := time.Now()
+	if e := p.tokens[host]; e != nil && e.valid(now) {
+		return e.token, e.err
+	}
+	t, err := p.source.Token(host)
+	if err != nil {
+		p.ctx.Logger(REALM).Debug("no cloud registry token", "cloud", p.name, "host", host, "error", err.Error())
+	}
+	p.tokens[host] = &entry{token: t, err: err, fetched: now}
+	return t, err
+}
+
+type credentialGetter struct {
+	provider *ConsumerProvider
+	host     string
+}
+
+var _ cpi.CredentialsSource = credentialGetter{}
+
+// Credentials provides the current token for the host. If no token can be
+// obtained, empty credentials are provided, which result in an anonymous access.
+func (c credentialGetter) Credentials(ctx cpi.Context, cs ...cpi.CredentialsSource) (cpi.Credentials, error) {
+	t, err := c.provider.Token(c.host)
+	if err != nil {
+		return cpi.NewCredentials(nil), nil
+	}
+	return cpi.NewCredentials(common.Properties{
+		cpi.ATTR_USERNAME: t.Username,
+		cpi.ATTR_PASSWORD: t.Password,
+	}), nil
+}
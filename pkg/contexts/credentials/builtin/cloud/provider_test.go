@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cloud_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/cloud"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/dockerconfig"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/identity"
+)
+
+type source struct {
+	calls    int
+	lifetime time.Duration
+	err      error
+}
+
+func (s *source) Token(host string) (*cloud.Token, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	t := &cloud.Token{
+		Username: "user",
+		Password: fmt.Sprintf("%s-%d", host, s.calls),
+	}
+	if s.lifetime > 0 {
+		t.Expires = time.Now().Add(s.lifetime)
+	}
+	return t, nil
+}
+
+func match(host string) bool {
+	return host == "registry.cloud"
+}
+
+func consumer(host string) cpi.ConsumerIdentity {
+	return cpi.ConsumerIdentity{
+		identity.ID_TYPE:       identity.CONSUMER_TYPE,
+		identity.ID_HOSTNAME:   host,
+		identity.ID_PATHPREFIX: "ocm",
+	}
+}
+
+func props(pass string) common.Properties {
+	return common.Properties{
+		cpi.ATTR_USERNAME: "user",
+		cpi.ATTR_PASSWORD: pass,
+	}
+}
+
+var _ = Describe("cloud registry token provider", func() {
+	var ctx credentials.Context
+	var src *source
+
+	BeforeEach(func() {
+		ctx = credentials.New()
+		src = &source{lifetime: time.Hour}
+		cloud.Register(ctx, "test", match, src)
+	})
+
+	It("provides tokens for matching hosts", func() {
+		creds := Must(credentials.CredentialsForConsumer(ctx, consumer("registry.cloud"), identity.IdentityMatcher))
+		Expect(creds.Properties()).To(Equal(props("registry.cloud-1")))
+
+		creds = Must(credentials.CredentialsForConsumer(ctx, consumer("other.cloud"), identity.IdentityMatcher))
+		Expect(creds).To(BeNil())
+		Expect(src.calls).To(Equal(1))
+	})
+
+	It("caches tokens", func() {
+		for i := 0; i < 3; i++ {
+			creds := Must(credentials.CredentialsForConsumer(ctx, consumer("registry.cloud"), identity.IdentityMatcher))
+			Expect(creds.Properties()).To(Equal(props("registry.cloud-1")))
+		}
+		Expect(src.calls).To(Equal(1))
+	})
+
+	It("renews expiring tokens", func() {
+		src.lifetime = 200 * time.Millisecond
+		src := Must(ctx.GetCredentialsForConsumer(consumer("registry.cloud"), identity.IdentityMatcher))
+		Expect(Must(src.Credentials(ctx)).Properties()).To(Equal(props("registry.cloud-1")))
+		Expect(Must(src.Credentials(ctx)).Properties()).To(Equal(props("registry.cloud-1")))
+		time.Sleep(200 * time.Millisecond)
+		Expect(Must(src.Credentials(ctx)).Properties()).To(Equal(props("registry.cloud-2")))
+	})
+
+	It("requests tokens lazily", func() {
+		cs := Must(ctx.GetCredentialsForConsumer(consumer("registry.cloud"), identity.IdentityMatcher))
+		Expect(cs).NotTo(BeNil())
+		Expect(src.calls).To(Equal(0))
+		Expect(Must(cs.Credentials(ctx)).Properties()).To(Equal(props("registry.cloud-1")))
+		Expect(src.calls).To(Equal(1))
+	})
+
+	It("does not retry failed requests immediately", func() {
+		src.err = fmt.Errorf("no ambient credentials")
+		creds := Must(credentials.CredentialsForConsumer(ctx, consumer("registry.cloud"), identity.IdentityMatcher))
+		Expect(creds.Properties()).To(BeEmpty())
+		for i := 0; i < 2; i++ {
+			creds := Must(credentials.CredentialsForConsumer(ctx, consumer("registry.cloud"), identity.IdentityMatcher))
+			Expect(creds).To(BeNil())
+		}
+		Expect(src.calls).To(Equal(1))
+	})
+
+	It("prefers explicit credentials", func() {
+		id := cpi.ConsumerIdentity{
+			identity.ID_TYPE:     identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME: "registry.cloud",
+		}
+		ctx.SetCredentialsForConsumer(id, cpi.NewCredentials(props("explicit")))
+
+		creds := Must(credentials.CredentialsForConsumer(ctx, consumer("registry.cloud"), identity.IdentityMatcher))
+		Expect(creds.Properties()).To(Equal(props("explicit")))
+		Expect(src.calls).To(Equal(0))
+	})
+
+	It("prefers credentials of repository providers", func() {
+		// the providers are asked in a fixed order, so
+		// the result must not depend on the registration.
+		for i := 0; i < 10; i++ {
+			ctx := credentials.New()
+			src := &source{lifetime: time.Hour}
+			cloud.Register(ctx, "test", match, src)
+			Must(ctx.RepositoryForSpec(dockerconfig.NewRepositorySpecForConfig([]byte(`{"auths":{"registry.cloud":{"auth":"`+base64.StdEncoding.EncodeToString([]byte("docker:config"))+`"}}}`), true)))
+			cloud.Register(ctx, "other", match, src)
+
+			creds := Must(credentials.CredentialsForConsumer(ctx, consumer("registry.cloud"), identity.IdentityMatcher))
+			Expect(creds.GetProperty(cpi.ATTR_USERNAME)).To(Equal("docker"))
+			Expect(creds.GetProperty(cpi.ATTR_PASSWORD)).To(Equal("config"))
+			Expect(src.calls).To(Equal(0))
+		}
+	})
+})
@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/cloud"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const NAME = "gcp"
+
+const (
+	// USERNAME is the username used for OAuth access tokens
+	// by GCR and Artifact Registry.
+	USERNAME = "oauth2accesstoken"
+
+	SCOPE            = "https://www.googleapis.com/auth/cloud-platform"
+	DEFAULT_TOKENURL = "https://oauth2.googleapis.com/token"
+	METADATA_HOST    = "169.254.169.254"
+	METADATA_TIMEOUT = 2 * time.Second
+
+	ENV_ACCESS_TOKEN  = "GOOGLE_OAUTH_ACCESS_TOKEN"
+	ENV_CREDENTIALS   = "GOOGLE_APPLICATION_CREDENTIALS"
+	ENV_METADATA_HOST = "GCE_METADATA_HOST"
+)
+
+func init() {
+	Register(cpi.DefaultContext)
+}
+
+// Register registers the GCP token provider at the given credential context.
+func Register(ctx cpi.Context) {
+	cloud.Register(ctx, NAME, IsGCPHost, &TokenSource{})
+}
+
+// IsGCPHost checks whether the given host is a Google Container Registry
+// or Artifact Registry host.
+func IsGCPHost(host string) bool {
+	return host == "gcr.io" || strings.HasSuffix(host, ".gcr.io") || strings.HasSuffix(host, "-docker.pkg.dev")
+}
+
+// TokenSource provides OAuth access tokens based on the ambient GCP
+// credentials. The following sources are checked in this order:
+//   - an access token provided by the environment variable GOOGLE_OAUTH_ACCESS_TOKEN
+//   - a service account key or an external account (workload identity federation)
+//     configuration file provided by the environment variable GOOGLE_APPLICATION_CREDENTIALS
+//   - the metadata server of the compute environment.
+type TokenSource struct {
+	// MetadataHost optionally overwrites the metadata server host.
+	MetadataHost string
+	// Client is an optional HTTP client.
+	Client *http.Client
+}
+
+var _ cloud.TokenSource = (*TokenSource)(nil)
+
+func (s *TokenSource) Token(host string) (*cloud.Token, error) {
+	token, expires, err := s.accessToken()
+	if err != nil {
+		return nil, err
+	}
+	return &cloud.Token{
+		Username: USERNAME,
+		Password: token,
+		Expires:  expires,
+	}, nil
+}
+
+func (s *TokenSource) accessToken() (string, time.Time, error) {
+	if t := os.Getenv(ENV_ACCESS_TOKEN); t != "" {
+		return t, time.Time{}, nil
+	}
+	if f := os.Getenv(ENV_CREDENTIALS); f != "" {
+		return s.fromFile(f)
+	}
+	return s.fromMetadata()
+}
+
+type credentialsFile struct {
+	Type string `json:"type"`
+
+	// service account key
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+
+	// external account
+	Audience                       string `json:"audience"`
+	SubjectTokenType               string `json:"subject_token_type"`
+	TokenURL                       string `json:"token_url"`
+	ServiceAccountImpersonationURL string `json:"service_account_impersonation_url"`
+	CredentialSource               struct {
+		File string `json:"file"`
+	} `json:"credential_source"`
+}
+
+func (s *TokenSource) fromFile(path string) (string, time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", time.Time{}, errors.Wrapf(err, "cannot read GCP credentials file")
+	}
+	var f credentialsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return "", time.Time{}, errors.Wrapf(err, "invalid GCP credentials file %s", path)
+	}
+	switch f.Type {
+	case "service_account":
+		return s.fromServiceAccount(&f)
+	case "external_account":
+		return s.fromExternalAccount(&f)
+	default:
+		return "", time.Time{}, errors.ErrNotSupported("GCP credentials type", f.Type)
+	}
+}
+
+func (s *TokenSource) context() context.Context {
+	ctx := context.Background()
+	if s.Client != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, s.Client)
+	}
+	return ctx
+}
+
+func (s *TokenSource) fromServiceAccount(f *credentialsFile) (string, time.Time, error) {
+	cfg := &jwt.Config{
+		Email:        f.ClientEmail,
+		PrivateKey:   []byte(f.PrivateKey),
+		PrivateKeyID: f.PrivateKeyID,
+		Scopes:       []string{SCOPE},
+		TokenURL:     f.TokenURI,
+	}
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = DEFAULT_TOKENURL
+	}
+	t, err := cfg.TokenSource(s.context()).Token()
+	if err != nil {
+		return "", time.Time{}, errors.Wrapf(err, "cannot get token for service account %s", f.ClientEmail)
+	}
+	return t.AccessToken, t.Expiry, nil
+}
+
+// fromExternalAccount exchanges the subject token provided by a file
+// (for example a workload identity token) for a GCP access token.
+func (s *TokenSource) fromExternalAccount(f *credentialsFile) (string, time.Time, error) {
+	if f.CredentialSource.File == "" {
+		return "", time.Time{}, errors.ErrNotSupported("GCP external account credential source")
+	}
+	subject, err := os.ReadFile(f.CredentialSource.File)
+	if err != nil {
+		return "", time.Time{}, errors.Wrapf(err, "cannot read subject token")
+	}
+	values := url.Values{
+		"grant_type":           {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"audience":             {f.Audience},
+		"scope":                {SCOPE},
+		"requested_token_type": {"urn:ietf:params:oauth:token-type:access_token"},
+		"subject_token":        {strings.TrimSpace(string(subject))},
+		"subject_token_type":   {f.SubjectTokenType},
+	}
+	var sts cloud.OAuthToken
+	if err := cloud.PostForm(s.Client, f.TokenURL, values, &sts); err != nil {
+		return "", time.Time{}, errors.Wrapf(err, "token exchange failed")
+	}
+	if f.ServiceAccountImpersonationURL == "" {
+		return sts.AccessToken, sts.ExpiresIn.Expires(), nil
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"scope": []string{SCOPE}})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req, err := http.NewRequest(http.MethodPost, f.ServiceAccountImpersonationURL, bytes.NewReader(body))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+sts.AccessToken)
+	var result struct {
+		AccessToken string    `json:"accessToken"`
+		ExpireTime  time.Time `json:"expireTime"`
+	}
+	if err := cloud.Do(s.Client, req, &result); err != nil {
+		return "", time.Time{}, errors.Wrapf(err, "service account impersonation failed")
+	}
+	return result.AccessToken, result.ExpireTime, nil
+}
+
+func (s *TokenSource) fromMetadata() (string, time.Time, error) {
+	host := s.MetadataHost
+	if host == "" {
+		host = os.Getenv(ENV_METADATA_HOST)
+	}
+	if host == "" {
+		host = METADATA_HOST
+	}
+	u := host
+	if !strings.Contains(u, "://") {
+		u = "http://" + u
+	}
+	req, err := http.NewRequest(http.MethodGet, u+"/computeMetadata/v1/instance/service-accounts/default/token", nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: METADATA_TIMEOUT}
+	}
+	var t cloud.OAuthToken
+	if err := cloud.Do(client, req, &t); err != nil {
+		return "", time.Time{}, errors.Wrapf(err, "no token from GCP metadata server")
+	}
+	if t.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("no token from GCP metadata server")
+	}
+	return t.AccessToken, t.ExpiresIn.Expires(), nil
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/gcp"
+)
+
+var _ = Describe("GCP token source", func() {
+	var server *httptest.Server
+	var requests []*http.Request
+	var dir string
+
+	BeforeEach(func() {
+		requests = nil
+		dir = GinkgoT().TempDir()
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			requests = append(requests, r)
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/computeMetadata/v1/instance/service-accounts/default/token":
+				if r.Header.Get("Metadata-Flavor") != "Google" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				fmt.Fprint(w, `{"access_token":"metadata","expires_in":3599,"token_type":"Bearer"}`)
+			case "/token":
+				if r.Form.Get("grant_type") == "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+					fmt.Fprint(w, `{"access_token":"serviceaccount","expires_in":3600,"token_type":"Bearer"}`)
+					return
+				}
+				if r.Form.Get("subject_token") != "workload" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				fmt.Fprint(w, `{"access_token":"federated","expires_in":3600,"token_type":"Bearer"}`)
+			case "/impersonate":
+				if r.Header.Get("Authorization") != "Bearer federated" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				fmt.Fprintf(w, `{"accessToken":"impersonated","expireTime":%q}`, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.Unsetenv(gcp.ENV_ACCESS_TOKEN)
+		os.Unsetenv(gcp.ENV_CREDENTIALS)
+	})
+
+	writeCredentials := func(creds map[string]interface{}) {
+		file := filepath.Join(dir, "credentials.json")
+		MustBeSuccessful(os.WriteFile(file, Must(json.Marshal(creds)), 0o600))
+		os.Setenv(gcp.ENV_CREDENTIALS, file)
+	}
+
+	It("matches GCP hosts", func() {
+		Expect(gcp.IsGCPHost("gcr.io")).To(BeTrue())
+		Expect(gcp.IsGCPHost("eu.gcr.io")).To(BeTrue())
+		Expect(gcp.IsGCPHost("europe-west3-docker.pkg.dev")).To(BeTrue())
+		Expect(gcp.IsGCPHost("ghcr.io")).To(BeFalse())
+	})
+
+	It("uses access token from environment", func() {
+		os.Setenv(gcp.ENV_ACCESS_TOKEN, "env")
+		t := Must((&gcp.TokenSource{MetadataHost: server.URL}).Token("gcr.io"))
+		Expect(t.Username).To(Equal(gcp.USERNAME))
+		Expect(t.Password).To(Equal("env"))
+		Expect(t.Expires.IsZero()).To(BeTrue())
+		Expect(requests).To(BeEmpty())
+	})
+
+	It("uses metadata server", func() {
+		t := Must((&gcp.TokenSource{MetadataHost: server.URL}).Token("gcr.io"))
+		Expect(t.Username).To(Equal(gcp.USERNAME))
+		Expect(t.Password).To(Equal("metadata"))
+		Expect(t.Expires).To(BeTemporally("~", time.Now().Add(3599*time.Second), time.Minute))
+	})
+
+	It("fails without metadata server", func() {
+		_, err := (&gcp.TokenSource{MetadataHost: server.URL + "/none"}).Token("gcr.io")
+		Expect(err).To(MatchError(ContainSubstring("no token from GCP metadata server")))
+	})
+
+	It("exchanges workload identity token", func() {
+		token := filepath.Join(dir, "token")
+		MustBeSuccessful(os.WriteFile(token, []byte("workload\n"), 0o600))
+		writeCredentials(map[string]interface{}{
+			"type":                              "external_account",
+			"audience":                          "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/pool/providers/provider",
+			"subject_token_type":                "urn:ietf:params:oauth:token-type:jwt",
+			"token_url":                         server.URL + "/token",
+			"service_account_impersonation_url": server.URL + "/impersonate",
+			"credential_source":                 map[string]interface{}{"file": token},
+		})
+		t := Must((&gcp.TokenSource{MetadataHost: server.URL}).Token("gcr.io"))
+		Expect(t.Password).To(Equal("impersonated"))
+		Expect(t.Expires).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+		Expect(len(requests)).To(Equal(2))
+		Expect(requests[0].Form.Get("audience")).To(HavePrefix("//iam.googleapis.com/"))
+	})
+
+	It("uses service account key", func() {
+		key := Must(rsa.GenerateKey(rand.Reader, 2048))
+		writeCredentials(map[string]interface{}{
+			"type":           "service_account",
+			"client_email":   "ocm@project.iam.gserviceaccount.com",
+			"private_key_id": "1",
+			"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+			"token_uri":      server.URL + "/token",
+		})
+		t := Must((&gcp.TokenSource{MetadataHost: server.URL}).Token("gcr.io"))
+		Expect(t.Password).To(Equal("serviceaccount"))
+		Expect(len(requests)).To(Equal(1))
+		Expect(requests[0].Form.Get("assertion")).NotTo(BeEmpty())
+	})
+})
@@ -4,4 +4,9 @@
 
 package builtin
 
-import _ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/github"
+import (
+	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/aws"
+	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/azure"
+	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/gcp"
+	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/github"
+)
@@ -20,4 +20,7 @@ const (
 	ATTR_TOKEN                 = internal.ATTR_TOKEN
 	ATTR_AWS_ACCESS_KEY_ID     = internal.ATTR_AWS_ACCESS_KEY_ID
 	ATTR_AWS_SECRET_ACCESS_KEY = internal.ATTR_AWS_SECRET_ACCESS_KEY
+	ATTR_PRIVATE_KEY           = internal.ATTR_PRIVATE_KEY
+	ATTR_AZURE_ACCOUNT_KEY     = internal.ATTR_AZURE_ACCOUNT_KEY
+	ATTR_AZURE_SAS_TOKEN       = internal.ATTR_AZURE_SAS_TOKEN
 )
@@ -17,5 +17,27 @@ const (
 	ATTR_SERVER_ADDRESS = internal.ATTR_SERVER_ADDRESS
 	ATTR_IDENTITY_TOKEN = internal.ATTR_IDENTITY_TOKEN
 	ATTR_REGISTRY_TOKEN = internal.ATTR_REGISTRY_TOKEN
+	ATTR_TOKEN          = internal.ATTR_TOKEN
 	ATTR_KEY            = internal.ATTR_KEY
+	ATTR_PRIVATE_KEY    = internal.ATTR_PRIVATE_KEY
+
+	ATTR_AZURE_ACCOUNT_KEY = internal.ATTR_AZURE_ACCOUNT_KEY
+	ATTR_AZURE_SAS_TOKEN   = internal.ATTR_AZURE_SAS_TOKEN
+
+	ATTR_AWS_ACCESS_KEY_ID     = internal.ATTR_AWS_ACCESS_KEY_ID
+	ATTR_AWS_SECRET_ACCESS_KEY = internal.ATTR_AWS_SECRET_ACCESS_KEY
 )
+
+// DefaultConsumerTypes are the consumer types generic credential
+// sources, like netrc files or credential helpers, provide credentials
+// for, if no explicit types are configured.
+// The credentials package must not depend on the packages defining
+// the consumer types, therefore their names are used here.
+var DefaultConsumerTypes = []string{
+	"OCIRegistry",
+	"HTTPServer",
+	"HelmChartRepository",
+	"MavenRepository",
+	"PythonPackageIndex",
+	"Git",
+}
@@ -41,6 +41,9 @@ type (
 	IdentityMatcher         = internal.IdentityMatcher
 	IdentityMatcherInfo     = internal.IdentityMatcherInfo
 	IdentityMatcherRegistry = internal.IdentityMatcherRegistry
+
+	CredentialsExplanation = internal.CredentialsExplanation
+	CredentialsCandidate   = internal.CredentialsCandidate
 )
 
 var DefaultContext = internal.DefaultContext
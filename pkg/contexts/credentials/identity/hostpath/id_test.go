@@ -207,4 +207,40 @@ var _ = Describe("ctf management", func() {
 		})
 	})
 
+	Context("for URLs", func() {
+		It("maps URL to consumer id", func() {
+			id, err := hostpath.GetConsumerId("HTTPServer", "https://host:4711/a/b/")
+			Expect(err).To(Succeed())
+			Expect(id).To(Equal(credentials.ConsumerIdentity{
+				hostpath.ID_TYPE:       "HTTPServer",
+				hostpath.ID_HOSTNAME:   "host",
+				hostpath.ID_PORT:       "4711",
+				hostpath.ID_SCHEME:     "https",
+				hostpath.ID_PATHPREFIX: "a/b",
+			}))
+		})
+
+		It("provides credentials for URL", func() {
+			ctx := credentials.New()
+			ctx.SetCredentialsForConsumer(credentials.ConsumerIdentity{
+				hostpath.ID_TYPE:       "HTTPServer",
+				hostpath.ID_HOSTNAME:   "host",
+				hostpath.ID_PATHPREFIX: "a",
+			}, credentials.DirectCredentials{credentials.ATTR_TOKEN: "a"})
+			ctx.SetCredentialsForConsumer(credentials.ConsumerIdentity{
+				hostpath.ID_TYPE:     "HTTPServer",
+				hostpath.ID_HOSTNAME: "host",
+			}, credentials.DirectCredentials{credentials.ATTR_TOKEN: "host"})
+
+			creds, err := hostpath.GetCredentials(ctx, "HTTPServer", "https://host/a/b")
+			Expect(err).To(Succeed())
+			Expect(creds.GetProperty(credentials.ATTR_TOKEN)).To(Equal("a"))
+			creds, err = hostpath.GetCredentials(ctx, "HTTPServer", "https://host/c")
+			Expect(err).To(Succeed())
+			Expect(creds.GetProperty(credentials.ATTR_TOKEN)).To(Equal("host"))
+			creds, err = hostpath.GetCredentials(ctx, "OCIRegistry", "https://host/a")
+			Expect(err).To(Succeed())
+			Expect(creds).To(BeNil())
+		})
+	})
 })
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package hostpath
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+)
+
+// GetConsumerId provides the consumer identity of the given consumer type
+// for a URL. The hostname, port, scheme and path of the URL are mapped
+// to the identity attributes of this matcher.
+func GetConsumerId(consumerType string, rawURL string) (cpi.ConsumerIdentity, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	id := cpi.ConsumerIdentity{
+		ID_TYPE:     consumerType,
+		ID_HOSTNAME: u.Hostname(),
+	}
+	if u.Port() != "" {
+		id[ID_PORT] = u.Port()
+	}
+	if u.Scheme != "" {
+		id[ID_SCHEME] = u.Scheme
+	}
+	if p := strings.Trim(u.Path, "/"); p != "" {
+		id[ID_PATHPREFIX] = p
+	}
+	return id, nil
+}
+
+// GetCredentials provides the credentials configured for a URL
+// for the given consumer type.
+func GetCredentials(ctx cpi.ContextProvider, consumerType string, rawURL string) (cpi.Credentials, error) {
+	id, err := GetConsumerId(consumerType, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, IdentityMatcher(consumerType))
+}
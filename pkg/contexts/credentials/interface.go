@@ -41,6 +41,9 @@ type (
 	IdentityMatcher         = internal.IdentityMatcher
 	IdentityMatcherInfo     = internal.IdentityMatcherInfo
 	IdentityMatcherRegistry = internal.IdentityMatcherRegistry
+
+	CredentialsExplanation = internal.CredentialsExplanation
+	CredentialsCandidate   = internal.CredentialsCandidate
 )
 
 type (
@@ -89,6 +92,10 @@ func ErrUnknownCredentials(name string) error {
 	return internal.ErrUnknownCredentials(name)
 }
 
+func ErrUnknownConsumer(name string) error {
+	return internal.ErrUnknownConsumer(name)
+}
+
 // CredentialsForConsumer determine effective credentials for a consumer.
 // If no credentials are configured no error and nil is returned.
 // It evaluates a found credentials source for the consumer to determine the
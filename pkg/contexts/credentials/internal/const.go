@@ -17,4 +17,7 @@ const (
 	ATTR_AWS_ACCESS_KEY_ID     = "awsAccessKeyID"
 	ATTR_AWS_SECRET_ACCESS_KEY = "awsSecretAccessKey"
 	ATTR_KEY                   = "key"
+	ATTR_PRIVATE_KEY           = "privateKey"
+	ATTR_AZURE_ACCOUNT_KEY     = "azureAccountKey"
+	ATTR_AZURE_SAS_TOKEN       = "azureSASToken"
 )
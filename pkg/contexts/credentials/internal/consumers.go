@@ -8,7 +8,7 @@ import (
 	"sort"
 	"sync"
 
-	"github.com/open-component-model/ocm/pkg/generics"
+	"github.com/open-component-model/ocm/pkg/utils"
 )
 
 type _consumers struct {
@@ -46,10 +46,11 @@ func (c *_consumers) Get(id ConsumerIdentity) (CredentialsSource, bool) {
 }
 
 // Match matches a given request (pattern) against configured
-// identities.
+// identities. The identities are checked in a fixed order.
 func (c *_consumers) Match(pattern ConsumerIdentity, cur ConsumerIdentity, m IdentityMatcher) (CredentialsSource, ConsumerIdentity) {
 	var found *_consumer
-	for _, s := range c.data {
+	for _, k := range utils.StringMapKeys(c.data) {
+		s := c.data[k]
 		if m(pattern, cur, s.identity) {
 			found = s
 			cur = s.identity
@@ -106,7 +107,7 @@ type consumerProviderRegistry struct {
 	lock      sync.RWMutex
 	explicit  *_consumers
 	providers map[ProviderIdentity]ConsumerProvider
-	ordered   []ConsumerProvider
+	ordered   []ProviderIdentity
 }
 
 func newConsumerProviderRegistry() *consumerProviderRegistry {
@@ -125,10 +126,25 @@ func (p *consumerProviderRegistry) Register(id ProviderIdentity, c ConsumerProvi
 
 	p.unregister(id)
 	p.providers[id] = c
-	p.ordered = generics.MapValues(p.providers)
-	sort.Slice(p.ordered, func(a, b int) bool {
-		return priority(p.ordered[a]) < priority(p.ordered[b])
+	p.order()
+}
+
+// order determines the order the providers are asked in. Providers are
+// ordered by their priority, providers with the same priority are ordered
+// by their identity.
+func (p *consumerProviderRegistry) order() {
+	ids := make([]ProviderIdentity, 0, len(p.providers))
+	for id := range p.providers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(a, b int) bool {
+		pa, pb := priority(p.providers[ids[a]]), priority(p.providers[ids[b]])
+		if pa != pb {
+			return pa < pb
+		}
+		return ids[a] < ids[b]
 	})
+	p.ordered = ids
 }
 
 func (p *consumerProviderRegistry) Unregister(id ProviderIdentity) {
@@ -141,10 +157,7 @@ func (p *consumerProviderRegistry) unregister(id ProviderIdentity) {
 	p.explicit.Unregister(id)
 	if _, ok := p.providers[id]; ok {
 		delete(p.providers, id)
-		p.ordered = generics.MapValues(p.providers)
-		sort.Slice(p.ordered, func(a, b int) bool {
-			return priority(p.ordered[a]) < priority(p.ordered[b])
-		})
+		p.order()
 	} else {
 		for _, sub := range p.providers {
 			sub.Unregister(id)
@@ -155,13 +168,16 @@ func (p *consumerProviderRegistry) unregister(id ProviderIdentity) {
 func (p *consumerProviderRegistry) Get(id ConsumerIdentity) (CredentialsSource, bool) {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
+	return p.get(id)
+}
 
+func (p *consumerProviderRegistry) get(id ConsumerIdentity) (CredentialsSource, bool) {
 	credsrc, ok := p.explicit.Get(id)
 	if ok {
 		return credsrc, ok
 	}
-	for _, sub := range p.providers {
-		credsrc, ok := sub.Get(id)
+	for _, pid := range p.ordered {
+		credsrc, ok := p.providers[pid].Get(id)
 		if ok {
 			return credsrc, ok
 		}
@@ -173,10 +189,18 @@ func (p *consumerProviderRegistry) Match(pattern ConsumerIdentity, cur ConsumerI
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
-	credsrc, cur := p.explicit.Match(pattern, cur, m)
-	for _, sub := range p.providers {
+	return p.match(pattern, cur, func(ProviderIdentity, bool) IdentityMatcher { return m })
+}
+
+// match matches a given request against the explicit identities and the
+// identities offered by the consumer providers. The matcher used for
+// a provider is determined by the given function, which is called before
+// the provider is asked. This is used to trace the matching process.
+func (p *consumerProviderRegistry) match(pattern ConsumerIdentity, cur ConsumerIdentity, matcher func(pid ProviderIdentity, explicit bool) IdentityMatcher) (CredentialsSource, ConsumerIdentity) {
+	credsrc, cur := p.explicit.Match(pattern, cur, matcher("", true))
+	for _, pid := range p.ordered {
 		var f CredentialsSource
-		f, cur = sub.Match(pattern, cur, m)
+		f, cur = p.providers[pid].Match(pattern, cur, matcher(pid, false))
 		if f != nil {
 			credsrc = f
 		}
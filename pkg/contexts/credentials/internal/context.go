@@ -55,6 +55,10 @@ type Context interface {
 	UnregisterConsumerProvider(id ProviderIdentity)
 
 	GetCredentialsForConsumer(ConsumerIdentity, ...IdentityMatcher) (CredentialsSource, error)
+	// ExplainCredentialsForConsumer traces the resolution of credentials for
+	// a consumer identity. It lists all checked consumer providers with the
+	// identities offered by them and the finally chosen credentials source.
+	ExplainCredentialsForConsumer(ConsumerIdentity, ...IdentityMatcher) (*CredentialsExplanation, error)
 	SetCredentialsForConsumer(identity ConsumerIdentity, creds CredentialsSource)
 	SetCredentialsForConsumerWithProvider(pid ProviderIdentity, identity ConsumerIdentity, creds CredentialsSource)
 
@@ -194,6 +198,14 @@ func (c *_context) GetCredentialsForConsumer(identity ConsumerIdentity, matchers
 	return credsrc, nil
 }
 
+func (c *_context) ExplainCredentialsForConsumer(identity ConsumerIdentity, matchers ...IdentityMatcher) (*CredentialsExplanation, error) {
+	err := c.Update()
+	if err != nil {
+		return nil, err
+	}
+	return c.consumerProviders.Explain(identity, defaultMatcher(matchers...)), nil
+}
+
 func (c *_context) SetCredentialsForConsumer(identity ConsumerIdentity, creds CredentialsSource) {
 	c.Update()
 	c.consumerProviders.Set(identity, "", creds)
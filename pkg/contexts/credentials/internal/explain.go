@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+// CredentialsCandidate describes a consumer identity offered by a
+// consumer provider while resolving the credentials for a consumer.
+type CredentialsCandidate struct {
+	// Provider is the identity of the provider offering the candidate.
+	// It is empty for credentials explicitly set for a consumer identity.
+	Provider ProviderIdentity
+	// Explicit indicates credentials explicitly set for a consumer identity.
+	Explicit bool
+	// Identity is the consumer identity offered by the provider. For
+	// consumer providers it is nil, if the provider has no matching identity.
+	Identity ConsumerIdentity
+	// Matches indicates that the identity matches the requested consumer.
+	Matches bool
+	// Selected indicates that the identity was accepted by the identity
+	// matcher as better match than the candidates checked before.
+	Selected bool
+}
+
+// CredentialsExplanation describes the resolution of credentials
+// for a consumer identity.
+type CredentialsExplanation struct {
+	// Consumer is the requested consumer identity.
+	Consumer ConsumerIdentity
+	// Candidates lists the checked providers and their offered identities
+	// in the order they have been checked.
+	Candidates []*CredentialsCandidate
+	// Result is the finally chosen candidate, if a match was found.
+	Result *CredentialsCandidate
+	// Fallback indicates that the credentials are provided for the
+	// empty consumer identity.
+	Fallback bool
+	// Source is the finally chosen credential source.
+	Source CredentialsSource
+}
+
+// Explain resolves the credentials for a consumer like Match, but records
+// the identities passed to the identity matcher by the consumer providers.
+func (p *consumerProviderRegistry) Explain(pattern ConsumerIdentity, m IdentityMatcher) *CredentialsExplanation {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	e := &CredentialsExplanation{
+		Consumer: pattern,
+	}
+
+	trace := func(pid ProviderIdentity, explicit bool) IdentityMatcher {
+		var placeholder *CredentialsCandidate
+		if !explicit {
+			// providers not offering any identity are listed, also.
+			placeholder = &CredentialsCandidate{Provider: pid}
+			e.Candidates = append(e.Candidates, placeholder)
+		}
+		return func(pattern, cur, id ConsumerIdentity) bool {
+			c := placeholder
+			if c == nil {
+				c = &CredentialsCandidate{Provider: pid, Explicit: explicit}
+				if explicit {
+					if s := p.explicit.data[string(id.Key())]; s != nil {
+						c.Provider = s.providerId
+					}
+				}
+				e.Candidates = append(e.Candidates, c)
+			}
+			placeholder = nil
+			c.Identity = id
+			c.Matches = m(pattern, nil, id)
+			c.Selected = m(pattern, cur, id)
+			return c.Selected
+		}
+	}
+
+	src, cur := p.match(pattern, nil, trace)
+	if src != nil {
+		for i := len(e.Candidates) - 1; i >= 0; i-- {
+			if c := e.Candidates[i]; c.Selected && c.Identity.Equals(cur) {
+				e.Result = c
+				break
+			}
+		}
+		e.Source = src
+	} else {
+		if src, ok := p.get(emptyIdentity); ok && src != nil {
+			e.Source = src
+			e.Fallback = true
+		}
+	}
+	return e
+}
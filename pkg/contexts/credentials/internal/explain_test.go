@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/internal"
+)
+
+// provider offers a fixed identity.
+type provider struct {
+	id    internal.ConsumerIdentity
+	creds internal.Credentials
+}
+
+func (p *provider) Unregister(internal.ProviderIdentity) {}
+
+func (p *provider) Get(id internal.ConsumerIdentity) (internal.CredentialsSource, bool) {
+	return nil, false
+}
+
+func (p *provider) Match(pattern, cur internal.ConsumerIdentity, m internal.IdentityMatcher) (internal.CredentialsSource, internal.ConsumerIdentity) {
+	if m(pattern, cur, p.id) {
+		return p.creds, p.id
+	}
+	return nil, cur
+}
+
+// silent never offers an identity.
+type silent struct{}
+
+func (silent) Unregister(internal.ProviderIdentity) {}
+
+func (silent) Get(internal.ConsumerIdentity) (internal.CredentialsSource, bool) {
+	return nil, false
+}
+
+func (silent) Match(pattern, cur internal.ConsumerIdentity, m internal.IdentityMatcher) (internal.CredentialsSource, internal.ConsumerIdentity) {
+	return nil, cur
+}
+
+var _ = Describe("credential resolution", func() {
+	consumer := credentials.ConsumerIdentity{
+		hostpath.ID_TYPE:       "test",
+		hostpath.ID_HOSTNAME:   "ghcr.io",
+		hostpath.ID_PATHPREFIX: "a/b",
+	}
+
+	It("explains resolution", func() {
+		ctx := credentials.New()
+		generic := credentials.ConsumerIdentity{hostpath.ID_TYPE: "test", hostpath.ID_HOSTNAME: "ghcr.io"}
+		specific := credentials.ConsumerIdentity{hostpath.ID_TYPE: "test", hostpath.ID_HOSTNAME: "ghcr.io", hostpath.ID_PATHPREFIX: "a"}
+		ctx.SetCredentialsForConsumer(generic, credentials.DirectCredentials{"username": "generic"})
+		ctx.SetCredentialsForConsumer(specific, credentials.DirectCredentials{"username": "specific"})
+
+		e := Must(ctx.ExplainCredentialsForConsumer(consumer, hostpath.IdentityMatcher("test")))
+		Expect(e.Candidates).To(HaveLen(2))
+		Expect(e.Result).NotTo(BeNil())
+		Expect(e.Result.Identity).To(Equal(specific))
+		Expect(e.Fallback).To(BeFalse())
+		Expect(Must(e.Source.Credentials(ctx)).GetProperty("username")).To(Equal("specific"))
+
+		e = Must(ctx.ExplainCredentialsForConsumer(credentials.ConsumerIdentity{hostpath.ID_TYPE: "other"}, hostpath.IdentityMatcher("test")))
+		Expect(e.Result).To(BeNil())
+		Expect(e.Source).To(BeNil())
+	})
+
+	It("traces consumer providers", func() {
+		ctx := credentials.New()
+		generic := credentials.ConsumerIdentity{hostpath.ID_TYPE: "test", hostpath.ID_HOSTNAME: "ghcr.io"}
+		specific := credentials.ConsumerIdentity{hostpath.ID_TYPE: "test", hostpath.ID_HOSTNAME: "ghcr.io", hostpath.ID_PATHPREFIX: "a"}
+		ctx.SetCredentialsForConsumer(generic, credentials.DirectCredentials{"username": "generic"})
+		ctx.RegisterConsumerProvider("a/specific", &provider{specific, credentials.DirectCredentials{"username": "specific"}})
+		ctx.RegisterConsumerProvider("b/other", &provider{credentials.ConsumerIdentity{hostpath.ID_TYPE: "other"}, nil})
+		ctx.RegisterConsumerProvider("c/none", silent{})
+
+		m := hostpath.IdentityMatcher("test")
+		e := Must(ctx.ExplainCredentialsForConsumer(consumer, m))
+		Expect(e.Candidates).To(Equal([]*credentials.CredentialsCandidate{
+			{Explicit: true, Identity: generic, Matches: true, Selected: true},
+			{Provider: "a/specific", Identity: specific, Matches: true, Selected: true},
+			{Provider: "b/other", Identity: credentials.ConsumerIdentity{hostpath.ID_TYPE: "other"}},
+			{Provider: "c/none"},
+		}))
+		Expect(e.Result).To(BeIdenticalTo(e.Candidates[1]))
+
+		// the explanation is based on the regular resolution
+		src := Must(ctx.GetCredentialsForConsumer(consumer, m))
+		Expect(Must(src.Credentials(ctx)).GetProperty("username")).To(Equal("specific"))
+		Expect(Must(e.Source.Credentials(ctx)).GetProperty("username")).To(Equal("specific"))
+	})
+})
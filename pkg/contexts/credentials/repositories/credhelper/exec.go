@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credhelper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// CMD_GET is the command passed to an executable credential helper.
+const CMD_GET = "get"
+
+// DEFAULT_TIMEOUT is the default period a credential helper
+// may take to answer a request.
+const DEFAULT_TIMEOUT = time.Minute
+
+// ExecutableHelper is a Helper calling an external executable.
+// The executable is called with the configured arguments followed
+// by the command get. The request is passed as JSON document on stdin
+// and the result is expected as JSON document on stdout.
+// An empty output indicates that no credentials are available.
+// The executable is killed if it does not answer within the timeout.
+type ExecutableHelper struct {
+	Path    string
+	Args    []string
+	Timeout time.Duration
+}
+
+var _ Helper = (*ExecutableHelper)(nil)
+
+// NewExecutableHelper creates a helper for the given executable.
+// A zero timeout selects the DEFAULT_TIMEOUT.
+func NewExecutableHelper(path string, timeout time.Duration, args ...string) *ExecutableHelper {
+	if timeout <= 0 {
+		timeout = DEFAULT_TIMEOUT
+	}
+	return &ExecutableHelper{
+		Path:    path,
+		Args:    args,
+		Timeout: timeout,
+	}
+}
+
+func (h *ExecutableHelper) GetCredentials(req *Request) (*Result, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DEFAULT_TIMEOUT
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, h.Path, append(append([]string{}, h.Args...), CMD_GET)...)
+	var stdout bytes.Buffer
+	stderr := accessio.LimitBuffer(accessio.DESCRIPTOR_LIMIT)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = accessio.LimitWriter(&stdout, accessio.DESCRIPTOR_LIMIT+1)
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("credential helper %s timed out after %s", h.Path, timeout)
+		}
+		if msg := strings.TrimSpace(string(stderr.Bytes())); msg != "" {
+			return nil, fmt.Errorf("credential helper %s failed: %s", h.Path, msg)
+		}
+		return nil, errors.Wrapf(err, "credential helper %s failed", h.Path)
+	}
+	if int64(stdout.Len()) > accessio.DESCRIPTOR_LIMIT {
+		return nil, fmt.Errorf("credential helper %s: stdout limit exceeded", h.Path)
+	}
+	return ParseResult(stdout.Bytes())
+}
+
+// ParseResult parses the output of a credential helper.
+// An empty output is mapped to a nil result.
+func ParseResult(data []byte) (*Result, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errors.Wrapf(err, "invalid credential helper result")
+	}
+	if len(result.Properties) == 0 {
+		return nil, nil
+	}
+	return &result, nil
+}
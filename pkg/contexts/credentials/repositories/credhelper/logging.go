@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credhelper
+
+import (
+	ocmlog "github.com/open-component-model/ocm/pkg/logging"
+)
+
+var REALM = ocmlog.DefineSubRealm("credential helper based credential repository", "credentials/credhelper")
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credhelper
+
+import (
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+)
+
+// Request is the request passed to a credential helper.
+// It either asks for the credentials for a consumer identity
+// or for a named credential set.
+type Request struct {
+	ConsumerId cpi.ConsumerIdentity `json:"consumerId,omitempty"`
+	Name       string               `json:"name,omitempty"`
+}
+
+func (r *Request) Key() string {
+	if r.Name != "" {
+		return "name:" + r.Name
+	}
+	return "id:" + r.ConsumerId.String()
+}
+
+// Result is the answer of a credential helper. If no properties
+// are returned, the helper is not responsible for the request.
+// The consumer identity optionally describes the identity the
+// credentials are provided for. It is used to compare the result with
+// credentials provided by other sources. By default, the requested
+// identity is used. The TTL optionally overwrites the configured
+// caching period (in seconds).
+type Result struct {
+	ConsumerId cpi.ConsumerIdentity `json:"consumerId,omitempty"`
+	Properties common.Properties    `json:"properties,omitempty"`
+	TTL        *int                 `json:"ttl,omitempty"`
+}
+
+// Helper is the interface for a credential helper.
+type Helper interface {
+	// GetCredentials requests the credentials for the given request.
+	// A nil result or a result without properties indicates that
+	// no credentials are found.
+	GetCredentials(req *Request) (*Result, error)
+}
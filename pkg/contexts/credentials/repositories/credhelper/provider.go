@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credhelper
+
+import (
+	"sync"
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/internal"
+)
+
+const PROVIDER = "ocm.software/credentialprovider/" + Type
+
+// DEFAULT_TTL is the default caching period for results of a
+// credential helper.
+const DEFAULT_TTL = 5 * time.Minute
+
+// ANY_CONSUMER_TYPE can be used as consumer type to ask the helper
+// for all consumer identities.
+const ANY_CONSUMER_TYPE = "*"
+
+type entry struct {
+	result  *Result
+	expires time.Time
+}
+
+// call is a pending helper request. Concurrent requests for the
+// same key wait for the result of the pending call.
+type call struct {
+	done   chan struct{}
+	result *Result
+	err    error
+}
+
+// ConsumerProvider provides the credentials for consumer identities
+// by asking a credential Helper. The results (including negative ones)
+// are cached for a configurable time period.
+type ConsumerProvider struct {
+	lock    sync.Mutex
+	ctx     cpi.Context
+	helper  Helper
+	types   map[string]bool
+	ttl     time.Duration
+	cache   map[string]*entry
+	pending map[string]*call
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+// NewConsumerProvider creates a consumer provider for the given helper.
+// The helper is only asked for the given consumer types, by default
+// for the cpi.DefaultConsumerTypes. With ANY_CONSUMER_TYPE it is asked
+// for all consumer identities.
+// A non-positive ttl disables the caching.
+func NewConsumerProvider(ctx cpi.Context, helper Helper, ttl time.Duration, types ...string) *ConsumerProvider {
+	if len(types) == 0 {
+		types = cpi.DefaultConsumerTypes
+	}
+	m := map[string]bool{}
+	for _, t := range types {
+		if t == ANY_CONSUMER_TYPE {
+			m = nil
+			break
+		}
+		m[t] = true
+	}
+	return &ConsumerProvider{
+		ctx:     ctx,
+		helper:  helper,
+		types:   m,
+		ttl:     ttl,
+		cache:   map[string]*entry{},
+		pending: map[string]*call{},
+	}
+}
+
+func (p *ConsumerProvider) Unregister(id internal.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+func (p *ConsumerProvider) get(req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	if len(req) == 0 || (p.types != nil && !p.types[req.Type()]) {
+		return nil, cur
+	}
+	result, err := p.Request(&Request{ConsumerId: req})
+	if err != nil {
+		p.ctx.Logger(REALM).LogError(err, "credential helper request failed", "consumer", req.String())
+		return nil, cur
+	}
+	if result == nil {
+		return nil, cur
+	}
+	id := result.ConsumerId
+	if len(id) == 0 {
+		id = req
+	}
+	if !m(req, cur, id) {
+		return nil, cur
+	}
+	return cpi.NewCredentials(result.Properties), id
+}
+
+// Request asks the helper for the given request, or provides a cached
+// result, if available. The helper is executed without holding the
+// lock of the provider, concurrent requests with the same key share
+// a single helper execution.
+func (p *ConsumerProvider) Request(req *Request) (*Result, error) {
+	key := req.Key()
+
+	p.lock.Lock()
+	if e := p.cache[key]; e != nil {
+		if time.Now().Before(e.expires) {
+			p.lock.Unlock()
+			return e.result, nil
+		}
+		delete(p.cache, key)
+	}
+	if c := p.pending[key]; c != nil {
+		p.lock.Unlock()
+		<-c.done
+		return c.result, c.err
+	}
+	c := &call{done: make(chan struct{})}
+	p.pending[key] = c
+	p.lock.Unlock()
+
+	c.result, c.err = p.request(req)
+
+	p.lock.Lock()
+	delete(p.pending, key)
+	if c.err == nil {
+		ttl := p.ttl
+		if c.result != nil && c.result.TTL != nil {
+			ttl = time.Duration(*c.result.TTL) * time.Second
+		}
+		if ttl > 0 {
+			p.cache[key] = &entry{result: c.result, expires: time.Now().Add(ttl)}
+		}
+	}
+	p.lock.Unlock()
+	close(c.done)
+	return c.result, c.err
+}
+
+func (p *ConsumerProvider) request(req *Request) (*Result, error) {
+	result, err := p.helper.GetCredentials(req)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil && len(result.Properties) == 0 {
+		result = nil
+	}
+	return result, nil
+}
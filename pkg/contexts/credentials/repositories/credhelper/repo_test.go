@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package credhelper_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/credhelper"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/identity"
+)
+
+type blockingHelper struct {
+	lock    sync.Mutex
+	calls   map[string]int
+	started chan struct{}
+	release chan struct{}
+}
+
+func (h *blockingHelper) Calls() map[string]int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.calls
+}
+
+func (h *blockingHelper) GetCredentials(req *credhelper.Request) (*credhelper.Result, error) {
+	h.lock.Lock()
+	if h.calls == nil {
+		h.calls = map[string]int{}
+	}
+	h.calls[req.Name]++
+	h.lock.Unlock()
+
+	if req.Name == "blocked" {
+		h.started <- struct{}{}
+		<-h.release
+	}
+	return &credhelper.Result{Properties: common.Properties{"name": req.Name}}, nil
+}
+
+var _ = Describe("credential helper repository", func() {
+	var ctx credentials.Context
+	var counter string
+	var spec *credhelper.RepositorySpec
+
+	calls := func() int {
+		data, err := os.ReadFile(counter)
+		if err != nil {
+			return 0
+		}
+		return strings.Count(string(data), "x")
+	}
+
+	consumer := func(host string) cpi.ConsumerIdentity {
+		return cpi.ConsumerIdentity{
+			cpi.ID_TYPE:            identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME:   host,
+			identity.ID_PATHPREFIX: "ocm",
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = credentials.New()
+		counter = filepath.Join(GinkgoT().TempDir(), "counter")
+		spec = credhelper.NewRepositorySpec(Must(filepath.Abs("testdata/helper")), counter)
+	})
+
+	It("serializes repo spec", func() {
+		spec := credhelper.NewRepositorySpec("helper", "arg").WithConsumerTypes(identity.CONSUMER_TYPE).WithTTL(time.Minute)
+		data := Must(json.Marshal(spec))
+		Expect(string(data)).To(Equal(`{"type":"CredentialHelper","executable":"helper","args":["arg"],"consumerTypes":["OCIRegistry"],"ttl":"1m0s"}`))
+
+		s := Must(ctx.RepositorySpecForConfig(data, nil))
+		Expect(reflect.TypeOf(s).String()).To(Equal("*credhelper.RepositorySpec"))
+		Expect(s).To(Equal(spec))
+	})
+
+	It("rejects invalid timeout", func() {
+		spec.Timeout = "invalid"
+		_, err := ctx.RepositoryForSpec(spec)
+		Expect(err).To(MatchError(ContainSubstring(`invalid timeout "invalid"`)))
+	})
+
+	It("rejects invalid ttl", func() {
+		spec.TTL = "invalid"
+		_, err := ctx.RepositoryForSpec(spec)
+		Expect(err).To(MatchError(ContainSubstring(`invalid ttl "invalid"`)))
+	})
+
+	It("provides named credentials", func() {
+		repo := Must(ctx.RepositoryForSpec(spec))
+		Expect(repo.ExistsCredentials("test")).To(BeTrue())
+		Expect(repo.ExistsCredentials("other")).To(BeFalse())
+		Expect(Must(repo.LookupCredentials("test")).Properties()).To(Equal(common.Properties{"token": "test"}))
+
+		_, err := repo.LookupCredentials("other")
+		Expect(err).To(MatchError(`credentials "other" is unknown`))
+		Expect(calls()).To(Equal(2))
+	})
+
+	It("provides and caches consumer credentials", func() {
+		Must(ctx.RepositoryForSpec(spec))
+
+		creds := Must(credentials.CredentialsForConsumer(ctx, consumer("ghcr.io"), identity.IdentityMatcher))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			cpi.ATTR_USERNAME: "ocm",
+			cpi.ATTR_PASSWORD: "secret",
+		}))
+		Expect(calls()).To(Equal(1))
+
+		creds = Must(credentials.CredentialsForConsumer(ctx, consumer("ghcr.io"), identity.IdentityMatcher))
+		Expect(creds).NotTo(BeNil())
+		Expect(calls()).To(Equal(1))
+
+		creds = Must(credentials.CredentialsForConsumer(ctx, consumer("other.io"), identity.IdentityMatcher))
+		Expect(creds).To(BeNil())
+		creds = Must(credentials.CredentialsForConsumer(ctx, consumer("other.io"), identity.IdentityMatcher))
+		Expect(creds).To(BeNil())
+		Expect(calls()).To(Equal(2))
+	})
+
+	It("respects ttl of result", func() {
+		Must(ctx.RepositoryForSpec(spec))
+
+		for i := 1; i <= 2; i++ {
+			creds := Must(credentials.CredentialsForConsumer(ctx, consumer("short.io"), identity.IdentityMatcher))
+			Expect(creds.Properties()).To(Equal(common.Properties{
+				cpi.ATTR_USERNAME: "short",
+				cpi.ATTR_PASSWORD: "lived",
+			}))
+			Expect(calls()).To(Equal(i))
+		}
+	})
+
+	It("restricts consumer types", func() {
+		Must(ctx.RepositoryForSpec(spec.WithConsumerTypes("other")))
+
+		creds := Must(credentials.CredentialsForConsumer(ctx, consumer("ghcr.io"), identity.IdentityMatcher))
+		Expect(creds).To(BeNil())
+		Expect(calls()).To(Equal(0))
+	})
+
+	It("asks only for default consumer types", func() {
+		Must(ctx.RepositoryForSpec(spec))
+
+		creds := Must(credentials.CredentialsForConsumer(ctx, cpi.ConsumerIdentity{
+			cpi.ID_TYPE:          "other",
+			identity.ID_HOSTNAME: "ghcr.io",
+		}))
+		Expect(creds).To(BeNil())
+		Expect(calls()).To(Equal(0))
+
+		Must(ctx.RepositoryForSpec(spec.WithConsumerTypes(credhelper.ANY_CONSUMER_TYPE)))
+		creds = Must(credentials.CredentialsForConsumer(ctx, cpi.ConsumerIdentity{
+			cpi.ID_TYPE:          "other",
+			identity.ID_HOSTNAME: "ghcr.io",
+		}))
+		Expect(creds).To(BeNil())
+		Expect(calls()).To(Equal(1))
+	})
+
+	It("does not block other requests while the helper is running", func() {
+		helper := &blockingHelper{
+			started: make(chan struct{}, 10),
+			release: make(chan struct{}),
+		}
+		provider := credhelper.NewConsumerProvider(ctx, helper, time.Minute)
+
+		var wg sync.WaitGroup
+		results := make([]*credhelper.Result, 3)
+		for i := range results {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _ = provider.Request(&credhelper.Request{Name: "blocked"})
+			}()
+		}
+		<-helper.started
+
+		r := Must(provider.Request(&credhelper.Request{Name: "other"}))
+		Expect(r.Properties).To(Equal(common.Properties{"name": "other"}))
+
+		close(helper.release)
+		wg.Wait()
+		for _, r := range results {
+			Expect(r.Properties).To(Equal(common.Properties{"name": "blocked"}))
+		}
+		Expect(helper.Calls()).To(Equal(map[string]int{"blocked": 1, "other": 1}))
+	})
+
+	It("ignores failing helper", func() {
+		Must(ctx.RepositoryForSpec(spec))
+
+		creds := Must(credentials.CredentialsForConsumer(ctx, consumer("fail.io"), identity.IdentityMatcher))
+		Expect(creds).To(BeNil())
+	})
+
+	It("reports helper errors", func() {
+		provider := credhelper.NewConsumerProvider(ctx, spec.Helper(), 0)
+		_, err := provider.Request(&credhelper.Request{ConsumerId: consumer("fail.io")})
+		Expect(err).To(MatchError(ContainSubstring("helper failed")))
+	})
+
+	It("kills hanging helper", func() {
+		spec := credhelper.NewRepositorySpec(Must(filepath.Abs("testdata/hanging"))).WithTimeout(100 * time.Millisecond)
+		provider := credhelper.NewConsumerProvider(ctx, spec.Helper(), 0)
+
+		start := time.Now()
+		_, err := provider.Request(&credhelper.Request{ConsumerId: consumer("ghcr.io")})
+		Expect(err).To(MatchError(ContainSubstring("timed out after 100ms")))
+		Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+	})
+})
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credhelper
+
+import (
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// Repository provides the credentials delivered by a credential helper.
+// Named credentials are requested from the helper by name, additionally
+// the helper is registered as consumer provider at the credential context.
+type Repository struct {
+	ctx      cpi.Context
+	spec     *RepositorySpec
+	provider *ConsumerProvider
+}
+
+func NewRepository(ctx cpi.Context, spec *RepositorySpec) (*Repository, error) {
+	ttl, err := spec.GetTTL()
+	if err != nil {
+		return nil, err
+	}
+	r := &Repository{
+		ctx:      ctx,
+		spec:     spec,
+		provider: NewConsumerProvider(ctx, spec.Helper(), ttl, spec.ConsumerTypes...),
+	}
+	ctx.RegisterConsumerProvider(r.providerId(), r.provider)
+	return r, nil
+}
+
+var _ cpi.Repository = &Repository{}
+
+func (r *Repository) providerId() cpi.ProviderIdentity {
+	return cpi.ProviderIdentity(PROVIDER + "/" + strings.Join(append([]string{r.spec.Executable}, r.spec.Args...), " "))
+}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	result, err := r.provider.Request(&Request{Name: name})
+	if err != nil {
+		return false, err
+	}
+	return result != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	result, err := r.provider.Request(&Request{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, cpi.ErrUnknownCredentials(name)
+	}
+	return cpi.NewCredentials(result.Properties), nil
+}
+
+func (r *Repository) WriteCredentials(name string, creds cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credhelper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	Type   = "CredentialHelper"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(Type, cpi.NewRepositoryType(Type, &RepositorySpec{}))
+	cpi.RegisterRepositoryType(TypeV1, cpi.NewRepositoryType(TypeV1, &RepositorySpec{}))
+}
+
+// RepositorySpec describes a credential repository based on an
+// external credential helper executable.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	// Executable is the path of the credential helper executable.
+	Executable string `json:"executable"`
+	// Args are additional arguments passed to the executable.
+	Args []string `json:"args,omitempty"`
+	// ConsumerTypes are the consumer types the helper is asked for.
+	// By default, the cpi.DefaultConsumerTypes are used, ANY_CONSUMER_TYPE
+	// enables all types.
+	ConsumerTypes []string `json:"consumerTypes,omitempty"`
+	// TTL is the caching period for results of the helper.
+	TTL string `json:"ttl,omitempty"`
+	// Timeout is the period the helper may take to answer a request.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// NewRepositorySpec creates a new credential helper RepositorySpec.
+func NewRepositorySpec(executable string, args ...string) *RepositorySpec {
+	return &RepositorySpec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		Executable:          executable,
+		Args:                args,
+	}
+}
+
+func (a RepositorySpec) WithConsumerTypes(types ...string) *RepositorySpec {
+	a.ConsumerTypes = types
+	return &a
+}
+
+func (a RepositorySpec) WithTTL(ttl time.Duration) *RepositorySpec {
+	a.TTL = ttl.String()
+	return &a
+}
+
+func (a RepositorySpec) WithTimeout(timeout time.Duration) *RepositorySpec {
+	a.Timeout = timeout.String()
+	return &a
+}
+
+func (a *RepositorySpec) GetType() string {
+	return Type
+}
+
+// Helper provides the credential helper described by the specification.
+// An invalid timeout is replaced by the default, it is rejected by Validate.
+func (a *RepositorySpec) Helper() Helper {
+	timeout, _ := a.GetTimeout()
+	return NewExecutableHelper(a.Executable, timeout, a.Args...)
+}
+
+func (a *RepositorySpec) GetTTL() (time.Duration, error) {
+	if a.TTL == "" {
+		return DEFAULT_TTL, nil
+	}
+	d, err := time.ParseDuration(a.TTL)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid ttl %q", a.TTL)
+	}
+	return d, nil
+}
+
+func (a *RepositorySpec) GetTimeout() (time.Duration, error) {
+	if a.Timeout == "" {
+		return DEFAULT_TIMEOUT, nil
+	}
+	d, err := time.ParseDuration(a.Timeout)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid timeout %q", a.Timeout)
+	}
+	return d, nil
+}
+
+func (a *RepositorySpec) Validate() error {
+	if a.Executable == "" {
+		return fmt.Errorf("executable required for credential helper repository")
+	}
+	if _, err := a.GetTTL(); err != nil {
+		return err
+	}
+	_, err := a.GetTimeout()
+	return err
+}
+
+func (a *RepositorySpec) Repository(ctx cpi.Context, creds cpi.Credentials) (cpi.Repository, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	r := ctx.GetAttributes().GetOrCreateAttribute(ATTR_REPOS, newRepositories)
+	repos, ok := r.(*Repositories)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type %T to Repositories", r)
+	}
+	return repos.GetRepository(ctx, a)
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package env
+
+import (
+	"sync"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
+)
+
+const ATTR_REPOS = "github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/env"
+
+type Repositories struct {
+	lock  sync.Mutex
+	repos map[string]*Repository
+}
+
+func newRepositories(datacontext.Context) interface{} {
+	return &Repositories{
+		repos: map[string]*Repository{},
+	}
+}
+
+func (r *Repositories) GetRepository(ctx cpi.Context, prefix string, propagate bool) (*Repository, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	repo := r.repos[prefix]
+	if repo == nil {
+		var err error
+		repo, err = NewRepository(ctx, prefix, propagate)
+		if err != nil {
+			return nil, err
+		}
+		r.repos[prefix] = repo
+	} else if propagate {
+		repo.Propagate()
+	}
+	return repo, nil
+}
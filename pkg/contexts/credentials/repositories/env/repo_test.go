@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package env_test
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/env"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/identity"
+)
+
+const PREFIX = "OCMTEST_"
+
+var _ = Describe("environment credential repository", func() {
+	var ctx credentials.Context
+
+	vars := map[string]string{
+		PREFIX + "GHCR__USERNAME":         "ocm",
+		PREFIX + "GHCR__PASSWORD":         "secret",
+		PREFIX + "GHCR__ID_TYPE":          identity.CONSUMER_TYPE,
+		PREFIX + "GHCR__ID_HOSTNAME":      "ghcr.io",
+		PREFIX + "GHCR__ID_PATH_PREFIX":   "open-component-model",
+		PREFIX + "AWS__AWS_ACCESS_KEY_ID": "id",
+		PREFIX + "AWS__CUSTOM_FIELD":      "custom",
+		PREFIX + "AWS__otherField":        "other",
+		PREFIX + "INVALID":                "invalid",
+	}
+
+	BeforeEach(func() {
+		ctx = credentials.New()
+		for k, v := range vars {
+			os.Setenv(k, v)
+		}
+	})
+
+	AfterEach(func() {
+		for k := range vars {
+			os.Unsetenv(k)
+		}
+	})
+
+	It("serializes repo spec", func() {
+		spec := env.NewRepositorySpec(PREFIX, true)
+		data := Must(json.Marshal(spec))
+		Expect(string(data)).To(Equal(`{"type":"Env","prefix":"OCMTEST_","propagateConsumerIdentity":true}`))
+
+		s := Must(ctx.RepositorySpecForConfig(data, nil))
+		Expect(reflect.TypeOf(s).String()).To(Equal("*env.RepositorySpec"))
+		Expect(s).To(Equal(spec))
+	})
+
+	It("maps property names", func() {
+		Expect(env.PropertyName("USERNAME")).To(Equal(cpi.ATTR_USERNAME))
+		Expect(env.PropertyName("IDENTITY_TOKEN")).To(Equal(cpi.ATTR_IDENTITY_TOKEN))
+		Expect(env.PropertyName("AWS_SECRET_ACCESS_KEY")).To(Equal("awsSecretAccessKey"))
+		Expect(env.PropertyName("PATHPREFIX")).To(Equal("pathprefix"))
+		Expect(env.PropertyName("MY_PROPERTY")).To(Equal("myProperty"))
+		Expect(env.PropertyName("myProperty")).To(Equal("myProperty"))
+	})
+
+	It("reads credentials", func() {
+		repo := Must(ctx.RepositoryForSpec(env.NewRepositorySpec(PREFIX)))
+
+		Expect(repo.ExistsCredentials("ghcr")).To(BeTrue())
+		Expect(repo.ExistsCredentials("invalid")).To(BeFalse())
+
+		c := Must(repo.LookupCredentials("ghcr"))
+		Expect(c.Properties()).To(Equal(common.Properties{
+			cpi.ATTR_USERNAME: "ocm",
+			cpi.ATTR_PASSWORD: "secret",
+		}))
+		c = Must(repo.LookupCredentials("aws"))
+		Expect(c.Properties()).To(Equal(common.Properties{
+			"awsAccessKeyID": "id",
+			"customField":    "custom",
+			"otherField":     "other",
+		}))
+		_, err := repo.LookupCredentials("invalid")
+		Expect(err).To(MatchError(`credentials "invalid" is unknown`))
+	})
+
+	It("propagates consumer identities", func() {
+		id := cpi.ConsumerIdentity{
+			identity.ID_TYPE:       identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME:   "ghcr.io",
+			identity.ID_PATHPREFIX: "open-component-model/ocm",
+		}
+		Expect(Must(credentials.CredentialsForConsumer(ctx, id, identity.IdentityMatcher))).To(BeNil())
+
+		Must(ctx.RepositoryForSpec(env.NewRepositorySpec(PREFIX, true)))
+		c := Must(credentials.CredentialsForConsumer(ctx, id, identity.IdentityMatcher))
+		Expect(c).NotTo(BeNil())
+		Expect(c.GetProperty(cpi.ATTR_USERNAME)).To(Equal("ocm"))
+	})
+
+	It("is configurable by the credentials config", func() {
+		cfg := `
+type: credentials.config.ocm.software
+repositories:
+  - repository:
+      type: Env
+      prefix: ` + PREFIX + `
+      propagateConsumerIdentity: true
+`
+		_, err := ctx.ConfigContext().ApplyData([]byte(cfg), nil, "config")
+		MustBeSuccessful(err)
+		c := Must(credentials.CredentialsForConsumer(ctx, cpi.ConsumerIdentity{
+			identity.ID_TYPE:       identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME:   "ghcr.io",
+			identity.ID_PATHPREFIX: "open-component-model",
+		}, identity.IdentityMatcher))
+		Expect(c).NotTo(BeNil())
+		Expect(c.GetProperty(cpi.ATTR_PASSWORD)).To(Equal("secret"))
+	})
+})
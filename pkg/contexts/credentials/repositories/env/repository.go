@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package env
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/internal"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+const (
+	// SEPARATOR separates the credential name from the property name
+	// in an environment variable name.
+	SEPARATOR = "__"
+	// ID_PREFIX marks a property describing an attribute of the consumer identity.
+	ID_PREFIX = "ID_"
+)
+
+// knownNames are the well-known property and identity attribute names.
+// Environment variable names are mapped to those names
+// case-insensitively and ignoring underscores.
+var knownNames = []string{
+	cpi.ATTR_USERNAME,
+	cpi.ATTR_PASSWORD,
+	cpi.ATTR_SERVER_ADDRESS,
+	cpi.ATTR_IDENTITY_TOKEN,
+	cpi.ATTR_REGISTRY_TOKEN,
+	cpi.ATTR_TOKEN,
+	cpi.ATTR_KEY,
+	cpi.ATTR_PRIVATE_KEY,
+	cpi.ATTR_AZURE_ACCOUNT_KEY,
+	cpi.ATTR_AZURE_SAS_TOKEN,
+	internal.ATTR_AWS_ACCESS_KEY_ID,
+	internal.ATTR_AWS_SECRET_ACCESS_KEY,
+	hostpath.ID_TYPE,
+	hostpath.ID_HOSTNAME,
+	hostpath.ID_PORT,
+	hostpath.ID_PATHPREFIX,
+	hostpath.ID_SCHEME,
+}
+
+type entry struct {
+	props common.Properties
+	id    cpi.ConsumerIdentity
+}
+
+type Repository struct {
+	lock      sync.RWMutex
+	ctx       cpi.Context
+	prefix    string
+	propagate bool
+	entries   map[string]*entry
+}
+
+func NewRepository(ctx cpi.Context, prefix string, propagate bool) (*Repository, error) {
+	r := &Repository{
+		ctx:       ctx,
+		prefix:    prefix,
+		propagate: propagate,
+	}
+	err := r.Read(true)
+	return r, err
+}
+
+var _ cpi.Repository = &Repository{}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	err := r.Read(false)
+	if err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.entries[name] != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	err := r.Read(false)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	e := r.entries[name]
+	if e == nil {
+		return nil, cpi.ErrUnknownCredentials(name)
+	}
+	return cpi.NewCredentials(e.props), nil
+}
+
+func (r *Repository) WriteCredentials(name string, creds cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
+
+// Propagate enables the propagation of the consumer identities.
+func (r *Repository) Propagate() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.propagate {
+		r.propagate = true
+		r.ctx.RegisterConsumerProvider(r.providerId(), &ConsumerProvider{r})
+	}
+}
+
+func (r *Repository) providerId() cpi.ProviderIdentity {
+	return cpi.ProviderIdentity(PROVIDER + "/" + r.prefix)
+}
+
+// Read (re-)reads the credentials from the process environment.
+// Variables of the form <prefix><name>__<property> describe the
+// credential properties, variables of the form
+// <prefix><name>__ID_<attribute> the consumer identity the
+// credentials should be used for.
+func (r *Repository) Read(force bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !force && r.entries != nil {
+		return nil
+	}
+
+	entries := map[string]*entry{}
+	for _, v := range os.Environ() {
+		i := strings.Index(v, "=")
+		if i < 0 {
+			continue
+		}
+		key, value := v[:i], v[i+1:]
+		if !strings.HasPrefix(key, r.prefix) {
+			continue
+		}
+		key = key[len(r.prefix):]
+		i = strings.Index(key, SEPARATOR)
+		if i <= 0 || i+len(SEPARATOR) == len(key) {
+			continue
+		}
+		name, prop := strings.ToLower(key[:i]), key[i+len(SEPARATOR):]
+		e := entries[name]
+		if e == nil {
+			e = &entry{props: common.Properties{}}
+			entries[name] = e
+		}
+		if strings.HasPrefix(prop, ID_PREFIX) && len(prop) > len(ID_PREFIX) {
+			if e.id == nil {
+				e.id = cpi.ConsumerIdentity{}
+			}
+			e.id[PropertyName(prop[len(ID_PREFIX):])] = value
+		} else {
+			e.props[PropertyName(prop)] = value
+		}
+	}
+	r.entries = entries
+	if r.propagate {
+		r.ctx.RegisterConsumerProvider(r.providerId(), &ConsumerProvider{r})
+	}
+	return nil
+}
+
+// PropertyName maps the property part of an environment variable name
+// to a property name. Well-known names are matched case-insensitively
+// ignoring underscores, names with lower case characters are taken as they
+// are, all other names are converted from snake case to camel case.
+func PropertyName(name string) string {
+	norm := strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	for _, n := range knownNames {
+		if strings.ToLower(n) == norm {
+			return n
+		}
+	}
+	if strings.ToUpper(name) != name {
+		return name
+	}
+	var result strings.Builder
+	for i, p := range strings.Split(strings.ToLower(name), "_") {
+		if p == "" {
+			continue
+		}
+		if i > 0 && result.Len() > 0 {
+			p = strings.ToUpper(p[:1]) + p[1:]
+		}
+		result.WriteString(p)
+	}
+	return result.String()
+}
+
+func (r *Repository) names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return utils.StringMapKeys(r.entries)
+}
+
+func (r *Repository) consumerId(name string) cpi.ConsumerIdentity {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if e := r.entries[name]; e != nil {
+		return e.id
+	}
+	return nil
+}
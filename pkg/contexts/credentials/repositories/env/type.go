@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package env
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	Type   = "Env"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+// DEFAULT_PREFIX is the default prefix of environment variables
+// describing credentials.
+const DEFAULT_PREFIX = "OCM_CREDENTIALS_"
+
+func init() {
+	cpi.RegisterRepositoryType(Type, cpi.NewRepositoryType(Type, &RepositorySpec{}))
+	cpi.RegisterRepositoryType(TypeV1, cpi.NewRepositoryType(TypeV1, &RepositorySpec{}))
+}
+
+// RepositorySpec describes an environment variable based credential repository interface.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	Prefix                      string `json:"prefix,omitempty"`
+	PropagateConsumerIdentity   bool   `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// NewRepositorySpec creates a new environment RepositorySpec.
+func NewRepositorySpec(prefix string, prop ...bool) *RepositorySpec {
+	p := false
+	for _, e := range prop {
+		p = p || e
+	}
+	return &RepositorySpec{
+		ObjectVersionedType:       runtime.NewVersionedObjectType(Type),
+		Prefix:                    prefix,
+		PropagateConsumerIdentity: p,
+	}
+}
+
+func (a *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (a *RepositorySpec) GetPrefix() string {
+	if a.Prefix == "" {
+		return DEFAULT_PREFIX
+	}
+	return a.Prefix
+}
+
+func (a *RepositorySpec) Repository(ctx cpi.Context, creds cpi.Credentials) (cpi.Repository, error) {
+	r := ctx.GetAttributes().GetOrCreateAttribute(ATTR_REPOS, newRepositories)
+	repos, ok := r.(*Repositories)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type %T to Repositories", r)
+	}
+	return repos.GetRepository(ctx, a.GetPrefix(), a.PropagateConsumerIdentity)
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package netrc
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
+)
+
+const ATTR_REPOS = "github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/netrc"
+
+type Repositories struct {
+	lock  sync.Mutex
+	repos map[string]*Repository
+}
+
+func newRepositories(datacontext.Context) interface{} {
+	return &Repositories{
+		repos: map[string]*Repository{},
+	}
+}
+
+func (r *Repositories) GetRepository(ctx cpi.Context, path string, types []string, propagate bool) (*Repository, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := path + ":" + strings.Join(types, ",")
+	repo := r.repos[key]
+	if repo == nil {
+		var err error
+		repo, err = NewRepository(ctx, path, types, propagate)
+		if err != nil {
+			return nil, err
+		}
+		r.repos[key] = repo
+	} else if propagate {
+		repo.Propagate()
+	}
+	return repo, nil
+}
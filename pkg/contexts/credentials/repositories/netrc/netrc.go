@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package netrc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// DEFAULT_MACHINE is the credential name used for the default entry.
+const DEFAULT_MACHINE = "default"
+
+// Machine is a machine entry of a netrc file.
+type Machine struct {
+	Name     string
+	Login    string
+	Password string
+	Account  string
+}
+
+// Parse parses the content of a netrc file. The default
+// entry is returned with the name DEFAULT_MACHINE.
+func Parse(data []byte) ([]*Machine, error) {
+	var (
+		result []*Machine
+		cur    *Machine
+		tokens []string
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	inMacro := false
+	// value indicates that the next token is the value of a keyword.
+	// Values may start with a '#', comments only start at the
+	// beginning of a line or a keyword token.
+	value := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if inMacro {
+			// macro definitions end with an empty line
+			if strings.TrimSpace(line) == "" {
+				inMacro = false
+			}
+			continue
+		}
+		for _, f := range strings.Fields(line) {
+			if value {
+				tokens = append(tokens, f)
+				value = false
+				continue
+			}
+			if strings.HasPrefix(f, "#") {
+				break
+			}
+			if f == "macdef" {
+				// skip macro name, the rest of the line and the macro body
+				inMacro = true
+				break
+			}
+			tokens = append(tokens, f)
+			switch f {
+			case "machine", "login", "password", "account":
+				value = true
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	next := func(i int, key string) (string, error) {
+		if i+1 >= len(tokens) {
+			return "", fmt.Errorf("netrc: missing value for %q", key)
+		}
+		return tokens[i+1], nil
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch t {
+		case "machine":
+			name, err := next(i, t)
+			if err != nil {
+				return nil, err
+			}
+			cur = &Machine{Name: name}
+			result = append(result, cur)
+			i++
+		case DEFAULT_MACHINE:
+			cur = &Machine{Name: DEFAULT_MACHINE}
+			result = append(result, cur)
+		case "login", "password", "account":
+			if cur == nil {
+				return nil, fmt.Errorf("netrc: %q outside of machine entry", t)
+			}
+			v, err := next(i, t)
+			if err != nil {
+				return nil, err
+			}
+			switch t {
+			case "login":
+				cur.Login = v
+			case "password":
+				cur.Password = v
+			case "account":
+				cur.Account = v
+			}
+			i++
+		default:
+			return nil, fmt.Errorf("netrc: unexpected token %q", t)
+		}
+	}
+	return result, nil
+}
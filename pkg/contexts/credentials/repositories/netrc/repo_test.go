@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package netrc_test
+
+import (
+	"encoding/json"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/netrc"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/identity"
+	gitidentity "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/git/identity"
+	helmidentity "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/helm/identity"
+	mavenidentity "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven/identity"
+	pypiidentity "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/pypi/identity"
+	wgetidentity "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/wget/identity"
+)
+
+const NETRC = `
+# build credentials
+machine ghcr.io
+  login ocm
+  password secret
+
+machine artifacts.example.com:8443 login deployer password other account builds
+
+macdef init
+cd /pub
+bin
+
+default login anonymous password guest
+`
+
+var _ = Describe("netrc credential repository", func() {
+	var ctx credentials.Context
+
+	BeforeEach(func() {
+		ctx = credentials.New()
+		fs := memoryfs.New()
+		MustBeSuccessful(vfs.WriteFile(fs, "/netrc", []byte(NETRC), 0o600))
+		vfsattr.Set(ctx, fs)
+	})
+
+	It("serializes repo spec", func() {
+		spec := netrc.NewRepositorySpec("/netrc", true).WithConsumerTypes(identity.CONSUMER_TYPE)
+		data := Must(json.Marshal(spec))
+		Expect(string(data)).To(Equal(`{"type":"NetRC","path":"/netrc","consumerTypes":["OCIRegistry"],"propagateConsumerIdentity":true}`))
+
+		s := Must(ctx.RepositorySpecForConfig(data, nil))
+		Expect(reflect.TypeOf(s).String()).To(Equal("*netrc.RepositorySpec"))
+		Expect(s).To(Equal(spec))
+	})
+
+	It("parses netrc files", func() {
+		machines := Must(netrc.Parse([]byte(NETRC)))
+		Expect(machines).To(Equal([]*netrc.Machine{
+			{Name: "ghcr.io", Login: "ocm", Password: "secret"},
+			{Name: "artifacts.example.com:8443", Login: "deployer", Password: "other", Account: "builds"},
+			{Name: netrc.DEFAULT_MACHINE, Login: "anonymous", Password: "guest"},
+		}))
+
+		machines = Must(netrc.Parse([]byte("machine ghcr.io # registry\n  login ocm password se#cret\n#comment\nmachine other login #user password #pass")))
+		Expect(machines).To(Equal([]*netrc.Machine{
+			{Name: "ghcr.io", Login: "ocm", Password: "se#cret"},
+			{Name: "other", Login: "#user", Password: "#pass"},
+		}))
+
+		_, err := netrc.Parse([]byte("login ocm"))
+		Expect(err).To(MatchError(`netrc: "login" outside of machine entry`))
+		_, err = netrc.Parse([]byte("machine ghcr.io password"))
+		Expect(err).To(MatchError(`netrc: missing value for "password"`))
+	})
+
+	It("reads credentials", func() {
+		repo := Must(ctx.RepositoryForSpec(netrc.NewRepositorySpec("/netrc")))
+
+		Expect(repo.ExistsCredentials("ghcr.io")).To(BeTrue())
+		Expect(repo.ExistsCredentials("unknown")).To(BeFalse())
+
+		Expect(Must(repo.LookupCredentials("ghcr.io")).Properties()).To(Equal(common.Properties{
+			cpi.ATTR_USERNAME: "ocm",
+			cpi.ATTR_PASSWORD: "secret",
+		}))
+		Expect(Must(repo.LookupCredentials("artifacts.example.com:8443")).Properties()).To(Equal(common.Properties{
+			cpi.ATTR_USERNAME:  "deployer",
+			cpi.ATTR_PASSWORD:  "other",
+			netrc.ATTR_ACCOUNT: "builds",
+		}))
+		Expect(Must(repo.LookupCredentials(netrc.DEFAULT_MACHINE)).Properties()).To(Equal(common.Properties{
+			cpi.ATTR_USERNAME: "anonymous",
+			cpi.ATTR_PASSWORD: "guest",
+		}))
+	})
+
+	It("propagates consumer identities", func() {
+		Must(ctx.RepositoryForSpec(netrc.NewRepositorySpec("/netrc", true)))
+
+		c := Must(credentials.CredentialsForConsumer(ctx, cpi.ConsumerIdentity{
+			identity.ID_TYPE:       identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME:   "ghcr.io",
+			identity.ID_PATHPREFIX: "open-component-model/ocm",
+		}, identity.IdentityMatcher))
+		Expect(c).NotTo(BeNil())
+		Expect(c.GetProperty(cpi.ATTR_PASSWORD)).To(Equal("secret"))
+
+		id := Must(hostpath.GetConsumerId(wgetidentity.CONSUMER_TYPE, "https://artifacts.example.com:8443/builds/artifact.tgz"))
+		c = Must(hostpath.GetCredentials(ctx, wgetidentity.CONSUMER_TYPE, "https://artifacts.example.com:8443/builds/artifact.tgz"))
+		Expect(c).NotTo(BeNil())
+		Expect(c.GetProperty(cpi.ATTR_USERNAME)).To(Equal("deployer"))
+
+		// the port must match
+		id[hostpath.ID_PORT] = "443"
+		Expect(Must(credentials.CredentialsForConsumer(ctx, id, wgetidentity.IdentityMatcher))).To(BeNil())
+	})
+
+	It("provides known default consumer types", func() {
+		Expect(cpi.DefaultConsumerTypes).To(ConsistOf(
+			identity.CONSUMER_TYPE,
+			wgetidentity.CONSUMER_TYPE,
+			helmidentity.CONSUMER_TYPE,
+			mavenidentity.CONSUMER_TYPE,
+			pypiidentity.CONSUMER_TYPE,
+			gitidentity.CONSUMER_TYPE,
+		))
+	})
+
+	It("restricts consumer types", func() {
+		Must(ctx.RepositoryForSpec(netrc.NewRepositorySpec("/netrc", true).WithConsumerTypes(wgetidentity.CONSUMER_TYPE)))
+
+		c := Must(credentials.CredentialsForConsumer(ctx, cpi.ConsumerIdentity{
+			identity.ID_TYPE:     identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME: "ghcr.io",
+		}, identity.IdentityMatcher))
+		Expect(c).To(BeNil())
+		c = Must(hostpath.GetCredentials(ctx, wgetidentity.CONSUMER_TYPE, "https://ghcr.io/some/file"))
+		Expect(c).NotTo(BeNil())
+	})
+
+	It("is configurable by the credentials config", func() {
+		cfg := `
+type: credentials.config.ocm.software
+repositories:
+  - repository:
+      type: NetRC
+      path: /netrc
+      propagateConsumerIdentity: true
+`
+		_, err := ctx.ConfigContext().ApplyData([]byte(cfg), nil, "config")
+		MustBeSuccessful(err)
+		c := Must(credentials.CredentialsForConsumer(ctx, cpi.ConsumerIdentity{
+			identity.ID_TYPE:     identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME: "ghcr.io",
+		}, identity.IdentityMatcher))
+		Expect(c).NotTo(BeNil())
+		Expect(c.GetProperty(cpi.ATTR_USERNAME)).To(Equal("ocm"))
+	})
+})
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package netrc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+type Repository struct {
+	lock      sync.RWMutex
+	ctx       cpi.Context
+	path      string
+	types     []string
+	propagate bool
+	machines  []*Machine
+}
+
+func NewRepository(ctx cpi.Context, path string, types []string, propagate bool) (*Repository, error) {
+	r := &Repository{
+		ctx:       ctx,
+		path:      path,
+		types:     types,
+		propagate: propagate,
+	}
+	err := r.Read(true)
+	return r, err
+}
+
+var _ cpi.Repository = &Repository{}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	err := r.Read(false)
+	if err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.lookup(name) != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	err := r.Read(false)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	m := r.lookup(name)
+	if m == nil {
+		return nil, cpi.ErrUnknownCredentials(name)
+	}
+	return newCredentials(m), nil
+}
+
+func (r *Repository) WriteCredentials(name string, creds cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
+
+func (r *Repository) lookup(name string) *Machine {
+	for _, m := range r.machines {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Propagate enables the propagation of the consumer identities.
+func (r *Repository) Propagate() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.propagate {
+		r.propagate = true
+		r.ctx.RegisterConsumerProvider(r.providerId(), &ConsumerProvider{r})
+	}
+}
+
+func (r *Repository) providerId() cpi.ProviderIdentity {
+	return cpi.ProviderIdentity(PROVIDER + "/" + r.path + "/" + strings.Join(r.types, ","))
+}
+
+func (r *Repository) Read(force bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !force && r.machines != nil {
+		return nil
+	}
+
+	path := r.path
+	if path == "" {
+		path = os.Getenv("NETRC")
+		if path == "" {
+			path = "~/.netrc"
+		}
+	}
+	if strings.HasPrefix(path, "~/") {
+		home := os.Getenv("HOME")
+		path = home + path[1:]
+	}
+
+	data, err := vfs.ReadFile(vfsattr.Get(r.ctx), path)
+	if err != nil {
+		return fmt.Errorf("failed to read file '%s': %w", path, err)
+	}
+	machines, err := Parse(data)
+	if err != nil {
+		return errors.Wrapf(err, "invalid netrc file %s", path)
+	}
+	if machines == nil {
+		machines = []*Machine{}
+	}
+	r.machines = machines
+	if r.propagate {
+		r.ctx.RegisterConsumerProvider(r.providerId(), &ConsumerProvider{r})
+	}
+	return nil
+}
+
+// consumerIds provides the consumer identities for the machine entries.
+// The default entry is not propagated.
+func (r *Repository) consumerIds() ([]cpi.ConsumerIdentity, []*Machine) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var (
+		ids      []cpi.ConsumerIdentity
+		machines []*Machine
+	)
+	for _, m := range r.machines {
+		if m.Name == DEFAULT_MACHINE {
+			continue
+		}
+		host, port, err := net.SplitHostPort(m.Name)
+		if err != nil {
+			host, port = m.Name, ""
+		}
+		for _, t := range r.types {
+			id := cpi.ConsumerIdentity{
+				cpi.ID_TYPE:          t,
+				hostpath.ID_HOSTNAME: host,
+			}
+			id.SetNonEmptyValue(hostpath.ID_PORT, port)
+			ids = append(ids, id)
+			machines = append(machines, m)
+		}
+	}
+	return ids, machines
+}
+
+func newCredentials(m *Machine) cpi.Credentials {
+	props := common.Properties{}
+	props.SetNonEmptyValue(cpi.ATTR_USERNAME, m.Login)
+	props.SetNonEmptyValue(cpi.ATTR_PASSWORD, m.Password)
+	props.SetNonEmptyValue(ATTR_ACCOUNT, m.Account)
+	return cpi.NewCredentials(props)
+}
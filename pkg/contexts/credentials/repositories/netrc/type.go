@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package netrc
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	Type   = "NetRC"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(Type, cpi.NewRepositoryType(Type, &RepositorySpec{}))
+	cpi.RegisterRepositoryType(TypeV1, cpi.NewRepositoryType(TypeV1, &RepositorySpec{}))
+}
+
+// RepositorySpec describes a netrc file based credential repository interface.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	// Path is the path of the netrc file. By default, the file
+	// given by the environment variable NETRC or ~/.netrc is used.
+	Path string `json:"path,omitempty"`
+	// ConsumerTypes are the consumer types the machine entries are
+	// provided for.
+	ConsumerTypes             []string `json:"consumerTypes,omitempty"`
+	PropagateConsumerIdentity bool     `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// NewRepositorySpec creates a new netrc RepositorySpec.
+func NewRepositorySpec(path string, prop ...bool) *RepositorySpec {
+	p := false
+	for _, e := range prop {
+		p = p || e
+	}
+	return &RepositorySpec{
+		ObjectVersionedType:       runtime.NewVersionedObjectType(Type),
+		Path:                      path,
+		PropagateConsumerIdentity: p,
+	}
+}
+
+func (a RepositorySpec) WithConsumerTypes(types ...string) *RepositorySpec {
+	a.ConsumerTypes = types
+	return &a
+}
+
+func (a *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (a *RepositorySpec) GetConsumerTypes() []string {
+	if len(a.ConsumerTypes) == 0 {
+		return cpi.DefaultConsumerTypes
+	}
+	return a.ConsumerTypes
+}
+
+func (a *RepositorySpec) Repository(ctx cpi.Context, creds cpi.Credentials) (cpi.Repository, error) {
+	r := ctx.GetAttributes().GetOrCreateAttribute(ATTR_REPOS, newRepositories)
+	repos, ok := r.(*Repositories)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type %T to Repositories", r)
+	}
+	return repos.GetRepository(ctx, a.Path, a.GetConsumerTypes(), a.PropagateConsumerIdentity)
+}
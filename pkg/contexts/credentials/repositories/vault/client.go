@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// response is the generic response of the vault API.
+type response struct {
+	RequestID     string          `json:"request_id"`
+	LeaseID       string          `json:"lease_id"`
+	Renewable     bool            `json:"renewable"`
+	LeaseDuration int             `json:"lease_duration"`
+	Data          json.RawMessage `json:"data"`
+	Auth          *authInfo       `json:"auth"`
+	Errors        []string        `json:"errors"`
+}
+
+type authInfo struct {
+	ClientToken   string `json:"client_token"`
+	Renewable     bool   `json:"renewable"`
+	LeaseDuration int    `json:"lease_duration"`
+}
+
+type tokenInfo struct {
+	TTL       int  `json:"ttl"`
+	Renewable bool `json:"renewable"`
+}
+
+// client is a minimal client for the vault HTTP API.
+// It handles the login for the configured auth method and
+// keeps the used token alive.
+type client struct {
+	lock      sync.Mutex
+	spec      *RepositorySpec
+	creds     cpi.Credentials
+	fs        vfs.FileSystem
+	http      *http.Client
+	token     string
+	renewable bool
+	ttl       time.Duration
+	expires   time.Time
+}
+
+func newClient(spec *RepositorySpec, creds cpi.Credentials, fs vfs.FileSystem) *client {
+	return &client{
+		spec:  spec,
+		creds: creds,
+		fs:    fs,
+		http:  http.DefaultClient,
+	}
+}
+
+func (c *client) url(path string) string {
+	return strings.TrimSuffix(c.spec.ServerURL, "/") + "/v1/" + strings.TrimPrefix(path, "/")
+}
+
+func (c *client) do(method string, path string, token string, body interface{}) (*response, error) {
+	var data io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		data = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), method, c.url(path), data)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if c.spec.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.spec.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "vault request %s %s failed", method, path)
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read vault response")
+	}
+	var r response
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &r); err != nil {
+			return nil, errors.Wrapf(err, "invalid vault response for %s", path)
+		}
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound && len(r.Errors) == 0:
+		return nil, errors.ErrNotFound("vault path", path)
+	case resp.StatusCode >= 300:
+		if len(r.Errors) > 0 {
+			return nil, fmt.Errorf("vault request %s %s failed: %s (%d)", method, path, strings.Join(r.Errors, ", "), resp.StatusCode)
+		}
+		return nil, fmt.Errorf("vault request %s %s failed: %s", method, path, resp.Status)
+	}
+	return &r, nil
+}
+
+// Request executes a vault request with the actual client token.
+func (c *client) Request(method string, path string, body interface{}) (*response, error) {
+	token, err := c.Token()
+	if err != nil {
+		return nil, err
+	}
+	return c.do(method, path, token, body)
+}
+
+// Token provides a valid client token. It logs in if required and
+// renews the token lease before it expires.
+func (c *client) Token() (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.token != "" {
+		if c.ttl == 0 {
+			return c.token, nil
+		}
+		remaining := time.Until(c.expires)
+		if remaining > c.ttl/3 {
+			return c.token, nil
+		}
+		if c.renewable && remaining > 0 {
+			if err := c.renew(); err == nil {
+				return c.token, nil
+			}
+		}
+		if c.spec.GetAuthMethod() == AUTH_TOKEN && remaining > 0 {
+			// a static token cannot be recreated, just use it as long as possible.
+			return c.token, nil
+		}
+	}
+	if err := c.login(); err != nil {
+		return "", err
+	}
+	return c.token, nil
+}
+
+func (c *client) renew() error {
+	r, err := c.do(http.MethodPost, "auth/token/renew-self", c.token, map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+	if r.Auth == nil {
+		return fmt.Errorf("no auth info in vault response")
+	}
+	c.setAuth(r.Auth)
+	return nil
+}
+
+func (c *client) setAuth(auth *authInfo) {
+	if auth.ClientToken != "" {
+		c.token = auth.ClientToken
+	}
+	c.renewable = auth.Renewable
+	c.ttl = time.Duration(auth.LeaseDuration) * time.Second
+	c.expires = time.Now().Add(c.ttl)
+}
+
+func (c *client) credential(name string) string {
+	if c.creds == nil {
+		return ""
+	}
+	return c.creds.GetProperty(name)
+}
+
+func (c *client) login() error {
+	var (
+		body map[string]interface{}
+		path = "auth/" + strings.Trim(c.spec.GetAuthPath(), "/") + "/login"
+	)
+
+	switch c.spec.GetAuthMethod() {
+	case AUTH_TOKEN:
+		token := c.credential(ATTR_TOKEN)
+		if token == "" {
+			return fmt.Errorf("no token found for vault server %s", c.spec.ServerURL)
+		}
+		r, err := c.do(http.MethodGet, "auth/token/lookup-self", token, nil)
+		if err != nil {
+			return errors.Wrapf(err, "invalid token for vault server %s", c.spec.ServerURL)
+		}
+		var info tokenInfo
+		if err := json.Unmarshal(r.Data, &info); err != nil {
+			return errors.Wrapf(err, "invalid token info")
+		}
+		c.setAuth(&authInfo{ClientToken: token, Renewable: info.Renewable, LeaseDuration: info.TTL})
+		return nil
+	case AUTH_APPROLE:
+		roleid := c.credential(ATTR_ROLEID)
+		if roleid == "" {
+			return fmt.Errorf("no role id found for vault server %s", c.spec.ServerURL)
+		}
+		body = map[string]interface{}{
+			"role_id": roleid,
+		}
+		if secret := c.credential(ATTR_SECRETID); secret != "" {
+			body["secret_id"] = secret
+		}
+	case AUTH_KUBERNETES:
+		jwt := c.credential(ATTR_JWT)
+		if jwt == "" {
+			file := c.credential(ATTR_JWT_FILE)
+			if file == "" {
+				file = DEFAULT_JWT_FILE
+			}
+			data, err := vfs.ReadFile(c.fs, file)
+			if err != nil {
+				return errors.Wrapf(err, "cannot read service account token")
+			}
+			jwt = strings.TrimSpace(string(data))
+		}
+		body = map[string]interface{}{
+			"role": c.spec.Role,
+			"jwt":  jwt,
+		}
+	default:
+		return errors.ErrNotSupported("auth method", c.spec.AuthMethod, Type)
+	}
+
+	r, err := c.do(http.MethodPost, path, "", body)
+	if err != nil {
+		return errors.Wrapf(err, "%s login failed for vault server %s", c.spec.GetAuthMethod(), c.spec.ServerURL)
+	}
+	if r.Auth == nil || r.Auth.ClientToken == "" {
+		return fmt.Errorf("%s login for vault server %s provided no token", c.spec.GetAuthMethod(), c.spec.ServerURL)
+	}
+	c.setAuth(r.Auth)
+	return nil
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vault
+
+import (
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+// CONSUMER_TYPE is the consumer type used to request credentials
+// for a vault server.
+const CONSUMER_TYPE = "HashiCorpVault" + common.OCM_TYPE_GROUP_SUFFIX
+
+const (
+	ATTR_TOKEN       = cpi.ATTR_TOKEN
+	ATTR_ROLEID      = "roleId"
+	ATTR_SECRETID    = "secretId"
+	ATTR_JWT         = "jwt"
+	ATTR_JWT_FILE    = "jwtFile"
+	DEFAULT_JWT_FILE = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+func init() {
+	cpi.RegisterStandardIdentityMatcher(CONSUMER_TYPE, IdentityMatcher, `HashiCorp Vault credential matcher
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type.
+
+The following credential attributes are used to authenticate at the vault server:
+- <code>`+ATTR_TOKEN+`</code>: the vault token (auth method <code>`+AUTH_TOKEN+`</code>)
+- <code>`+ATTR_ROLEID+`</code> and <code>`+ATTR_SECRETID+`</code>: the AppRole credentials (auth method <code>`+AUTH_APPROLE+`</code>)
+- <code>`+ATTR_JWT+`</code> or <code>`+ATTR_JWT_FILE+`</code>: the service account token (auth method <code>`+AUTH_KUBERNETES+`</code>)
+`)
+}
+
+// GetConsumerId provides the consumer identity for a vault server.
+func GetConsumerId(serverurl string, namespace string) cpi.ConsumerIdentity {
+	u, err := utils.ParseURL(serverurl)
+	if err != nil {
+		return nil
+	}
+	id := cpi.ConsumerIdentity{
+		cpi.ID_TYPE: CONSUMER_TYPE,
+	}
+	id.SetNonEmptyValue(hostpath.ID_HOSTNAME, u.Hostname())
+	id.SetNonEmptyValue(hostpath.ID_SCHEME, u.Scheme)
+	id.SetNonEmptyValue(hostpath.ID_PORT, u.Port())
+	id.SetNonEmptyValue(hostpath.ID_PATHPREFIX, strings.Trim(namespace, "/"))
+	return id
+}
+
+func getCredentials(ctx cpi.Context, serverurl string, namespace string) (cpi.Credentials, error) {
+	id := GetConsumerId(serverurl, namespace)
+	if id == nil {
+		return nil, nil
+	}
+	return cpi.CredentialsForConsumer(ctx, id, identityMatcher)
+}
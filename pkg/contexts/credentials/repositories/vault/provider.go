@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vault
+
+import (
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/internal"
+)
+
+const PROVIDER = "ocm.software/credentialprovider/" + Type
+
+// ConsumerProvider maps the consumer identities described by the
+// secrets of a vault repository to the credentials provided by the secrets.
+// The credentials are always looked up from the repository, therefore
+// renewed or updated secrets are considered.
+type ConsumerProvider struct {
+	repo *Repository
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func (p *ConsumerProvider) Unregister(id internal.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+func (p *ConsumerProvider) get(req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	var creds cpi.CredentialsSource
+
+	for _, n := range p.repo.names() {
+		for _, id := range p.repo.consumerIds(n) {
+			if m(req, cur, id) {
+				creds = credentialGetter{p.repo, n}
+				cur = id
+			}
+		}
+	}
+	return creds, cur
+}
+
+type credentialGetter struct {
+	repo *Repository
+	name string
+}
+
+var _ cpi.CredentialsSource = credentialGetter{}
+
+func (c credentialGetter) Credentials(ctx cpi.Context, cs ...cpi.CredentialsSource) (cpi.Credentials, error) {
+	return c.repo.LookupCredentials(c.name)
+}
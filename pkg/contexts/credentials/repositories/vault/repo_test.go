@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vault_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/vault"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/identity"
+)
+
+const ROOT_TOKEN = "root"
+
+var _ = Describe("vault credential repository", func() {
+	var ctx credentials.Context
+	var server *fakeVault
+
+	creds := common.Properties{
+		"username": "ocm",
+		"password": "secret",
+	}
+	ghcr := cpi.ConsumerIdentity{
+		cpi.ID_TYPE:          identity.CONSUMER_TYPE,
+		identity.ID_HOSTNAME: "ghcr.io",
+	}
+
+	BeforeEach(func() {
+		ctx = credentials.New()
+	})
+
+	AfterEach(func() {
+		if server != nil {
+			server.Close()
+			server = nil
+		}
+	})
+
+	It("serializes repo spec", func() {
+		spec := vault.NewRepositorySpec("https://vault.example.com:8200", "ocm", true).WithKV("kv", 1).WithAuth(vault.AUTH_APPROLE, "")
+		data := Must(json.Marshal(spec))
+		Expect(string(data)).To(Equal(`{"type":"Vault","serverURL":"https://vault.example.com:8200","mountPath":"kv","kvVersion":1,"path":"ocm","authMethod":"approle","propagateConsumerIdentity":true}`))
+
+		s := Must(ctx.RepositorySpecForConfig(data, nil))
+		Expect(reflect.TypeOf(s).String()).To(Equal("*vault.RepositorySpec"))
+		Expect(s).To(Equal(spec))
+	})
+
+	It("validates repo spec", func() {
+		_, err := ctx.RepositoryForSpec(vault.NewRepositorySpec("https://vault.example.com", "").WithAuth(vault.AUTH_KUBERNETES, ""))
+		Expect(err).To(MatchError("role required for vault kubernetes auth method"))
+		_, err = ctx.RepositoryForSpec(vault.NewRepositorySpec("https://vault.example.com", "").WithKV("", 3))
+		Expect(err).To(MatchError("invalid KV version 3 for vault repository: only 1 or 2 possible"))
+	})
+
+	Context("KV v2", func() {
+		BeforeEach(func() {
+			server = newFakeVault(2, ROOT_TOKEN)
+			server.secrets["ocm/ghcr"] = map[string]interface{}{
+				"username":   "ocm",
+				"password":   "secret",
+				"consumerId": map[string]interface{}{"type": identity.CONSUMER_TYPE, "hostname": "ghcr.io"},
+			}
+			server.secrets["ocm/nested/config"] = map[string]interface{}{
+				"key":     "value",
+				"numbers": []int{1, 2},
+			}
+			server.secrets["other/ignored"] = map[string]interface{}{
+				"key": "value",
+			}
+		})
+
+		It("reads secrets with a token", func() {
+			repo := Must(ctx.RepositoryForSpec(vault.NewRepositorySpec(server.URL, "ocm"), credentials.DirectCredentials{cpi.ATTR_TOKEN: ROOT_TOKEN}))
+
+			Expect(repo.ExistsCredentials("ghcr")).To(BeTrue())
+			Expect(repo.ExistsCredentials("nested/config")).To(BeTrue())
+			Expect(repo.ExistsCredentials("unknown")).To(BeFalse())
+
+			c := Must(repo.LookupCredentials("ghcr"))
+			Expect(c.Properties()).To(Equal(creds))
+			c = Must(repo.LookupCredentials("nested/config"))
+			Expect(c.Properties()).To(Equal(common.Properties{"key": "value", "numbers": "[1,2]"}))
+
+			_, err := repo.LookupCredentials("unknown")
+			Expect(err).To(MatchError(`credentials "unknown" is unknown`))
+			_, err = repo.WriteCredentials("unknown", c)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("restricts to explicit secrets", func() {
+			repo := Must(ctx.RepositoryForSpec(vault.NewRepositorySpec(server.URL, "ocm").WithSecrets("ghcr"), credentials.DirectCredentials{cpi.ATTR_TOKEN: ROOT_TOKEN}))
+			Expect(repo.ExistsCredentials("ghcr")).To(BeTrue())
+			Expect(repo.ExistsCredentials("nested/config")).To(BeFalse())
+		})
+
+		It("propagates consumer identities", func() {
+			Must(ctx.RepositoryForSpec(vault.NewRepositorySpec(server.URL, "ocm", true), credentials.DirectCredentials{cpi.ATTR_TOKEN: ROOT_TOKEN}))
+
+			c := Must(credentials.CredentialsForConsumer(ctx, ghcr, identity.IdentityMatcher))
+			Expect(c).NotTo(BeNil())
+			Expect(c.Properties()).To(Equal(creds))
+		})
+
+		It("uses credentials configured for the vault server", func() {
+			ctx.SetCredentialsForConsumer(vault.GetConsumerId(server.URL, ""), credentials.DirectCredentials{cpi.ATTR_TOKEN: ROOT_TOKEN})
+			repo := Must(ctx.RepositoryForSpec(vault.NewRepositorySpec(server.URL, "ocm")))
+			Expect(Must(repo.LookupCredentials("ghcr")).Properties()).To(Equal(creds))
+		})
+
+		It("rejects invalid tokens", func() {
+			_, err := ctx.RepositoryForSpec(vault.NewRepositorySpec(server.URL, "ocm"), credentials.DirectCredentials{cpi.ATTR_TOKEN: "invalid"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("permission denied"))
+		})
+
+		It("authenticates with kubernetes", func() {
+			server.role = "ocm"
+			server.jwt = "service-account-token"
+
+			fs := memoryfs.New()
+			MustBeSuccessful(fs.MkdirAll(vfs.Dir(fs, vault.DEFAULT_JWT_FILE), 0o700))
+			MustBeSuccessful(vfs.WriteFile(fs, vault.DEFAULT_JWT_FILE, []byte(server.jwt+"\n"), 0o600))
+			vfsattr.Set(ctx, fs)
+
+			repo := Must(ctx.RepositoryForSpec(vault.NewRepositorySpec(server.URL, "ocm").WithAuth(vault.AUTH_KUBERNETES, "").WithRole("ocm"), credentials.DirectCredentials{}))
+			Expect(Must(repo.LookupCredentials("ghcr")).Properties()).To(Equal(creds))
+			logins, _, _ := server.Counters()
+			Expect(logins).To(Equal(1))
+		})
+	})
+
+	Context("KV v1", func() {
+		BeforeEach(func() {
+			server = newFakeVault(1, ROOT_TOKEN)
+			server.mount = "kv"
+			server.roleId = "role"
+			server.secretId = "secret"
+			server.secrets["ocm/ghcr"] = map[string]interface{}{
+				"username":   "ocm",
+				"password":   "secret",
+				"consumerId": `[{"type":"` + identity.CONSUMER_TYPE + `","hostname":"ghcr.io"}]`,
+			}
+		})
+
+		It("authenticates with AppRole and propagates consumer identities", func() {
+			spec := vault.NewRepositorySpec(server.URL, "ocm", true).WithKV("kv", 1).WithAuth(vault.AUTH_APPROLE, "")
+			repo := Must(ctx.RepositoryForSpec(spec, credentials.DirectCredentials{vault.ATTR_ROLEID: "role", vault.ATTR_SECRETID: "secret"}))
+			Expect(Must(repo.LookupCredentials("ghcr")).Properties()).To(Equal(creds))
+
+			c := Must(credentials.CredentialsForConsumer(ctx, ghcr, identity.IdentityMatcher))
+			Expect(c.Properties()).To(Equal(creds))
+		})
+
+		It("rejects invalid AppRole credentials", func() {
+			spec := vault.NewRepositorySpec(server.URL, "ocm").WithKV("kv", 1).WithAuth(vault.AUTH_APPROLE, "")
+			_, err := ctx.RepositoryForSpec(spec, credentials.DirectCredentials{vault.ATTR_ROLEID: "role", vault.ATTR_SECRETID: "other"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid role or secret ID"))
+		})
+
+		It("refreshes leases", func() {
+			server.tokenTTL = 1
+			server.secretTTL = 1
+
+			spec := vault.NewRepositorySpec(server.URL, "ocm").WithKV("kv", 1).WithAuth(vault.AUTH_APPROLE, "")
+			repo := Must(ctx.RepositoryForSpec(spec, credentials.DirectCredentials{vault.ATTR_ROLEID: "role", vault.ATTR_SECRETID: "secret"}))
+			Expect(Must(repo.LookupCredentials("ghcr")).Properties()).To(Equal(creds))
+			logins, renews, reads := server.Counters()
+			Expect([]int{logins, renews, reads}).To(Equal([]int{1, 0, 1}))
+
+			time.Sleep(1100 * time.Millisecond)
+
+			// the non-renewable token requires a new login,
+			// the secret lease is renewed.
+			Expect(Must(repo.LookupCredentials("ghcr")).Properties()).To(Equal(creds))
+			logins, renews, reads = server.Counters()
+			Expect([]int{logins, renews, reads}).To(Equal([]int{2, 1, 1}))
+		})
+	})
+})
@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+// ATTR_CONSUMER_ID is the secret field used to describe the consumer
+// identities the credentials should be used for. It may contain a single
+// identity object or a list of identities.
+const ATTR_CONSUMER_ID = "consumerId"
+
+type secret struct {
+	props     common.Properties
+	ids       []cpi.ConsumerIdentity
+	leaseId   string
+	renewable bool
+	ttl       time.Duration
+	expires   time.Time
+}
+
+func (s *secret) setLease(id string, renewable bool, duration int) {
+	s.leaseId = id
+	s.renewable = renewable
+	s.ttl = time.Duration(duration) * time.Second
+	s.expires = time.Now().Add(s.ttl)
+}
+
+func (s *secret) Expired() bool {
+	return s.ttl > 0 && time.Now().After(s.expires)
+}
+
+type Repository struct {
+	lock    sync.RWMutex
+	ctx     cpi.Context
+	spec    *RepositorySpec
+	client  *client
+	secrets map[string]*secret
+}
+
+func NewRepository(ctx cpi.Context, spec *RepositorySpec, creds cpi.Credentials) (*Repository, error) {
+	var err error
+	if creds == nil {
+		creds, err = getCredentials(ctx, spec.ServerURL, spec.Namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get credentials for vault server %s", spec.ServerURL)
+		}
+	}
+	r := &Repository{
+		ctx:    ctx,
+		spec:   spec,
+		client: newClient(spec, creds, vfsattr.Get(ctx)),
+	}
+	if err := r.Read(true); err != nil {
+		return nil, fmt.Errorf("unable to read repository content: %w", err)
+	}
+	return r, nil
+}
+
+var _ cpi.Repository = &Repository{}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	s, err := r.get(name)
+	if err != nil {
+		if errors.IsErrNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return s != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	s, err := r.get(name)
+	if err != nil {
+		if errors.IsErrNotFound(err) {
+			return nil, cpi.ErrUnknownCredentials(name)
+		}
+		return nil, err
+	}
+	if s == nil {
+		return nil, cpi.ErrUnknownCredentials(name)
+	}
+	return cpi.NewCredentials(s.props), nil
+}
+
+func (r *Repository) WriteCredentials(name string, creds cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
+
+// Read (re-)reads the secrets of the repository.
+func (r *Repository) Read(force bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !force && r.secrets != nil {
+		return nil
+	}
+
+	names := r.spec.Secrets
+	if len(names) == 0 {
+		list, err := r.list("")
+		if err != nil {
+			return err
+		}
+		names = list
+	}
+	secrets := map[string]*secret{}
+	for _, n := range names {
+		s, err := r.read(n)
+		if err != nil {
+			return errors.Wrapf(err, "secret %q", n)
+		}
+		secrets[n] = s
+	}
+	r.secrets = secrets
+
+	if r.spec.PropagateConsumerIdentity {
+		r.ctx.RegisterConsumerProvider(r.providerId(), &ConsumerProvider{r})
+	}
+	return nil
+}
+
+func (r *Repository) providerId() cpi.ProviderIdentity {
+	return cpi.ProviderIdentity(PROVIDER + "/" + r.spec.ServerURL + "/" + r.spec.Namespace + "/" + r.spec.GetMountPath() + "/" + r.spec.Path)
+}
+
+// get provides the secret for the given credential name. Secrets with
+// an expired lease are renewed or re-read.
+func (r *Repository) get(name string) (*secret, error) {
+	if err := r.Read(false); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	s := r.secrets[name]
+	if s == nil {
+		if !r.inScope(name) {
+			return nil, nil
+		}
+	} else {
+		if !s.Expired() {
+			return s, nil
+		}
+		if s.renewable && s.leaseId != "" {
+			if err := r.renew(s); err == nil {
+				return s, nil
+			}
+		}
+	}
+	s, err := r.read(name)
+	if err != nil {
+		return nil, err
+	}
+	r.secrets[name] = s
+	return s, nil
+}
+
+func (r *Repository) inScope(name string) bool {
+	if len(r.spec.Secrets) == 0 {
+		return name != "" && !strings.HasSuffix(name, "/")
+	}
+	for _, n := range r.spec.Secrets {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Repository) renew(s *secret) error {
+	resp, err := r.client.Request(http.MethodPut, "sys/leases/renew", map[string]interface{}{"lease_id": s.leaseId})
+	if err != nil {
+		return err
+	}
+	s.setLease(resp.LeaseID, resp.Renewable, resp.LeaseDuration)
+	return nil
+}
+
+func (r *Repository) secretPath(kind string, name string) string {
+	path := strings.Trim(r.spec.GetMountPath(), "/")
+	if r.spec.GetKVVersion() == 2 {
+		path += "/" + kind
+	}
+	if p := strings.Trim(r.spec.Path, "/"); p != "" {
+		path += "/" + p
+	}
+	if name != "" {
+		path += "/" + name
+	}
+	return path
+}
+
+// list recursively lists the secrets below the given folder
+// (relative to the base path).
+func (r *Repository) list(folder string) ([]string, error) {
+	resp, err := r.client.Request(http.MethodGet, r.secretPath("metadata", folder)+"?list=true", nil)
+	if err != nil {
+		if errors.IsErrNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var keys struct {
+		Keys []string `json:"keys"`
+	}
+	if err := json.Unmarshal(resp.Data, &keys); err != nil {
+		return nil, errors.Wrapf(err, "invalid secret list")
+	}
+	var result []string
+	for _, k := range keys.Keys {
+		n := k
+		if folder != "" {
+			n = folder + "/" + k
+		}
+		if strings.HasSuffix(k, "/") {
+			sub, err := r.list(strings.TrimSuffix(n, "/"))
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, sub...)
+		} else {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+func (r *Repository) read(name string) (*secret, error) {
+	resp, err := r.client.Request(http.MethodGet, r.secretPath("data", name), nil)
+	if err != nil {
+		return nil, err
+	}
+	data := resp.Data
+	if r.spec.GetKVVersion() == 2 {
+		var v2 struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(data, &v2); err != nil {
+			return nil, errors.Wrapf(err, "invalid KV v2 secret")
+		}
+		data = v2.Data
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, errors.Wrapf(err, "invalid secret data")
+	}
+
+	s := &secret{props: common.Properties{}}
+	for k, v := range values {
+		if k == ATTR_CONSUMER_ID {
+			s.ids, err = parseConsumerIds(v)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var str string
+		if err := json.Unmarshal(v, &str); err == nil {
+			s.props[k] = str
+		} else {
+			s.props[k] = string(v)
+		}
+	}
+	s.setLease(resp.LeaseID, resp.Renewable, resp.LeaseDuration)
+	return s, nil
+}
+
+func parseConsumerIds(data json.RawMessage) ([]cpi.ConsumerIdentity, error) {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		data = []byte(str)
+	}
+	var id cpi.ConsumerIdentity
+	if err := json.Unmarshal(data, &id); err == nil {
+		return []cpi.ConsumerIdentity{id}, nil
+	}
+	var ids []cpi.ConsumerIdentity
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, errors.Wrapf(err, "invalid consumer identity")
+	}
+	return ids, nil
+}
+
+// names provides the sorted names of the actually known secrets.
+func (r *Repository) names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return utils.StringMapKeys(r.secrets)
+}
+
+func (r *Repository) consumerIds(name string) []cpi.ConsumerIdentity {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if s := r.secrets[name]; s != nil {
+		return s.ids
+	}
+	return nil
+}
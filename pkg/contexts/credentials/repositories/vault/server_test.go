@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vault_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// fakeVault is a minimal simulation of the vault HTTP API
+// supporting the KV secret engine and the token, AppRole and
+// kubernetes auth methods.
+type fakeVault struct {
+	*httptest.Server
+	lock sync.Mutex
+
+	kvVersion int
+	mount     string
+	secrets   map[string]map[string]interface{}
+
+	tokenTTL       int
+	tokenRenewable bool
+	secretTTL      int
+	tokens         map[string]bool
+	roleId         string
+	secretId       string
+	role           string
+	jwt            string
+
+	logins  int
+	renews  int
+	reads   int
+	counter int
+}
+
+func newFakeVault(kvVersion int, rootToken string) *fakeVault {
+	v := &fakeVault{
+		kvVersion: kvVersion,
+		mount:     "secret",
+		secrets:   map[string]map[string]interface{}{},
+		tokens:    map[string]bool{rootToken: true},
+	}
+	v.Server = httptest.NewServer(http.HandlerFunc(v.handle))
+	return v
+}
+
+func (v *fakeVault) Counters() (int, int, int) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.logins, v.renews, v.reads
+}
+
+func (v *fakeVault) reply(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if data != nil {
+		_ = json.NewEncoder(w).Encode(data)
+	}
+}
+
+func (v *fakeVault) fail(w http.ResponseWriter, status int, msg string) {
+	v.reply(w, status, map[string]interface{}{"errors": []string{msg}})
+}
+
+func (v *fakeVault) newToken() map[string]interface{} {
+	v.counter++
+	token := "token-" + string(rune('a'+v.counter))
+	v.tokens[token] = true
+	return map[string]interface{}{
+		"client_token":   token,
+		"renewable":      v.tokenRenewable,
+		"lease_duration": v.tokenTTL,
+	}
+}
+
+func (v *fakeVault) handle(w http.ResponseWriter, r *http.Request) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	var body map[string]interface{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch path {
+	case "auth/approle/login":
+		if body["role_id"] != v.roleId || body["secret_id"] != v.secretId {
+			v.fail(w, http.StatusBadRequest, "invalid role or secret ID")
+			return
+		}
+		v.logins++
+		v.reply(w, http.StatusOK, map[string]interface{}{"auth": v.newToken()})
+		return
+	case "auth/kubernetes/login":
+		if body["role"] != v.role || body["jwt"] != v.jwt {
+			v.fail(w, http.StatusForbidden, "permission denied")
+			return
+		}
+		v.logins++
+		v.reply(w, http.StatusOK, map[string]interface{}{"auth": v.newToken()})
+		return
+	}
+
+	if !v.tokens[r.Header.Get("X-Vault-Token")] {
+		v.fail(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	switch path {
+	case "auth/token/lookup-self":
+		v.reply(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"ttl": v.tokenTTL, "renewable": v.tokenRenewable}})
+		return
+	case "auth/token/renew-self":
+		v.renews++
+		v.reply(w, http.StatusOK, map[string]interface{}{"auth": map[string]interface{}{
+			"client_token":   r.Header.Get("X-Vault-Token"),
+			"renewable":      v.tokenRenewable,
+			"lease_duration": v.tokenTTL,
+		}})
+		return
+	case "sys/leases/renew":
+		v.renews++
+		v.reply(w, http.StatusOK, map[string]interface{}{
+			"lease_id":       body["lease_id"],
+			"renewable":      true,
+			"lease_duration": v.secretTTL,
+		})
+		return
+	}
+
+	if !strings.HasPrefix(path, v.mount+"/") {
+		v.reply(w, http.StatusNotFound, nil)
+		return
+	}
+	path = strings.TrimPrefix(path, v.mount+"/")
+	list := r.URL.Query().Get("list") == "true"
+	if v.kvVersion == 2 {
+		prefix := "data/"
+		if list {
+			prefix = "metadata/"
+		}
+		if !strings.HasPrefix(path, prefix) {
+			v.reply(w, http.StatusNotFound, nil)
+			return
+		}
+		path = strings.TrimPrefix(path, prefix)
+	}
+
+	if list {
+		keys := map[string]bool{}
+		prefix := strings.TrimSuffix(path, "/") + "/"
+		for k := range v.secrets {
+			if strings.HasPrefix(k, prefix) {
+				rest := k[len(prefix):]
+				if i := strings.Index(rest, "/"); i >= 0 {
+					rest = rest[:i+1]
+				}
+				keys[rest] = true
+			}
+		}
+		if len(keys) == 0 {
+			v.reply(w, http.StatusNotFound, nil)
+			return
+		}
+		var result []string
+		for k := range keys {
+			result = append(result, k)
+		}
+		sort.Strings(result)
+		v.reply(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"keys": result}})
+		return
+	}
+
+	data := v.secrets[path]
+	if data == nil {
+		v.reply(w, http.StatusNotFound, nil)
+		return
+	}
+	v.reads++
+	resp := map[string]interface{}{}
+	if v.secretTTL > 0 {
+		resp["lease_id"] = v.mount + "/" + path + "/lease"
+		resp["renewable"] = true
+		resp["lease_duration"] = v.secretTTL
+	}
+	if v.kvVersion == 2 {
+		resp["data"] = map[string]interface{}{"data": data, "metadata": map[string]interface{}{"version": 1}}
+	} else {
+		resp["data"] = data
+	}
+	v.reply(w, http.StatusOK, resp)
+}
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vault
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	Type   = "Vault"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+const (
+	AUTH_TOKEN      = "token"
+	AUTH_APPROLE    = "approle"
+	AUTH_KUBERNETES = "kubernetes"
+)
+
+const (
+	DEFAULT_MOUNT      = "secret"
+	DEFAULT_KV_VERSION = 2
+)
+
+func init() {
+	cpi.RegisterRepositoryType(Type, cpi.NewRepositoryType(Type, &RepositorySpec{}))
+	cpi.RegisterRepositoryType(TypeV1, cpi.NewRepositoryType(TypeV1, &RepositorySpec{}))
+}
+
+// RepositorySpec describes a HashiCorp Vault based credential repository interface.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	// ServerURL is the URL of the vault server.
+	ServerURL string `json:"serverURL"`
+	// Namespace is the (enterprise) namespace used for all requests.
+	Namespace string `json:"namespace,omitempty"`
+	// MountPath is the mount path of the KV secret engine.
+	MountPath string `json:"mountPath,omitempty"`
+	// KVVersion is the version (1 or 2) of the KV secret engine.
+	KVVersion int `json:"kvVersion,omitempty"`
+	// Path is the base path of the secrets below the mount path.
+	Path string `json:"path,omitempty"`
+	// Secrets is an optional list of secrets (relative to the base path)
+	// used as credentials. By default, all secrets found below the base path
+	// are used.
+	Secrets []string `json:"secrets,omitempty"`
+	// AuthMethod is the method used to authenticate at the vault server.
+	AuthMethod string `json:"authMethod,omitempty"`
+	// AuthPath is the mount path of the auth method.
+	AuthPath string `json:"authPath,omitempty"`
+	// Role is the role used for the kubernetes auth method.
+	Role string `json:"role,omitempty"`
+
+	PropagateConsumerIdentity bool `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// NewRepositorySpec creates a new vault RepositorySpec.
+func NewRepositorySpec(url string, path string, prop ...bool) *RepositorySpec {
+	p := false
+	for _, e := range prop {
+		p = p || e
+	}
+	return &RepositorySpec{
+		ObjectVersionedType:       runtime.NewVersionedObjectType(Type),
+		ServerURL:                 url,
+		Path:                      path,
+		PropagateConsumerIdentity: p,
+	}
+}
+
+func (a RepositorySpec) WithKV(mount string, version int) *RepositorySpec {
+	a.MountPath = mount
+	a.KVVersion = version
+	return &a
+}
+
+func (a RepositorySpec) WithSecrets(secrets ...string) *RepositorySpec {
+	a.Secrets = secrets
+	return &a
+}
+
+func (a RepositorySpec) WithNamespace(ns string) *RepositorySpec {
+	a.Namespace = ns
+	return &a
+}
+
+func (a RepositorySpec) WithAuth(method string, path string) *RepositorySpec {
+	a.AuthMethod = method
+	a.AuthPath = path
+	return &a
+}
+
+func (a RepositorySpec) WithRole(role string) *RepositorySpec {
+	a.Role = role
+	return &a
+}
+
+func (a *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (a *RepositorySpec) GetMountPath() string {
+	if a.MountPath == "" {
+		return DEFAULT_MOUNT
+	}
+	return a.MountPath
+}
+
+func (a *RepositorySpec) GetKVVersion() int {
+	if a.KVVersion == 0 {
+		return DEFAULT_KV_VERSION
+	}
+	return a.KVVersion
+}
+
+func (a *RepositorySpec) GetAuthMethod() string {
+	if a.AuthMethod == "" {
+		return AUTH_TOKEN
+	}
+	return a.AuthMethod
+}
+
+func (a *RepositorySpec) GetAuthPath() string {
+	if a.AuthPath == "" {
+		return a.GetAuthMethod()
+	}
+	return a.AuthPath
+}
+
+func (a *RepositorySpec) Validate() error {
+	if a.ServerURL == "" {
+		return fmt.Errorf("server url required for vault repository")
+	}
+	if v := a.GetKVVersion(); v != 1 && v != 2 {
+		return fmt.Errorf("invalid KV version %d for vault repository: only 1 or 2 possible", v)
+	}
+	switch a.GetAuthMethod() {
+	case AUTH_TOKEN, AUTH_APPROLE:
+	case AUTH_KUBERNETES:
+		if a.Role == "" {
+			return fmt.Errorf("role required for vault kubernetes auth method")
+		}
+	default:
+		return fmt.Errorf("unknown vault auth method %q", a.AuthMethod)
+	}
+	return nil
+}
+
+func (a *RepositorySpec) Repository(ctx cpi.Context, creds cpi.Credentials) (cpi.Repository, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	r := ctx.GetAttributes().GetOrCreateAttribute(ATTR_REPOS, newRepositories)
+	repos, ok := r.(*Repositories)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type %T to Repositories", r)
+	}
+	return repos.GetRepository(ctx, a, creds)
+}
@@ -53,6 +53,7 @@ func (g *GenericDescriptor) AsManifest() *ociv1.Manifest {
 		MediaType:   g.MediaType,
 		Config:      g.Config,
 		Layers:      g.Layers,
+		Subject:     g.Subject,
 		Annotations: g.Annotations,
 	}
 }
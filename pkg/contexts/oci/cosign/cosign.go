@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/cosign"
+)
+
+const KIND_COSIGN_SIGNATURE = "cosign signature"
+
+const (
+	// SignatureTagSuffix is the tag suffix used by cosign to store
+	// the signatures of an image.
+	SignatureTagSuffix = ".sig"
+	// AttestationTagSuffix is the tag suffix used by cosign to store
+	// the attestations of an image.
+	AttestationTagSuffix = ".att"
+	// SBOMTagSuffix is the tag suffix used by cosign to store
+	// the SBOMs of an image.
+	SBOMTagSuffix = ".sbom"
+)
+
+const (
+	// SimpleSigningMediaType is the media type of the layers of a cosign
+	// signature artifact holding the signed payload.
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// ArtifactTypeSignature is the artifact type of cosign signatures
+	// stored as referrers.
+	ArtifactTypeSignature = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	// SignatureAnnotation is the layer annotation holding the base64
+	// encoded signature of the payload.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+func init() {
+	cpi.RegisterSideArtifactTagSuffix(SignatureTagSuffix)
+	cpi.RegisterSideArtifactTagSuffix(AttestationTagSuffix)
+	cpi.RegisterSideArtifactTagSuffix(SBOMTagSuffix)
+}
+
+// SignatureTag provides the tag used by cosign to store the
+// signatures for a subject manifest.
+func SignatureTag(subject digest.Digest) string {
+	return cpi.SideArtifactTag(subject, SignatureTagSuffix)
+}
+
+// Sign creates a cosign signature for the subject manifest with the given
+// digest and adds it to the signature artifact stored under the cosign
+// signature tag. The reference is used as docker reference in the signed
+// payload.
+func Sign(ns cpi.NamespaceAccess, subject digest.Digest, ref string, key interface{}) error {
+	data, err := cosign.NewPayload(ref, subject.String()).Data()
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	sig, err := cosign.Handler{}.Sign(nil, hex.EncodeToString(sum[:]), crypto.SHA256, "", key)
+	if err != nil {
+		return err
+	}
+
+	art, err := ns.NewArtifact()
+	if err != nil {
+		return err
+	}
+	defer art.Close()
+	m := art.ManifestAccess()
+
+	tag := SignatureTag(subject)
+	var layers []artdesc.Descriptor
+	old, err := ns.GetArtifact(tag)
+	if err == nil {
+		defer old.Close()
+		if old.IsManifest() {
+			layers = old.ManifestAccess().GetDescriptor().Layers
+		}
+	} else if !errors.IsErrNotFound(err) && !errors.IsErrUnknown(err) {
+		return errors.Wrapf(err, "cannot access existing signatures")
+	}
+	for _, l := range layers {
+		blob, err := old.GetBlob(l.Digest)
+		if err != nil {
+			return errors.Wrapf(err, "cannot access existing signature %s", l.Digest)
+		}
+		d := l
+		_, err = m.AddLayer(blob, &d)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = m.AddLayer(accessio.BlobAccessForData(SimpleSigningMediaType, data), &artdesc.Descriptor{
+		Annotations: map[string]string{SignatureAnnotation: sig.Value},
+	})
+	if err != nil {
+		return err
+	}
+
+	config := ociv1.Image{
+		RootFS: ociv1.RootFS{Type: "layers"},
+	}
+	for _, l := range m.GetDescriptor().Layers {
+		config.RootFS.DiffIDs = append(config.RootFS.DiffIDs, l.Digest)
+	}
+	configData, err := json.Marshal(&config)
+	if err != nil {
+		return err
+	}
+	err = m.SetConfigBlob(accessio.BlobAccessForData(ociv1.MediaTypeImageConfig, configData), nil)
+	if err != nil {
+		return err
+	}
+	_, err = ns.AddArtifact(art, tag)
+	return err
+}
+
+// Verify verifies the cosign signatures for the subject manifest with
+// the given digest. Signatures are looked up using the cosign signature tag
+// and the referrers of the subject. The verification succeeds, if
+// at least one signature can be verified with one of the given public keys.
+func Verify(ns cpi.ArtifactSource, subject digest.Digest, keys ...interface{}) error {
+	if len(keys) == 0 {
+		return errors.Newf("no public key for cosign signature verification")
+	}
+
+	var refs []string
+	art, err := ns.GetArtifact(SignatureTag(subject))
+	if err == nil {
+		refs = append(refs, SignatureTag(subject))
+		art.Close()
+	} else if !errors.IsErrNotFound(err) && !errors.IsErrUnknown(err) {
+		return errors.Wrapf(err, "cannot access cosign signatures for %s", subject)
+	}
+	list, err := cpi.ListReferrers(ns, subject, ArtifactTypeSignature)
+	if err != nil {
+		return err
+	}
+	for _, d := range list {
+		refs = append(refs, d.Digest.String())
+	}
+
+	found := 0
+	errlist := errors.ErrListf("cosign signatures for %s", subject)
+	for _, ref := range refs {
+		n, err := verifyArtifact(ns, ref, subject, keys)
+		if err == nil && n > 0 {
+			return nil
+		}
+		found += n
+		errlist.Add(err)
+	}
+	if found == 0 {
+		return errors.ErrNotFound(KIND_COSIGN_SIGNATURE, subject.String())
+	}
+	if err := errlist.Result(); err != nil {
+		return err
+	}
+	return errors.Newf("no cosign signature for %s verifiable with given keys", subject)
+}
+
+// verifyArtifact checks the signature layers of a signature artifact.
+// It returns the number of found signatures for the subject, and
+// no error, if one of them could be verified.
+func verifyArtifact(ns cpi.ArtifactSource, ref string, subject digest.Digest, keys []interface{}) (int, error) {
+	art, err := ns.GetArtifact(ref)
+	if err != nil {
+		return 0, err
+	}
+	defer art.Close()
+	if !art.IsManifest() {
+		return 0, nil
+	}
+	found := 0
+	var lasterr error
+	for _, l := range art.ManifestAccess().GetDescriptor().Layers {
+		sig := l.Annotations[SignatureAnnotation]
+		if l.MediaType != SimpleSigningMediaType || sig == "" {
+			continue
+		}
+		blob, err := art.GetBlob(l.Digest)
+		if err != nil {
+			return found, err
+		}
+		data, err := blob.Get()
+		blob.Close()
+		if err != nil {
+			return found, err
+		}
+		payload, err := cosign.ParsePayload(data)
+		if err != nil || payload.Critical.Image.DockerManifestDigest != subject.String() {
+			continue
+		}
+		found++
+		sum := sha256.Sum256(data)
+		for _, key := range keys {
+			lasterr = cosign.Handler{}.Verify(hex.EncodeToString(sum[:]), crypto.SHA256, &signing.Signature{
+				Value:     sig,
+				MediaType: cosign.MediaType,
+				Algorithm: cosign.Algorithm,
+			}, key)
+			if lasterr == nil {
+				return found, nil
+			}
+		}
+	}
+	return found, lasterr
+}
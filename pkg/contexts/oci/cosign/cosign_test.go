@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cosign"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+)
+
+var _ = Describe("cosign signatures", func() {
+	var tempfs vfs.FileSystem
+	var repo cpi.Repository
+	var ns cpi.NamespaceAccess
+	var priv *ecdsa.PrivateKey
+
+	BeforeEach(func() {
+		tempfs = Must(osfs.NewTempFileSystem())
+		repo = Must(ctf.Create(oci.DefaultContext(), accessobj.ACC_CREATE, "test", 0o700, accessio.PathFileSystem(tempfs), accessobj.FormatDirectory))
+		ns = Must(repo.LookupNamespace("mandelsoft/test"))
+		DefaultManifestFill(ns)
+		priv = Must(ecdsa.GenerateKey(elliptic.P256(), rand.Reader))
+	})
+
+	AfterEach(func() {
+		Close(ns)
+		Close(repo)
+		vfs.Cleanup(tempfs)
+	})
+
+	It("signs and verifies", func() {
+		art := Must(ns.GetArtifact(TAG))
+		defer Close(art)
+
+		MustBeSuccessful(cosign.Sign(ns, art.Digest(), "ghcr.io/mandelsoft/test:"+TAG, priv))
+		Expect(Must(ns.ListTags())).To(ContainElement(cosign.SignatureTag(art.Digest())))
+		MustBeSuccessful(cosign.Verify(ns, art.Digest(), &priv.PublicKey))
+	})
+
+	It("fails for other keys", func() {
+		art := Must(ns.GetArtifact(TAG))
+		defer Close(art)
+		other := Must(ecdsa.GenerateKey(elliptic.P256(), rand.Reader))
+
+		MustBeSuccessful(cosign.Sign(ns, art.Digest(), "ghcr.io/mandelsoft/test:"+TAG, other))
+		Expect(cosign.Verify(ns, art.Digest(), &priv.PublicKey)).To(HaveOccurred())
+
+		MustBeSuccessful(cosign.Sign(ns, art.Digest(), "ghcr.io/mandelsoft/test:"+TAG, priv))
+		MustBeSuccessful(cosign.Verify(ns, art.Digest(), &priv.PublicKey))
+	})
+
+	It("fails for unsigned artifacts", func() {
+		art := Must(ns.GetArtifact(TAG))
+		defer Close(art)
+
+		Expect(cosign.Verify(ns, art.Digest(), &priv.PublicKey)).To(MatchError(`cosign signature "` + art.Digest().String() + `" not found`))
+	})
+
+	It("keeps signatures for synthesized artifact sets", func() {
+		art := Must(ns.GetArtifact(TAG))
+		defer Close(art)
+		MustBeSuccessful(cosign.Sign(ns, art.Digest(), "ghcr.io/mandelsoft/test:"+TAG, priv))
+
+		blob := Must(artifactset.SynthesizeArtifactBlob(ns, TAG))
+		defer Close(blob)
+		set := Must(artifactset.OpenFromBlob(accessobj.ACC_READONLY, blob))
+		defer Close(set)
+
+		MustBeSuccessful(cosign.Verify(set, set.GetMain(), &priv.PublicKey))
+	})
+})
@@ -38,6 +38,8 @@ type (
 	BlobSink                         = internal.BlobSink
 	NamespaceLister                  = internal.NamespaceLister
 	NamespaceAccess                  = internal.NamespaceAccess
+	BlobChecker                      = internal.BlobChecker
+	ReferrersLister                  = internal.ReferrersLister
 	ManifestAccess                   = internal.ManifestAccess
 	IndexAccess                      = internal.IndexAccess
 	BlobAccess                       = internal.BlobAccess
@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cpi
+
+import (
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// ReferrersTag provides the tag used by the referrers tag schema of the
+// OCI distribution spec to store the referrers index of a subject manifest
+// in repositories without support for the referrers API.
+func ReferrersTag(subject digest.Digest) string {
+	return subject.Algorithm().String() + "-" + subject.Encoded()
+}
+
+// GetSubject provides the subject of an artifact. Only manifests
+// can refer to a subject, for all other artifacts nil is returned.
+func GetSubject(art Artifact) *Descriptor {
+	m := getManifest(art)
+	if m == nil {
+		return nil
+	}
+	return m.Subject
+}
+
+// ReferrerDescriptor provides the descriptor of an artifact as used
+// in a referrers index. The artifact type of a manifest is the
+// media type of its config blob.
+func ReferrerDescriptor(art Artifact, blob BlobAccess) (*Descriptor, error) {
+	m := getManifest(art)
+	if m == nil {
+		return nil, errors.ErrInvalid(KIND_OCIARTIFACT, blob.Digest().String())
+	}
+	return &Descriptor{
+		MediaType:    blob.MimeType(),
+		Digest:       blob.Digest(),
+		Size:         blob.Size(),
+		ArtifactType: m.Config.MediaType,
+		Annotations:  m.Annotations,
+	}, nil
+}
+
+func getManifest(art Artifact) *artdesc.Manifest {
+	a := art.Artifact()
+	if a == nil || !a.IsManifest() {
+		return nil
+	}
+	return a.Manifest()
+}
+
+// ListReferrers lists the artifacts referring to a subject manifest.
+// If the namespace supports the referrers API, it is used, otherwise
+// the referrers index is looked up according to the referrers tag schema.
+func ListReferrers(ns ArtifactSource, subject digest.Digest, artifactType string) ([]Descriptor, error) {
+	if l, ok := ns.(ReferrersLister); ok {
+		return l.ListReferrers(subject, artifactType)
+	}
+	return ListReferrersByTag(ns, subject, artifactType)
+}
+
+// ListReferrersByTag lists the artifacts referring to a subject manifest
+// according to the referrers tag schema.
+func ListReferrersByTag(ns ArtifactSource, subject digest.Digest, artifactType string) ([]Descriptor, error) {
+	idx, err := getReferrersIndex(ns, subject)
+	if err != nil || idx == nil {
+		return nil, err
+	}
+	return FilterReferrers(idx.Manifests, artifactType), nil
+}
+
+// FilterReferrers filters a list of referrer descriptors by an artifact type.
+// An empty artifact type matches all referrers.
+func FilterReferrers(list []Descriptor, artifactType string) []Descriptor {
+	var result []Descriptor
+	for _, d := range list {
+		if artifactType == "" || d.ArtifactType == artifactType {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// ReferrersIndexContainer is the interface required to maintain
+// a referrers index according to the referrers tag schema.
+type ReferrersIndexContainer interface {
+	ArtifactSource
+	ArtifactSink
+}
+
+// AddReferrerByTag adds an artifact with a subject to the referrers index
+// of the subject stored according to the referrers tag schema.
+// Artifacts without subject are ignored.
+func AddReferrerByTag(ns ReferrersIndexContainer, art Artifact, blob BlobAccess) error {
+	subject := GetSubject(art)
+	if subject == nil {
+		return nil
+	}
+	desc, err := ReferrerDescriptor(art, blob)
+	if err != nil {
+		return err
+	}
+	idx, err := getReferrersIndex(ns, subject.Digest)
+	if err != nil {
+		return err
+	}
+	if idx == nil {
+		idx = artdesc.NewIndex()
+	}
+	for _, d := range idx.Manifests {
+		if d.Digest == desc.Digest {
+			return nil
+		}
+	}
+	idx.AddManifest(desc)
+
+	a := artdesc.New()
+	err = a.SetIndex(idx)
+	if err != nil {
+		return err
+	}
+	_, err = ns.AddArtifact(&referrersIndex{a}, ReferrersTag(subject.Digest))
+	if err != nil {
+		return errors.Wrapf(err, "cannot store referrers index for %s", subject.Digest)
+	}
+	return nil
+}
+
+func getReferrersIndex(ns ArtifactSource, subject digest.Digest) (*artdesc.Index, error) {
+	tag := ReferrersTag(subject)
+	art, err := ns.GetArtifact(tag)
+	if err != nil {
+		if errors.IsErrNotFound(err) || errors.IsErrUnknown(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer art.Close()
+	if !art.IsIndex() {
+		return nil, errors.ErrInvalid(KIND_OCIARTIFACT, tag)
+	}
+	return art.Index()
+}
+
+// referrersIndex provides an Artifact for a synthesized referrers index.
+type referrersIndex struct {
+	art *artdesc.Artifact
+}
+
+var _ Artifact = (*referrersIndex)(nil)
+
+func (r *referrersIndex) IsManifest() bool {
+	return false
+}
+
+func (r *referrersIndex) IsIndex() bool {
+	return true
+}
+
+func (r *referrersIndex) Digest() digest.Digest {
+	blob, err := r.Blob()
+	if err != nil {
+		return ""
+	}
+	return blob.Digest()
+}
+
+func (r *referrersIndex) Blob() (BlobAccess, error) {
+	return r.art.ToBlobAccess()
+}
+
+func (r *referrersIndex) Artifact() *artdesc.Artifact {
+	return r.art
+}
+
+func (r *referrersIndex) Manifest() (*artdesc.Manifest, error) {
+	return nil, errors.ErrInvalid()
+}
+
+func (r *referrersIndex) Index() (*artdesc.Index, error) {
+	return r.art.Index(), nil
+}
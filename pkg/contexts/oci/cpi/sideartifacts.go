@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cpi
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// Side artifacts are artifacts associated with a subject manifest by
+// a tag derived from the digest of the subject (for example
+// sha256-<hex>.sig used by cosign). In contrast to referrers they
+// are not described by the subject field of their manifest.
+
+var (
+	sideArtifactLock     sync.RWMutex
+	sideArtifactSuffixes = map[string]struct{}{}
+)
+
+// RegisterSideArtifactTagSuffix registers a tag suffix used to
+// associate side artifacts with a subject manifest.
+func RegisterSideArtifactTagSuffix(suffix string) {
+	sideArtifactLock.Lock()
+	defer sideArtifactLock.Unlock()
+	sideArtifactSuffixes[suffix] = struct{}{}
+}
+
+// SideArtifactTag provides the tag used to store a side artifact
+// with the given tag suffix for a subject manifest.
+func SideArtifactTag(subject digest.Digest, suffix string) string {
+	return ReferrersTag(subject) + suffix
+}
+
+// SideArtifactTags provides the tags of all registered kinds of
+// side artifacts for a subject manifest.
+func SideArtifactTags(subject digest.Digest) []string {
+	sideArtifactLock.RLock()
+	defer sideArtifactLock.RUnlock()
+	var tags []string
+	for s := range sideArtifactSuffixes {
+		tags = append(tags, SideArtifactTag(subject, s))
+	}
+	sort.Strings(tags)
+	return tags
+}
@@ -81,12 +81,12 @@ func (a *ArtifactSetAccess) AddArtifact(artifact cpi.Artifact, tags ...string) (
 }
 
 func (a *ArtifactSetAccess) AddBlob(blob cpi.BlobAccess) error {
-	a.lock.RLock()
-	defer a.lock.RUnlock()
 	err := a.base.AddBlob(blob)
 	if err != nil {
 		return err
 	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
 	a.blobinfos[blob.Digest()] = artdesc.DefaultBlobDescriptor(blob)
 	return nil
 }
@@ -21,6 +21,46 @@ func (s *StringList) Add(n string) {
 	*s = append(*s, n)
 }
 
+// ArtifactSize determines the number of bytes of an artifact
+// including its manifests and blobs, based on the artifact descriptors.
+func ArtifactSize(art ArtifactAccess) (int64, error) {
+	blob, err := art.Blob()
+	if err != nil {
+		return -1, err
+	}
+	size := blob.Size()
+	blob.Close()
+
+	if art.IsIndex() {
+		idx, err := art.Index()
+		if err != nil {
+			return -1, err
+		}
+		for _, d := range idx.Manifests {
+			sub, err := art.GetArtifact(d.Digest)
+			if err != nil {
+				return -1, err
+			}
+			s, err := ArtifactSize(sub)
+			sub.Close()
+			if err != nil {
+				return -1, err
+			}
+			size += s
+		}
+		return size, nil
+	}
+	m, err := art.Manifest()
+	if err != nil {
+		return -1, err
+	}
+	size += m.Config.Size
+	for _, l := range m.Layers {
+		size += l.Size
+	}
+	return size, nil
+}
+
 func FilterByNamespacePrefix(prefix string, list []string) []string {
 	result := []string{}
 	sub := prefix
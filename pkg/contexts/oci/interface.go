@@ -35,6 +35,8 @@ type (
 	ArtifactAccess                   = internal.ArtifactAccess
 	NamespaceLister                  = internal.NamespaceLister
 	NamespaceAccess                  = internal.NamespaceAccess
+	BlobChecker                      = internal.BlobChecker
+	ReferrersLister                  = internal.ReferrersLister
 	ManifestAccess                   = internal.ManifestAccess
 	IndexAccess                      = internal.IndexAccess
 	BlobAccess                       = internal.BlobAccess
@@ -49,6 +49,24 @@ type ArtifactSource interface {
 	GetBlobData(digest digest.Digest) (int64, DataAccess, error)
 }
 
+// BlobChecker is an optional interface of a NamespaceAccess
+// able to check for the existence of a blob without reading it.
+type BlobChecker interface {
+	// HasBlob checks whether a blob with the given digest is
+	// available and returns its size.
+	HasBlob(digest digest.Digest) (bool, int64, error)
+}
+
+// ReferrersLister is an optional interface of a NamespaceAccess
+// able to list the artifacts referring to a subject manifest
+// using the referrers API of the OCI distribution spec.
+type ReferrersLister interface {
+	// ListReferrers lists the descriptors of the artifacts referring to the
+	// given subject. If an artifact type is given, only artifacts of this
+	// type are listed.
+	ListReferrers(subject digest.Digest, artifactType string) ([]artdesc.Descriptor, error)
+}
+
 type NamespaceAccess interface {
 	ArtifactSource
 	ArtifactSink
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"golang.org/x/crypto/openpgp" //nolint: staticcheck // required by helm provenance
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/registry"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/ociutils/helm/loader"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+// ChartFileName provides the standard file name of a chart archive.
+func ChartFileName(name, version string) string {
+	return fmt.Sprintf("%s-%s.tgz", name, version)
+}
+
+// ExtractChart stores the chart archive and, if present, the provenance
+// file of a helm chart blob in the given directory. The blob might either be
+// an artifact set describing the chart as OCI artifact or a plain chart archive.
+// It returns the chart and the paths of the chart archive and the provenance
+// file. The provenance path is empty, if there is no provenance data.
+func ExtractChart(blob accessio.BlobAccess, dir string, fs vfs.FileSystem) (*chart.Chart, string, string, error) {
+	var chartblob, provblob accessio.BlobAccess
+
+	mt := mime.BaseType(blob.MimeType())
+	switch {
+	case !IsChartMediaType(mt):
+		return nil, "", "", errors.ErrInvalid("helm chart media type", blob.MimeType())
+	case mt == mime.BaseType(artdesc.MediaTypeImageManifest):
+		rd, err := blob.Reader()
+		if err != nil {
+			return nil, "", "", err
+		}
+		defer rd.Close()
+		set, err := artifactset.Open(accessobj.ACC_READONLY, "", 0, accessio.Reader(rd))
+		if err != nil {
+			return nil, "", "", err
+		}
+		defer set.Close()
+		art, err := set.GetArtifact(set.GetMain().String())
+		if err != nil {
+			return nil, "", "", err
+		}
+		defer art.Close()
+		m := art.ManifestAccess()
+		if m == nil {
+			return nil, "", "", errors.Newf("artifact is no image manifest")
+		}
+		for _, l := range m.GetDescriptor().Layers {
+			b, err := m.GetBlob(l.Digest)
+			if err != nil {
+				return nil, "", "", err
+			}
+			switch l.MediaType {
+			case registry.ChartLayerMediaType:
+				chartblob = b
+			case registry.ProvLayerMediaType:
+				provblob = b
+			}
+		}
+		if chartblob == nil {
+			return nil, "", "", errors.Newf("no helm chart layer found")
+		}
+		return writeChart(chartblob, provblob, dir, fs)
+	default:
+		return writeChart(blob, nil, dir, fs)
+	}
+}
+
+// IsChartMediaType checks whether a blob media type is supported by
+// ExtractChart.
+func IsChartMediaType(mimetype string) bool {
+	switch mime.BaseType(mimetype) {
+	case mime.BaseType(artdesc.MediaTypeImageManifest), mime.BaseType(registry.ChartLayerMediaType), mime.MIME_TGZ, mime.MIME_GZIP:
+		return true
+	}
+	return false
+}
+
+func writeChart(chartblob, provblob accessio.BlobAccess, dir string, fs vfs.FileSystem) (*chart.Chart, string, string, error) {
+	data, err := chartblob.Get()
+	if err != nil {
+		return nil, "", "", err
+	}
+	chart, err := loader.LoadArchive(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", "", errors.Wrapf(err, "invalid helm chart archive")
+	}
+	chartpath := path.Join(dir, ChartFileName(chart.Metadata.Name, chart.Metadata.Version))
+	err = vfs.WriteFile(fs, chartpath, data, 0o644)
+	if err != nil {
+		return nil, "", "", err
+	}
+	provpath := ""
+	if provblob != nil {
+		provpath = chartpath + ".prov"
+		err = writeBlob(provblob, provpath, fs)
+		if err != nil {
+			return nil, "", "", err
+		}
+	}
+	return chart, chartpath, provpath, nil
+}
+
+func writeBlob(blob accessio.BlobAccess, path string, fs vfs.FileSystem) error {
+	r, err := blob.Reader()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	f, err := fs.OpenFile(path, vfs.O_TRUNC|vfs.O_CREATE|vfs.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// VerifyProvenance verifies the provenance file of a chart archive
+// with an ASCII armored public key ring. Because of the helm
+// implementation, both paths must be located in the OS file system.
+func VerifyProvenance(chartpath, provpath string, keyring []byte) (*provenance.Verification, error) {
+	ring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(keyring))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid keyring")
+	}
+	sig := &provenance.Signatory{KeyRing: ring}
+	return sig.Verify(chartpath, provpath)
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// AttachArtifact attaches a manifest artifact, for example a signature,
+// an SBOM or an attestation, to a subject artifact of the same namespace.
+// The subject of the manifest is set accordingly and the artifact is
+// added to the namespace. Repositories without support for the referrers
+// API maintain the referrers index according to the referrers tag schema.
+func AttachArtifact(ns NamespaceAccess, subject ArtifactAccess, art ArtifactAccess, tags ...string) (BlobAccess, error) {
+	if !art.GetDescriptor().IsManifest() {
+		return nil, errors.ErrInvalid(KIND_OCIARTIFACT, "index", "referrer")
+	}
+	blob, err := subject.Blob()
+	if err != nil {
+		return nil, err
+	}
+	art.GetDescriptor().Manifest().Subject = &artdesc.Descriptor{
+		MediaType: blob.MimeType(),
+		Digest:    blob.Digest(),
+		Size:      blob.Size(),
+	}
+	return ns.AddArtifact(art, tags...)
+}
+
+// ListReferrers lists the descriptors of the artifacts referring to the
+// given subject manifest. If an artifact type is given, only artifacts
+// of this type are listed. The referrers can be accessed with
+// GetArtifact using the digest of the descriptor.
+func ListReferrers(ns NamespaceAccess, subject digest.Digest, artifactType string) ([]artdesc.Descriptor, error) {
+	return cpi.ListReferrers(ns, subject, artifactType)
+}
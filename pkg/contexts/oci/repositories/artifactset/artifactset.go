@@ -105,6 +105,41 @@ func (a *artifactSetImpl) Annotate(name string, value string) {
 ////////////////////////////////////////////////////////////////////////////////
 // sink
 
+// removeTags removes tags from an index entry, because a tag
+// may only refer to a single artifact.
+func removeTags(d *artdesc.Descriptor, tags ...string) {
+	cur := RetrieveTags(d.Annotations)
+	if cur == "" {
+		return
+	}
+	var keep []string
+outer:
+	for _, t := range strings.Split(cur, ",") {
+		for _, r := range tags {
+			if t == r {
+				continue outer
+			}
+		}
+		keep = append(keep, t)
+	}
+	if len(keep) == 0 {
+		delete(d.Annotations, TAGS_ANNOTATION)
+	} else {
+		d.Annotations[TAGS_ANNOTATION] = strings.Join(keep, ",")
+	}
+	if t, ok := d.Annotations[OCITAG_ANNOTATION]; ok {
+		for _, r := range tags {
+			if t == r {
+				delete(d.Annotations, OCITAG_ANNOTATION)
+				if len(keep) > 0 {
+					d.Annotations[OCITAG_ANNOTATION] = keep[0]
+				}
+				break
+			}
+		}
+	}
+}
+
 func (a *artifactSetImpl) AddTags(digest digest.Digest, tags ...string) error {
 	if a.IsClosed() {
 		return accessio.ErrClosed
@@ -117,6 +152,11 @@ func (a *artifactSetImpl) AddTags(digest digest.Digest, tags ...string) error {
 	defer a.base.Unlock()
 
 	idx := a.GetIndex()
+	for i, e := range idx.Manifests {
+		if e.Digest != digest {
+			removeTags(&idx.Manifests[i], tags...)
+		}
+	}
 	for i, e := range idx.Manifests {
 		if e.Digest == digest {
 			if e.Annotations == nil {
@@ -320,7 +360,12 @@ func (a *artifactSetImpl) AddArtifact(artifact cpi.Artifact, tags ...string) (ac
 	if err != nil {
 		return nil, err
 	}
-	return blob, a.AddTags(blob.Digest(), tags...)
+	err = a.AddTags(blob.Digest(), tags...)
+	if err != nil {
+		return nil, err
+	}
+	// maintain the referrers index according to the referrers tag schema
+	return blob, cpi.AddReferrerByTag(a, artifact, blob)
 }
 
 func (a *artifactSetImpl) AddPlatformArtifact(artifact cpi.Artifact, platform *artdesc.Platform) (access accessio.BlobAccess, err error) {
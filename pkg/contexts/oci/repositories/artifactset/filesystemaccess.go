@@ -17,8 +17,8 @@ type FileSystemBlobAccess struct {
 	*accessobj.FileSystemBlobAccess
 }
 
-func NewFileSystemBlobAccess(access *accessobj.AccessObject) *FileSystemBlobAccess {
-	return &FileSystemBlobAccess{accessobj.NewFileSystemBlobAccess(access)}
+func NewFileSystemBlobAccess(access *accessobj.AccessObject, mapper ...accessobj.DigestFileNameMapper) *FileSystemBlobAccess {
+	return &FileSystemBlobAccess{accessobj.NewFileSystemBlobAccess(access, mapper...)}
 }
 
 func (i *FileSystemBlobAccess) GetArtifact(access support.ArtifactSetContainerImpl, digest digest.Digest) (acc cpi.ArtifactAccess, err error) {
@@ -60,16 +60,22 @@ func TransferArtifact(art cpi.ArtifactAccess, set cpi.ArtifactSink, tags ...stri
 // SynthesizeArtifactBlob synthesizes an artifact blob incorporating all side artifacts.
 // To support extensions like cosign, we need the namespace access her to find
 // additionally objects associated by tags.
+// Artifacts referring to the artifact according to the OCI distribution spec
+// (referrers) are included, also.
 func SynthesizeArtifactBlob(ns cpi.NamespaceAccess, ref string) (ArtifactBlob, error) {
 	art, err := ns.GetArtifact(ref)
 	if err != nil {
 		return nil, GetArtifactError{Original: err, Ref: ref}
 	}
 	defer art.Close()
-	return SynthesizeArtifactBlobForArtifact(art, ref)
+	return synthesizeArtifactBlobForArtifact(ns, art, ref)
 }
 
 func SynthesizeArtifactBlobForArtifact(art cpi.ArtifactAccess, ref string) (ArtifactBlob, error) {
+	return synthesizeArtifactBlobForArtifact(nil, art, ref)
+}
+
+func synthesizeArtifactBlobForArtifact(ns cpi.ArtifactSource, art cpi.ArtifactAccess, ref string) (ArtifactBlob, error) {
 	blob, err := art.Blob()
 	if err != nil {
 		return nil, err
@@ -82,6 +88,13 @@ func SynthesizeArtifactBlobForArtifact(art cpi.ArtifactAccess, ref string) (Arti
 			return "", fmt.Errorf("failed to transfer artifact: %w", err)
 		}
 
+		if ns != nil {
+			err = transfer.TransferAttachedArtifacts(ns, digest, set)
+			if err != nil {
+				return "", fmt.Errorf("failed to transfer attached artifacts: %w", err)
+			}
+		}
+
 		if ok, _ := artdesc.IsDigest(ref); !ok {
 			err = set.AddTags(digest, ref)
 			if err != nil {
@@ -55,6 +55,7 @@ type NamespaceContainer struct {
 var (
 	_ support.ArtifactSetContainer = (*NamespaceContainer)(nil)
 	_ cpi.NamespaceAccess          = (*Namespace)(nil)
+	_ cpi.BlobChecker              = (*Namespace)(nil)
 )
 
 func (a *NamespaceContainer) View(main ...bool) (support.ArtifactSetContainer, error) {
@@ -101,6 +102,10 @@ func (n *NamespaceContainer) GetBlobData(digest digest.Digest) (int64, cpi.DataA
 	return n.repo.base.GetBlobData(digest)
 }
 
+func (n *NamespaceContainer) HasBlob(digest digest.Digest) (bool, int64, error) {
+	return n.repo.base.HasBlob(digest)
+}
+
 func (n *NamespaceContainer) AddBlob(blob cpi.BlobAccess) error {
 	n.repo.base.Lock()
 	defer n.repo.base.Unlock()
@@ -117,6 +122,15 @@ func (n *NamespaceContainer) GetArtifact(vers string) (cpi.ArtifactAccess, error
 }
 
 func (n *NamespaceContainer) AddArtifact(artifact cpi.Artifact, tags ...string) (access accessio.BlobAccess, err error) {
+	blob, err := n.addArtifact(artifact, tags...)
+	if err != nil {
+		return nil, err
+	}
+	// maintain the referrers index according to the referrers tag schema
+	return blob, cpi.AddReferrerByTag(n, artifact, blob)
+}
+
+func (n *NamespaceContainer) addArtifact(artifact cpi.Artifact, tags ...string) (access accessio.BlobAccess, err error) {
 	n.repo.base.Lock()
 	defer n.repo.base.Unlock()
 
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctf_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	SIGNATURE_TYPE = "application/vnd.test.signature"
+	SBOM_TYPE      = "application/vnd.test.sbom"
+)
+
+func attach(n cpi.NamespaceAccess, subject cpi.ArtifactAccess, typ, data string) digest.Digest {
+	art := Must(n.NewArtifact())
+	defer Close(art)
+	Expect(art.AddLayer(accessio.BlobAccessForString(mime.MIME_OCTET, data), nil)).To(Equal(0))
+	config := accessio.BlobAccessForString(typ, "{}")
+	MustBeSuccessful(n.AddBlob(config))
+	art.GetDescriptor().Manifest().Config = *artdesc.DefaultBlobDescriptor(config)
+	blob := Must(oci.AttachArtifact(n, subject, art))
+	return blob.Digest()
+}
+
+var _ = Describe("ctf referrers", func() {
+	var tempfs vfs.FileSystem
+	var repo cpi.Repository
+
+	BeforeEach(func() {
+		tempfs = Must(osfs.NewTempFileSystem())
+		repo = Must(ctf.Create(oci.DefaultContext(), accessobj.ACC_CREATE, "test", 0o700, accessio.PathFileSystem(tempfs), accessobj.FormatDirectory))
+	})
+
+	AfterEach(func() {
+		Close(repo)
+		vfs.Cleanup(tempfs)
+	})
+
+	It("attaches and lists referrers", func() {
+		n := Must(repo.LookupNamespace("mandelsoft/test"))
+		defer Close(n)
+		DefaultManifestFill(n)
+		subject := Must(n.GetArtifact(TAG))
+		defer Close(subject)
+
+		sig := attach(n, subject, SIGNATURE_TYPE, "signature")
+		sbom := attach(n, subject, SBOM_TYPE, "sbom")
+
+		dig := digest.Digest("sha256:" + DIGEST_MANIFEST)
+		list := Must(oci.ListReferrers(n, dig, ""))
+		Expect(len(list)).To(Equal(2))
+		Expect(list[0].Digest).To(Equal(sig))
+		Expect(list[0].ArtifactType).To(Equal(SIGNATURE_TYPE))
+		Expect(list[1].Digest).To(Equal(sbom))
+
+		list = Must(oci.ListReferrers(n, dig, SBOM_TYPE))
+		Expect(len(list)).To(Equal(1))
+		Expect(list[0].Digest).To(Equal(sbom))
+
+		Expect(Must(n.ListTags())).To(ContainElement(cpi.ReferrersTag(dig)))
+
+		ref := Must(n.GetArtifact(sbom.String()))
+		defer Close(ref)
+		m := Must(ref.Manifest())
+		Expect(m.Subject.Digest).To(Equal(dig))
+		blob := Must(ref.GetBlob(m.Layers[0].Digest))
+		Expect(Must(blob.Get())).To(Equal([]byte("sbom")))
+
+		Expect(Must(oci.ListReferrers(n, sig, ""))).To(BeEmpty())
+	})
+
+	It("keeps referrers for synthesized artifact sets", func() {
+		n := Must(repo.LookupNamespace("mandelsoft/test"))
+		defer Close(n)
+		DefaultManifestFill(n)
+		subject := Must(n.GetArtifact(TAG))
+		defer Close(subject)
+		sig := attach(n, subject, SIGNATURE_TYPE, "signature")
+
+		blob := Must(artifactset.SynthesizeArtifactBlob(n, TAG))
+		defer Close(blob)
+		set := Must(artifactset.OpenFromBlob(accessobj.ACC_READONLY, blob))
+		defer Close(set)
+
+		list := Must(cpi.ListReferrers(set, set.GetMain(), ""))
+		Expect(len(list)).To(Equal(1))
+		Expect(list[0].Digest).To(Equal(sig))
+
+		t := Must(repo.LookupNamespace("mandelsoft/copy"))
+		defer Close(t)
+		art := Must(set.GetArtifact(set.GetMain().String()))
+		defer Close(art)
+		MustBeSuccessful(transfer.TransferArtifact(art, t, TAG))
+		MustBeSuccessful(transfer.TransferReferrers(set, set.GetMain(), t))
+
+		list = Must(oci.ListReferrers(t, set.GetMain(), SIGNATURE_TYPE))
+		Expect(len(list)).To(Equal(1))
+		Expect(list[0].Digest).To(Equal(sig))
+	})
+})
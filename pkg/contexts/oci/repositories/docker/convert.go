@@ -17,6 +17,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
 )
 
@@ -55,6 +56,38 @@ func (f *fakeSource) Reference() types.ImageReference {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+// ConvertImageSource provides the image of a docker image source
+// as OCI artifact. The manifest is converted to an OCI manifest and
+// returned as blob. The blobs of the image are provided by the
+// returned blob source, which takes over the responsibility
+// for closing the image source.
+func ConvertImageSource(src types.ImageSource, sysctx *types.SystemContext) (cpi.BlobAccess, accessio.BlobSource, error) {
+	opts := types.ManifestUpdateOptions{
+		ManifestMIMEType: artdesc.MediaTypeImageManifest,
+	}
+	un := image.UnparsedInstance(src, nil)
+	img, err := image.FromUnparsedImage(dummyContext, sysctx, un)
+	if err != nil {
+		src.Close()
+		return nil, nil, err
+	}
+
+	img, err = img.UpdatedImage(dummyContext, opts)
+	if err != nil {
+		src.Close()
+		return nil, nil, err
+	}
+
+	data, mime, err := img.Manifest(dummyContext)
+	if err != nil {
+		src.Close()
+		return nil, nil, err
+	}
+	return accessio.BlobAccessForData(mime, data), newDockerSource(img, src), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type artBlobCache struct {
 	access cpi.ArtifactAccess
 }
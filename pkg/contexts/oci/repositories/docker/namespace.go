@@ -9,8 +9,6 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/containers/image/v5/image"
-	"github.com/containers/image/v5/types"
 	dockertypes "github.com/docker/docker/api/types"
 	"github.com/mandelsoft/logging"
 	"github.com/opencontainers/go-digest"
@@ -135,30 +133,11 @@ func (n *NamespaceContainer) GetArtifact(vers string) (cpi.ArtifactAccess, error
 	if err != nil {
 		return nil, err
 	}
-
-	opts := types.ManifestUpdateOptions{
-		ManifestMIMEType: artdesc.MediaTypeImageManifest,
-	}
-	un := image.UnparsedInstance(src, nil)
-	img, err := image.FromUnparsedImage(dummyContext, n.repo.sysctx, un)
-	if err != nil {
-		src.Close()
-		return nil, err
-	}
-
-	img, err = img.UpdatedImage(dummyContext, opts)
+	blob, source, err := ConvertImageSource(src, n.repo.sysctx)
 	if err != nil {
-		src.Close()
 		return nil, err
 	}
-
-	data, mime, err := img.Manifest(dummyContext)
-	if err != nil {
-		src.Close()
-		return nil, err
-	}
-
-	cache, err := accessio.NewCascadedBlobCacheForSource(n.cache, newDockerSource(img, src))
+	cache, err := accessio.NewCascadedBlobCacheForSource(n.cache, source)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +145,7 @@ func (n *NamespaceContainer) GetArtifact(vers string) (cpi.ArtifactAccess, error
 		namespace: n,
 		cache:     cache,
 	}
-	return cpi.NewArtifactForProviderBlob(n, p, accessio.BlobAccessForData(mime, data))
+	return cpi.NewArtifactForProviderBlob(n, p, blob)
 }
 
 func (n *NamespaceContainer) AddArtifact(artifact cpi.Artifact, tags ...string) (access accessio.BlobAccess, err error) {
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dockerarchive
+
+import (
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+type artifactProvider struct {
+	lock      sync.Mutex
+	namespace *NamespaceContainer
+	cache     accessio.BlobCache
+}
+
+var _ cpi.ArtifactProvider = (*artifactProvider)(nil)
+
+func (d *artifactProvider) IsClosed() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.cache == nil
+}
+
+func (d *artifactProvider) IsReadOnly() bool {
+	return d.namespace.IsReadOnly()
+}
+
+func (d *artifactProvider) GetBlobDescriptor(digest digest.Digest) *cpi.Descriptor {
+	return nil
+}
+
+func (d *artifactProvider) Close() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.cache != nil {
+		err := d.cache.Unref()
+		d.cache = nil
+		return err
+	}
+	return nil
+}
+
+func (d *artifactProvider) GetBlobData(digest digest.Digest) (int64, cpi.DataAccess, error) {
+	return d.cache.GetBlobData(digest)
+}
+
+func (d *artifactProvider) GetArtifact(digest digest.Digest) (cpi.ArtifactAccess, error) {
+	return nil, errors.ErrInvalid()
+}
+
+func (d *artifactProvider) AddBlob(access cpi.BlobAccess) error {
+	_, _, err := d.cache.AddBlob(access)
+	return err
+}
+
+func (d *artifactProvider) AddArtifact(art cpi.Artifact) (access accessio.BlobAccess, err error) {
+	return nil, errors.ErrInvalid()
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dockerarchive
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+type NamespaceContainer struct {
+	lock      sync.RWMutex
+	repo      *Repository
+	namespace string
+	closed    bool
+}
+
+var (
+	_ cpi.ArtifactSetContainer = (*NamespaceContainer)(nil)
+	_ cpi.NamespaceAccess      = (*Namespace)(nil)
+)
+
+func NewNamespace(repo *Repository, name string) (*Namespace, error) {
+	err := repo.cache.Ref()
+	if err != nil {
+		return nil, err
+	}
+	n := &Namespace{
+		access: &NamespaceContainer{
+			repo:      repo,
+			namespace: name,
+		},
+	}
+	return n, nil
+}
+
+func (n *NamespaceContainer) GetNamespace() string {
+	return n.namespace
+}
+
+func (n *NamespaceContainer) IsReadOnly() bool {
+	return n.repo.IsReadOnly()
+}
+
+func (n *NamespaceContainer) IsClosed() bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.closed
+}
+
+func (n *NamespaceContainer) Close() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.closed {
+		return accessio.ErrClosed
+	}
+	n.closed = true
+	if err := n.repo.cache.Unref(); err != nil {
+		return fmt.Errorf("failed to unref: %w", err)
+	}
+	return nil
+}
+
+func (n *NamespaceContainer) GetBlobDescriptor(digest digest.Digest) *cpi.Descriptor {
+	return nil
+}
+
+func (n *NamespaceContainer) ListTags() ([]string, error) {
+	return n.repo.listTags(n.namespace), nil
+}
+
+func (n *NamespaceContainer) GetBlobData(digest digest.Digest) (int64, cpi.DataAccess, error) {
+	return n.repo.cache.GetBlobData(digest)
+}
+
+func (n *NamespaceContainer) AddBlob(blob cpi.BlobAccess) error {
+	if _, _, err := n.repo.cache.AddBlob(blob); err != nil {
+		return fmt.Errorf("failed to add blob to cache: %w", err)
+	}
+	return nil
+}
+
+func (n *NamespaceContainer) GetArtifact(vers string) (cpi.ArtifactAccess, error) {
+	if ok, _ := artdesc.IsDigest(vers); ok {
+		return nil, errors.ErrNotSupported("image access by digest")
+	}
+	return n.repo.getArtifact(n, vers)
+}
+
+func (n *NamespaceContainer) AddArtifact(artifact cpi.Artifact, tags ...string) (access accessio.BlobAccess, err error) {
+	return n.repo.addArtifact(n.namespace, artifact, n.repo.cache, tags...)
+}
+
+// AddTags adds tags for an artifact already stored in the namespace.
+// The artifact is written again with the additional tags.
+func (n *NamespaceContainer) AddTags(digest digest.Digest, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	list, err := n.ListTags()
+	if err != nil {
+		return err
+	}
+	for _, t := range list {
+		art, err := n.GetArtifact(t)
+		if err != nil {
+			return err
+		}
+		if art.Digest() == digest {
+			_, err = n.repo.addArtifact(n.namespace, art, art, tags...)
+			art.Close()
+			return err
+		}
+		art.Close()
+	}
+	return errors.ErrUnknown(cpi.KIND_OCIARTIFACT, digest.String())
+}
+
+func (n *NamespaceContainer) NewArtifactProvider(state accessobj.State) (cpi.ArtifactProvider, error) {
+	return nil, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Namespace struct {
+	access *NamespaceContainer
+}
+
+func (n *Namespace) Close() error {
+	return n.access.Close()
+}
+
+func (n *Namespace) GetRepository() cpi.Repository {
+	return n.access.repo
+}
+
+func (n *Namespace) GetNamespace() string {
+	return n.access.GetNamespace()
+}
+
+func (n *Namespace) ListTags() ([]string, error) {
+	return n.access.ListTags()
+}
+
+func (n *Namespace) NewArtifact(art ...*artdesc.Artifact) (cpi.ArtifactAccess, error) {
+	if n.access.IsReadOnly() {
+		return nil, accessio.ErrReadOnly
+	}
+	var m *artdesc.Artifact
+	if len(art) == 0 {
+		m = artdesc.NewManifestArtifact()
+	} else {
+		if !art[0].IsManifest() {
+			return nil, errors.ErrNotSupported("image index")
+		}
+		m = art[0]
+	}
+	return cpi.NewArtifact(n.access, m)
+}
+
+func (n *Namespace) GetBlobData(digest digest.Digest) (int64, cpi.DataAccess, error) {
+	return n.access.GetBlobData(digest)
+}
+
+func (n *Namespace) GetArtifact(vers string) (cpi.ArtifactAccess, error) {
+	return n.access.GetArtifact(vers)
+}
+
+func (n *Namespace) AddArtifact(artifact cpi.Artifact, tags ...string) (accessio.BlobAccess, error) {
+	return n.access.AddArtifact(artifact, tags...)
+}
+
+func (n *Namespace) AddTags(digest digest.Digest, tags ...string) error {
+	return n.access.AddTags(digest, tags...)
+}
+
+func (n *Namespace) AddBlob(blob cpi.BlobAccess) error {
+	return n.access.AddBlob(blob)
+}
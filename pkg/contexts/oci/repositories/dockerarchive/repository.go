@@ -0,0 +1,494 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dockerarchive
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/containers/image/v5/docker/archive"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/types"
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/docker"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+var dummyContext = context.Background()
+
+// Repository maps a docker archive to the OCI repository API.
+// Image names (without tag) are mapped to namespaces.
+//
+// The archive is accessed via a temporary copy. Added artifacts
+// are recorded and written to a new archive, which replaces the original
+// file (including the non-overwritten images of the original archive)
+// when the repository is closed.
+type Repository struct {
+	lock   sync.RWMutex
+	ctx    cpi.Context
+	spec   *RepositorySpec
+	fs     vfs.FileSystem
+	sysctx *types.SystemContext
+	tmpdir string
+	closed bool
+
+	reader *archive.Reader
+	// images maps namespace and tag to the images of the original archive.
+	images map[string]map[string]types.ImageReference
+
+	cache accessio.BlobCache
+	// written maps namespace and tag to the manifest blobs of the added images.
+	written map[string]map[string]cpi.BlobAccess
+}
+
+var _ cpi.Repository = (*Repository)(nil)
+
+func NewRepository(ctx cpi.Context, spec *RepositorySpec) (*Repository, error) {
+	fs := spec.PathFileSystem
+	if fs == nil {
+		fs = vfsattr.Get(ctx)
+	}
+	ok, err := vfs.FileExists(fs, spec.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	if !ok && !spec.AccessMode.IsCreate() {
+		return nil, errors.ErrNotFound(KIND_DOCKERARCHIVE, spec.FilePath)
+	}
+
+	tmpdir, err := os.MkdirTemp("", "docker-archive-")
+	if err != nil {
+		return nil, err
+	}
+	cache, err := accessio.NewCascadedBlobCache(nil)
+	if err != nil {
+		os.RemoveAll(tmpdir)
+		return nil, err
+	}
+	r := &Repository{
+		ctx:     ctx,
+		spec:    spec,
+		fs:      fs,
+		sysctx:  &types.SystemContext{BigFilesTemporaryDir: tmpdir},
+		tmpdir:  tmpdir,
+		images:  map[string]map[string]types.ImageReference{},
+		cache:   cache,
+		written: map[string]map[string]cpi.BlobAccess{},
+	}
+	if ok {
+		err = r.read()
+		if err != nil {
+			r.cleanup()
+			return nil, errors.Wrapf(err, "cannot read docker archive %q", spec.FilePath)
+		}
+	}
+	return r, nil
+}
+
+// read provides the content of the original archive.
+func (r *Repository) read() error {
+	path := filepath.Join(r.tmpdir, "source.tar")
+	err := copyFile(r.fs, r.spec.FilePath, nil, path)
+	if err != nil {
+		return err
+	}
+	r.reader, err = archive.NewReader(r.sysctx, path)
+	if err != nil {
+		return err
+	}
+	list, err := r.reader.List()
+	if err != nil {
+		return err
+	}
+	for _, refs := range list {
+		for _, ref := range refs {
+			tagged, ok := ref.DockerReference().(reference.NamedTagged)
+			if !ok {
+				// images without repository tag cannot be addressed
+				continue
+			}
+			name := reference.FamiliarName(tagged)
+			tags := r.images[name]
+			if tags == nil {
+				tags = map[string]types.ImageReference{}
+				r.images[name] = tags
+			}
+			tags[tagged.Tag()] = ref
+		}
+	}
+	return nil
+}
+
+func (r *Repository) GetSpecification() cpi.RepositorySpec {
+	return r.spec
+}
+
+func (r *Repository) GetContext() cpi.Context {
+	return r.ctx
+}
+
+func (r *Repository) IsReadOnly() bool {
+	return r.spec.AccessMode.IsReadonly()
+}
+
+func (r *Repository) IsClosed() bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.closed
+}
+
+func (r *Repository) NamespaceLister() cpi.NamespaceLister {
+	return r
+}
+
+func (r *Repository) NumNamespaces(prefix string) (int, error) {
+	return len(cpi.FilterByNamespacePrefix(prefix, r.GetRepositories())), nil
+}
+
+func (r *Repository) GetNamespaces(prefix string, closure bool) ([]string, error) {
+	return cpi.FilterChildren(closure, cpi.FilterByNamespacePrefix(prefix, r.GetRepositories())), nil
+}
+
+// GetRepositories returns the image names found in the archive.
+func (r *Repository) GetRepositories() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var result cpi.StringList
+	for n := range r.images {
+		result.Add(n)
+	}
+	for n := range r.written {
+		result.Add(n)
+	}
+	return result
+}
+
+func (r *Repository) ExistsArtifact(name string, tag string) (bool, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if _, ok := r.written[name][tag]; ok {
+		return true, nil
+	}
+	_, ok := r.images[name][tag]
+	return ok, nil
+}
+
+func (r *Repository) LookupArtifact(name string, tag string) (cpi.ArtifactAccess, error) {
+	n, err := r.LookupNamespace(name)
+	if err != nil {
+		return nil, err
+	}
+	defer n.Close()
+	return n.GetArtifact(tag)
+}
+
+func (r *Repository) LookupNamespace(name string) (cpi.NamespaceAccess, error) {
+	if r.IsClosed() {
+		return nil, accessio.ErrClosed
+	}
+	return NewNamespace(r, name)
+}
+
+func (r *Repository) listTags(name string) []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var result cpi.StringList
+	for t := range r.images[name] {
+		result.Add(t)
+	}
+	for t := range r.written[name] {
+		result.Add(t)
+	}
+	return result
+}
+
+func (r *Repository) getArtifact(n *NamespaceContainer, tag string) (cpi.ArtifactAccess, error) {
+	r.lock.RLock()
+	blob := r.written[n.namespace][tag]
+	ref := r.images[n.namespace][tag]
+	r.lock.RUnlock()
+
+	if blob == nil && ref == nil {
+		return nil, cpi.ErrUnknownArtifact(n.namespace, tag)
+	}
+	return r.artifactFor(n, blob, ref)
+}
+
+// artifactFor provides the artifact access for a written manifest blob
+// or an image of the original archive.
+func (r *Repository) artifactFor(n *NamespaceContainer, blob cpi.BlobAccess, ref types.ImageReference) (cpi.ArtifactAccess, error) {
+	var cache accessio.BlobCache
+	var err error
+
+	if blob != nil {
+		cache, err = accessio.NewCascadedBlobCache(r.cache)
+	} else {
+		var src types.ImageSource
+		var source accessio.BlobSource
+
+		src, err = ref.NewImageSource(dummyContext, r.sysctx)
+		if err != nil {
+			return nil, err
+		}
+		blob, source, err = docker.ConvertImageSource(src, r.sysctx)
+		if err != nil {
+			return nil, err
+		}
+		cache, err = accessio.NewCascadedBlobCacheForSource(r.cache, source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := &artifactProvider{
+		namespace: n,
+		cache:     cache,
+	}
+	return cpi.NewArtifactForProviderBlob(n, p, blob)
+}
+
+// addArtifact records an artifact with the given tags for the
+// new archive. The manifest is kept by the repository and the
+// required blobs are provided by the repository's blob cache, because
+// the archive is written not before the repository is closed.
+func (r *Repository) addArtifact(name string, art cpi.Artifact, blobs cpi.BlobSource, tags ...string) (cpi.BlobAccess, error) {
+	if r.IsReadOnly() {
+		return nil, accessio.ErrReadOnly
+	}
+	if name == "" {
+		return nil, errors.ErrNotSupported("anonymous namespace")
+	}
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 || tags[0] == "" {
+		tags = []string{"latest"}
+	}
+	for _, tag := range tags {
+		if _, err := reference.WithTag(named, tag); err != nil {
+			return nil, err
+		}
+	}
+
+	m, err := art.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	blob, err := art.Blob()
+	if err != nil {
+		return nil, err
+	}
+	data, err := blob.Get()
+	if err != nil {
+		return nil, err
+	}
+	blob = accessio.BlobAccessForData(blob.MimeType(), data)
+	err = r.provideBlob(&m.Config, blobs)
+	for i := 0; err == nil && i < len(m.Layers); i++ {
+		err = r.provideBlob(&m.Layers[i], blobs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return nil, accessio.ErrClosed
+	}
+	written := r.written[name]
+	if written == nil {
+		written = map[string]cpi.BlobAccess{}
+		r.written[name] = written
+	}
+	for _, tag := range tags {
+		written[tag] = blob
+	}
+	return blob, nil
+}
+
+// provideBlob assures that the blob for the given descriptor
+// is available in the repository's blob cache.
+func (r *Repository) provideBlob(d *cpi.Descriptor, blobs cpi.BlobSource) error {
+	if _, _, err := r.cache.GetBlobData(d.Digest); err == nil {
+		return nil
+	}
+	if blobs == nil {
+		return errors.ErrNotFound(cpi.KIND_BLOB, d.Digest.String())
+	}
+	size, data, err := blobs.GetBlobData(d.Digest)
+	if err != nil {
+		return err
+	}
+	_, _, err = r.cache.AddBlob(accessio.BlobAccessForDataAccess(d.Digest, size, d.MediaType, data))
+	return err
+}
+
+// Close finalizes a modified archive and releases all
+// temporary resources.
+func (r *Repository) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return accessio.ErrClosed
+	}
+	r.closed = true
+
+	list := errors.ErrListf("closing docker archive %q", r.spec.FilePath)
+	if len(r.written) > 0 {
+		list.Add(r.finalize())
+	}
+	list.Add(r.cleanup())
+	return list.Result()
+}
+
+// image describes an image of the final archive
+// together with all its tags.
+type image struct {
+	art  cpi.ArtifactAccess
+	tags []reference.NamedTagged
+}
+
+// finalize writes the added images and the non-overwritten images of the
+// original archive to a new archive, which replaces the original file.
+// Every image is written only once together with all its tags.
+func (r *Repository) finalize() error {
+	var images []*image
+	found := map[digest.Digest]*image{}
+	defer func() {
+		for _, i := range images {
+			i.art.Close()
+		}
+	}()
+
+	collect := func(name, tag string, blob cpi.BlobAccess, ref types.ImageReference) error {
+		named, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			return err
+		}
+		tagged, err := reference.WithTag(named, tag)
+		if err != nil {
+			return err
+		}
+		art, err := r.artifactFor(&NamespaceContainer{repo: r, namespace: name}, blob, ref)
+		if err != nil {
+			return errors.Wrapf(err, "cannot access %s:%s", name, tag)
+		}
+		if i := found[art.Digest()]; i != nil {
+			i.tags = append(i.tags, tagged)
+			return art.Close()
+		}
+		i := &image{art: art, tags: []reference.NamedTagged{tagged}}
+		images = append(images, i)
+		found[art.Digest()] = i
+		return nil
+	}
+
+	for _, name := range utils.StringMapKeys(r.written) {
+		for _, tag := range utils.StringMapKeys(r.written[name]) {
+			if err := collect(name, tag, r.written[name][tag], nil); err != nil {
+				return err
+			}
+		}
+	}
+	for _, name := range utils.StringMapKeys(r.images) {
+		for _, tag := range utils.StringMapKeys(r.images[name]) {
+			if _, ok := r.written[name][tag]; ok {
+				continue
+			}
+			if err := collect(name, tag, nil, r.images[name][tag]); err != nil {
+				return err
+			}
+		}
+	}
+
+	path := filepath.Join(r.tmpdir, "target.tar")
+	writer, err := archive.NewWriter(r.sysctx, path)
+	if err != nil {
+		return err
+	}
+	for _, i := range images {
+		err = r.write(writer, i)
+		if err != nil {
+			writer.Close()
+			return errors.Wrapf(err, "cannot write %s", i.tags[0])
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+	return copyFile(nil, path, r.fs, r.spec.FilePath)
+}
+
+func (r *Repository) write(writer *archive.Writer, i *image) error {
+	ref, err := writer.NewReference(i.tags[0])
+	if err != nil {
+		return err
+	}
+	sysctx := *r.sysctx
+	sysctx.DockerArchiveAdditionalTags = i.tags[1:]
+	dst, err := ref.NewImageDestination(dummyContext, &sysctx)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = docker.Convert(i.art, r.cache, dst)
+	if err != nil {
+		return err
+	}
+	return dst.Commit(dummyContext, nil)
+}
+
+func (r *Repository) cleanup() error {
+	list := errors.ErrListf("cleanup")
+	if r.reader != nil {
+		list.Add(r.reader.Close())
+		r.reader = nil
+	}
+	list.Add(r.cache.Unref())
+	list.Add(os.RemoveAll(r.tmpdir))
+	return list.Result()
+}
+
+// copyFile copies a file between virtual filesystems.
+// A nil filesystem describes the OS filesystem.
+func copyFile(srcfs vfs.FileSystem, src string, dstfs vfs.FileSystem, dst string) error {
+	if srcfs == nil {
+		srcfs = osfs.New()
+	}
+	if dstfs == nil {
+		dstfs = osfs.New()
+	}
+	in, err := srcfs.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := dstfs.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
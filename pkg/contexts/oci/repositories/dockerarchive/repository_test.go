@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dockerarchive_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/dockerarchive"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer"
+)
+
+const (
+	ARCHIVE = "/tmp/image.tar"
+	NS      = "mandelsoft/test"
+)
+
+func tarData(files map[string][]byte) []byte {
+	buf := bytes.NewBuffer(nil)
+	tw := tar.NewWriter(buf)
+	for n, data := range files {
+		ExpectWithOffset(1, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: n, Mode: 0o644, Size: int64(len(data))})).To(Succeed())
+		ExpectWithOffset(1, Must(tw.Write(data))).To(Equal(len(data)))
+	}
+	ExpectWithOffset(1, tw.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+func configData(layer []byte) []byte {
+	return []byte(fmt.Sprintf(`{"architecture":"amd64","os":"linux","config":{},"rootfs":{"type":"layers","diff_ids":[%q]}}`, digest.FromBytes(layer)))
+}
+
+func addImage(src cpi.NamespaceAccess, ns cpi.NamespaceAccess, layer []byte, tags ...string) digest.Digest {
+	art := Must(src.NewArtifact())
+	defer art.Close()
+	Expect(art.AddLayer(accessio.BlobAccessForData(artdesc.MediaTypeImageLayer, layer), nil)).To(Equal(0))
+	config := accessio.BlobAccessForData(ociv1.MediaTypeImageConfig, configData(layer))
+	MustBeSuccessful(src.AddBlob(config))
+	desc := Must(art.Manifest())
+	desc.Config = *artdesc.DefaultBlobDescriptor(config)
+	blob := Must(src.AddArtifact(art))
+	MustBeSuccessful(transfer.TransferArtifact(art, ns, tags...))
+	return blob.Digest()
+}
+
+func readArchive(fs vfs.FileSystem, path string) map[string][]byte {
+	files := map[string][]byte{}
+	f := Must(fs.Open(path))
+	defer f.Close()
+	tr := tar.NewReader(f)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		Expect(err).To(Succeed())
+		files[h.Name] = Must(io.ReadAll(tr))
+	}
+	return files
+}
+
+func checkImage(ns cpi.NamespaceAccess, tag string, layer []byte) {
+	art := Must(ns.GetArtifact(tag))
+	defer art.Close()
+	ExpectWithOffset(1, art.IsManifest()).To(BeTrue())
+	m := art.ManifestAccess().GetDescriptor()
+	ExpectWithOffset(1, m.MediaType).To(Equal(artdesc.MediaTypeImageManifest))
+	ExpectWithOffset(1, len(m.Layers)).To(Equal(1))
+	blob := Must(art.GetBlob(m.Layers[0].Digest))
+	ExpectWithOffset(1, Must(blob.Get())).To(Equal(layer))
+}
+
+type manifestItem struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+var _ = Describe("docker archive", func() {
+	var fs vfs.FileSystem
+	var repo cpi.Repository
+	var src cpi.NamespaceAccess
+	var layer1, layer2 []byte
+
+	BeforeEach(func() {
+		fs = memoryfs.New()
+		MustBeSuccessful(fs.MkdirAll("/tmp", 0o700))
+		repo = Must(ctf.Open(cpi.DefaultContext, accessobj.ACC_CREATE, "/tmp/ctf", 0o700, accessio.PathFileSystem(fs)))
+		src = Must(repo.LookupNamespace("source"))
+		layer1 = tarData(map[string][]byte{"hello.txt": []byte("hello")})
+		layer2 = tarData(map[string][]byte{"world.txt": []byte("world")})
+	})
+
+	AfterEach(func() {
+		MustBeSuccessful(src.Close())
+		MustBeSuccessful(repo.Close())
+	})
+
+	It("writes and reads an archive", func() {
+		r := Must(cpi.DefaultContext.RepositoryForSpec(dockerarchive.NewRepositorySpec(accessobj.ACC_CREATE, ARCHIVE, fs)))
+		ns := Must(r.LookupNamespace(NS))
+		dig := addImage(src, ns, layer1, "v1")
+		MustBeSuccessful(ns.AddTags(dig, "latest"))
+		Expect(ns.ListTags()).To(ConsistOf("v1", "latest"))
+		checkImage(ns, "v1", layer1)
+		MustBeSuccessful(ns.Close())
+		MustBeSuccessful(r.Close())
+
+		files := readArchive(fs, ARCHIVE)
+		Expect(files).To(HaveKey("repositories"))
+		var items []manifestItem
+		MustBeSuccessful(json.Unmarshal(files["manifest.json"], &items))
+		Expect(len(items)).To(Equal(1))
+		Expect(items[0].RepoTags).To(ConsistOf("docker.io/"+NS+":v1", "docker.io/"+NS+":latest"))
+		Expect(files[items[0].Layers[0]]).To(Equal(layer1))
+
+		r = Must(cpi.DefaultContext.RepositoryForSpec(dockerarchive.NewRepositorySpec(accessobj.ACC_READONLY, ARCHIVE, fs)))
+		defer Close(r)
+		Expect(r.NamespaceLister().GetNamespaces("", true)).To(Equal([]string{NS}))
+		ns = Must(r.LookupNamespace(NS))
+		defer Close(ns)
+		Expect(ns.ListTags()).To(ConsistOf("v1", "latest"))
+		checkImage(ns, "latest", layer1)
+		_, err := ns.AddArtifact(Must(ns.GetArtifact("v1")), "v2")
+		Expect(err).To(MatchError(accessio.ErrReadOnly))
+	})
+
+	It("retains the original content when adding images", func() {
+		r := Must(cpi.DefaultContext.RepositoryForSpec(dockerarchive.NewRepositorySpec(accessobj.ACC_CREATE, ARCHIVE, fs)))
+		ns := Must(r.LookupNamespace(NS))
+		addImage(src, ns, layer1, "v1")
+		addImage(src, ns, layer1, "v2")
+		MustBeSuccessful(ns.Close())
+		MustBeSuccessful(r.Close())
+
+		r = Must(cpi.DefaultContext.RepositoryForSpec(dockerarchive.NewRepositorySpec(accessobj.ACC_WRITABLE, ARCHIVE, fs)))
+		ns = Must(r.LookupNamespace("other"))
+		addImage(src, ns, layer2, "v1")
+		MustBeSuccessful(ns.Close())
+		ns = Must(r.LookupNamespace(NS))
+		addImage(src, ns, layer2, "v2")
+		MustBeSuccessful(ns.Close())
+		MustBeSuccessful(r.Close())
+
+		r = Must(cpi.DefaultContext.RepositoryForSpec(dockerarchive.NewRepositorySpec(accessobj.ACC_READONLY, ARCHIVE, fs)))
+		defer Close(r)
+		Expect(r.NamespaceLister().GetNamespaces("", true)).To(ConsistOf(NS, "other"))
+		ns = Must(r.LookupNamespace(NS))
+		defer Close(ns)
+		checkImage(ns, "v1", layer1)
+		checkImage(ns, "v2", layer2)
+		other := Must(r.LookupNamespace("other"))
+		defer Close(other)
+		checkImage(other, "v1", layer2)
+	})
+
+	It("reads a legacy docker save archive", func() {
+		config := configData(layer1)
+		id := digest.FromBytes(config).Encoded()
+		layerid := digest.FromBytes(layer1).Encoded()
+		manifest := Must(json.Marshal([]manifestItem{{
+			Config:   id + ".json",
+			RepoTags: []string{"alpine:3.17"},
+			Layers:   []string{layerid + "/layer.tar"},
+		}}))
+		data := tarData(map[string][]byte{
+			"manifest.json":        manifest,
+			"repositories":         []byte(fmt.Sprintf(`{"alpine":{"3.17":%q}}`, layerid)),
+			id + ".json":           config,
+			layerid + "/VERSION":   []byte("1.0"),
+			layerid + "/json":      []byte(fmt.Sprintf(`{"id":%q}`, layerid)),
+			layerid + "/layer.tar": layer1,
+		})
+		MustBeSuccessful(vfs.WriteFile(fs, ARCHIVE, data, 0o600))
+
+		ctx := oci.New()
+		vfsattr.Set(ctx, fs)
+		u := Must(oci.ParseRepo(dockerarchive.Type + "::" + ARCHIVE))
+		spec := Must(ctx.MapUniformRepositorySpec(&u)).(*dockerarchive.RepositorySpec)
+		r := Must(ctx.RepositoryForSpec(spec))
+		defer Close(r)
+		Expect(r.ExistsArtifact("alpine", "3.17")).To(BeTrue())
+		art := Must(r.LookupArtifact("alpine", "3.17"))
+		defer Close(art)
+		Expect(art.ManifestAccess().GetDescriptor().Config.Digest).To(Equal(digest.FromBytes(config)))
+	})
+})
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dockerarchive
+
+import (
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/runtime"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+const (
+	Type   = "DockerArchive"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+
+	KIND_DOCKERARCHIVE = "docker archive"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(Type, cpi.NewRepositoryType(Type, &RepositorySpec{}))
+	cpi.RegisterRepositoryType(TypeV1, cpi.NewRepositoryType(TypeV1, &RepositorySpec{}))
+}
+
+// RepositorySpec describes a repository interface backed by a
+// docker archive (as created by docker save).
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// FilePath is the path of the archive file
+	FilePath string `json:"filePath"`
+	// AccessMode can be set to request readonly access or creation
+	AccessMode accessobj.AccessMode `json:"accessMode,omitempty"`
+
+	// PathFileSystem is the virtual filesystem to evaluate the file path.
+	// This configuration option is not available for the textual representation of
+	// the repository specification
+	PathFileSystem vfs.FileSystem `json:"-"`
+}
+
+var _ cpi.RepositorySpec = (*RepositorySpec)(nil)
+
+// NewRepositorySpec creates a new RepositorySpec for an optional filesystem.
+func NewRepositorySpec(mode accessobj.AccessMode, filePath string, fs ...vfs.FileSystem) *RepositorySpec {
+	return &RepositorySpec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		FilePath:            filePath,
+		AccessMode:          mode,
+		PathFileSystem:      utils.Optional(fs...),
+	}
+}
+
+func (a *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (a *RepositorySpec) Name() string {
+	return a.FilePath
+}
+
+func (a *RepositorySpec) UniformRepositorySpec() *cpi.UniformRepositorySpec {
+	return &cpi.UniformRepositorySpec{
+		Type: Type,
+		Info: a.FilePath,
+	}
+}
+
+func (a *RepositorySpec) Repository(ctx cpi.Context, creds credentials.Credentials) (cpi.Repository, error) {
+	return NewRepository(ctx, a)
+}
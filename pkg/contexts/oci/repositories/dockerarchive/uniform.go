@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dockerarchive
+
+import (
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+func init() {
+	cpi.RegisterRepositorySpecHandler(&repospechandler{}, Type)
+}
+
+type repospechandler struct{}
+
+func (h *repospechandler) MapReference(ctx cpi.Context, u *cpi.UniformRepositorySpec) (cpi.RepositorySpec, error) {
+	path := u.Info
+	if u.Info == "" {
+		if u.Host == "" {
+			return nil, nil
+		}
+		path = u.Host
+	}
+	fs := vfsattr.Get(ctx)
+
+	ok, err := vfs.FileExists(fs, path)
+	if err != nil {
+		return nil, err
+	}
+	mode := accessobj.ACC_WRITABLE
+	if !ok {
+		if !u.CreateIfMissing {
+			return nil, errors.ErrNotFound(KIND_DOCKERARCHIVE, path)
+		}
+		mode |= accessobj.ACC_CREATE
+	}
+	return NewRepositorySpec(mode, path, fs), nil
+}
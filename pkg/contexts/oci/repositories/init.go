@@ -8,6 +8,8 @@ import (
 	_ "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
 	_ "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
 	_ "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/docker"
+	_ "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/dockerarchive"
 	_ "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/empty"
+	_ "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocilayout"
 	_ "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocireg"
 )
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ocilayout
+
+import (
+	"sync"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const (
+	IndexFileName      = "index.json"
+	LayoutFileName     = "oci-layout"
+	BlobsDirectoryName = "blobs"
+
+	LayoutVersion = "1.0.0"
+)
+
+// DigestFileName maps a blob digest to its location
+// below the blobs directory (<algorithm>/<encoded>)
+// as required by the OCI image layout specification.
+func DigestFileName(d digest.Digest) string {
+	return d.Algorithm().String() + "/" + d.Encoded()
+}
+
+type accessObjectInfo struct {
+	accessobj.DefaultAccessObjectInfo
+}
+
+var _ accessobj.AccessObjectInfo = (*accessObjectInfo)(nil)
+
+var accessObjectInfoInstance = &accessObjectInfo{
+	accessobj.DefaultAccessObjectInfo{
+		DescriptorFileName:       IndexFileName,
+		ObjectTypeName:           "ocilayout",
+		ElementDirectoryName:     BlobsDirectoryName,
+		ElementTypeName:          "blob",
+		DescriptorHandlerFactory: NewStateHandler,
+		AdditionalFiles:          []string{LayoutFileName},
+	},
+}
+
+func NewAccessObjectInfo() accessobj.AccessObjectInfo {
+	return accessObjectInfoInstance
+}
+
+func (a *accessObjectInfo) SetupFileSystem(fs vfs.FileSystem, mode vfs.FileMode) error {
+	if err := a.DefaultAccessObjectInfo.SetupFileSystem(fs, mode); err != nil {
+		return err
+	}
+	data := `{
+    "imageLayoutVersion": "` + LayoutVersion + `"
+}
+`
+	return vfs.WriteFile(fs, LayoutFileName, []byte(data), mode)
+}
+
+// NewStateHandler implements the factory interface for the OCI layout
+// state descriptor handling.
+// The index.json of an OCI layout is just an OCI index.
+func NewStateHandler(fs vfs.FileSystem) accessobj.StateHandler {
+	return &cpi.IndexStateHandler{}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Object = Layout
+
+type FormatHandler interface {
+	accessio.Option
+
+	Format() accessio.FileFormat
+
+	Open(acc accessobj.AccessMode, path string, opts accessio.Options) (*Object, error)
+	Create(path string, opts accessio.Options, mode vfs.FileMode) (*Object, error)
+	Write(obj *Object, path string, opts accessio.Options, mode vfs.FileMode) error
+}
+
+type formatHandler struct {
+	accessobj.FormatHandler
+}
+
+var (
+	FormatDirectory = RegisterFormat(accessobj.FormatDirectory)
+	FormatTAR       = RegisterFormat(accessobj.FormatTAR)
+	FormatTGZ       = RegisterFormat(accessobj.FormatTGZ)
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+var (
+	fileFormats = map[accessio.FileFormat]FormatHandler{}
+	lock        sync.RWMutex
+)
+
+func RegisterFormat(f accessobj.FormatHandler) FormatHandler {
+	lock.Lock()
+	defer lock.Unlock()
+	h := &formatHandler{f}
+	fileFormats[f.Format()] = h
+	return h
+}
+
+func GetFormats() []string {
+	lock.RLock()
+	defer lock.RUnlock()
+	return accessio.GetFormatsFor(fileFormats)
+}
+
+func GetFormat(name accessio.FileFormat) FormatHandler {
+	lock.RLock()
+	defer lock.RUnlock()
+	return fileFormats[name]
+}
+
+func SupportedFormats() []accessio.FileFormat {
+	lock.RLock()
+	defer lock.RUnlock()
+	result := make([]accessio.FileFormat, 0, len(fileFormats))
+	for f := range fileFormats {
+		result = append(result, f)
+	}
+	return result
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Open(acc accessobj.AccessMode, path string, mode vfs.FileMode, olist ...accessio.Option) (*Object, error) {
+	o, create, err := accessobj.HandleAccessMode(acc, path, nil, olist...)
+	if err != nil {
+		return nil, err
+	}
+	h, ok := fileFormats[*o.GetFileFormat()]
+	if !ok {
+		return nil, errors.ErrUnknown(accessobj.KIND_FILEFORMAT, o.GetFileFormat().String())
+	}
+	if create {
+		return h.Create(path, o, mode)
+	}
+	return h.Open(acc, path, o)
+}
+
+func Create(acc accessobj.AccessMode, path string, mode vfs.FileMode, opts ...accessio.Option) (*Object, error) {
+	o, err := accessio.AccessOptions(nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	o.DefaultFormat(accessio.FormatDirectory)
+	h, ok := fileFormats[*o.GetFileFormat()]
+	if !ok {
+		return nil, errors.ErrUnknown(accessobj.KIND_FILEFORMAT, o.GetFileFormat().String())
+	}
+	return h.Create(path, o, mode)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (h *formatHandler) Open(acc accessobj.AccessMode, path string, opts accessio.Options) (*Object, error) {
+	return _Wrap(h.FormatHandler.Open(NewAccessObjectInfo(), acc, path, opts))
+}
+
+func (h *formatHandler) Create(path string, opts accessio.Options, mode vfs.FileMode) (*Object, error) {
+	return _Wrap(h.FormatHandler.Create(NewAccessObjectInfo(), path, opts, mode))
+}
+
+// Write writes the current object to a filesystem.
+func (h *formatHandler) Write(obj *Object, path string, opts accessio.Options, mode vfs.FileMode) error {
+	return h.FormatHandler.Write(obj.base.Access(), path, opts, mode)
+}
@@ -0,0 +1,367 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ocilayout
+
+import (
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi/support"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// REFNAME_ANNOTATION is the index annotation used by the
+// OCI image layout to describe the tag of a manifest.
+const REFNAME_ANNOTATION = artifactset.OCITAG_ANNOTATION
+
+func RetrieveRefName(m map[string]string) string {
+	return m[REFNAME_ANNOTATION]
+}
+
+// Layout provides an OCI image layout view on the layout implementation.
+// Every Layout is separated closable. If the last view is closed
+// the implementation is released.
+type Layout struct {
+	view        support.ArtifactSetContainer
+	*layoutImpl // provide the artifact set interface
+}
+
+// implemented by view
+// the rest is directly taken from the layout implementation
+
+func (s *Layout) Close() error {
+	return s.view.Close()
+}
+
+// Dup provides a new separately closable view on the layout.
+func (s *Layout) Dup() (*Layout, error) {
+	v, err := s.impl.View()
+	if err != nil {
+		return nil, err
+	}
+	return &Layout{v, s.layoutImpl}, nil
+}
+
+func (s *Layout) IsClosed() bool {
+	return s.view.IsClosed()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type layoutImpl struct {
+	impl support.ArtifactSetContainerImpl
+	base *artifactset.FileSystemBlobAccess
+	*support.ArtifactSetAccess
+}
+
+var (
+	_ cpi.ArtifactSink    = (*Layout)(nil)
+	_ cpi.NamespaceAccess = (*Layout)(nil)
+)
+
+// New returns a new representation based element.
+func New(acc accessobj.AccessMode, fs vfs.FileSystem, setup accessobj.Setup, closer accessobj.Closer, mode vfs.FileMode) (*Layout, error) {
+	return _Wrap(accessobj.NewAccessObject(NewAccessObjectInfo(), acc, fs, setup, closer, mode))
+}
+
+func _Wrap(obj *accessobj.AccessObject, err error) (*Layout, error) {
+	if err != nil {
+		return nil, err
+	}
+	s := &layoutImpl{
+		base: artifactset.NewFileSystemBlobAccess(obj, DigestFileName),
+	}
+	s.ArtifactSetAccess = support.NewArtifactSetAccess(s)
+	v, impl := support.NewArtifactSetContainer(s)
+	s.impl = impl
+	return &Layout{v, s}, nil
+}
+
+func (a *layoutImpl) GetNamespace() string {
+	return ""
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// sink
+
+// AddTags tags an artifact already contained in the layout.
+// According to the OCI image layout, every tag is described by a
+// dedicated index entry using the annotation REFNAME_ANNOTATION.
+// A tag may only refer to a single artifact, therefore it is removed
+// from all other entries.
+func (a *layoutImpl) AddTags(digest digest.Digest, tags ...string) error {
+	if a.IsClosed() {
+		return accessio.ErrClosed
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	a.base.Lock()
+	defer a.base.Unlock()
+
+	idx := a.GetIndex()
+	var desc *artdesc.Descriptor
+	for i, e := range idx.Manifests {
+		if e.Digest == digest {
+			desc = &idx.Manifests[i]
+			break
+		}
+	}
+	if desc == nil {
+		return errors.ErrUnknown(cpi.KIND_OCIARTIFACT, digest.String())
+	}
+	proto := *desc
+	proto.Annotations = nil
+
+	for _, tag := range tags {
+		found := false
+		for i := 0; i < len(idx.Manifests); i++ {
+			e := &idx.Manifests[i]
+			if RetrieveRefName(e.Annotations) != tag {
+				continue
+			}
+			if e.Digest == digest {
+				found = true
+				continue
+			}
+			// move tag to new artifact
+			delete(e.Annotations, REFNAME_ANNOTATION)
+		}
+		if found {
+			continue
+		}
+		// reuse an untagged entry for the artifact, if possible
+		for i, e := range idx.Manifests {
+			if e.Digest == digest && RetrieveRefName(e.Annotations) == "" {
+				if e.Annotations == nil {
+					idx.Manifests[i].Annotations = map[string]string{}
+				}
+				idx.Manifests[i].Annotations[REFNAME_ANNOTATION] = tag
+				found = true
+				break
+			}
+		}
+		if !found {
+			n := proto
+			n.Annotations = map[string]string{REFNAME_ANNOTATION: tag}
+			idx.Manifests = append(idx.Manifests, n)
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// forward
+
+func (a *layoutImpl) IsReadOnly() bool {
+	return a.base.IsReadOnly()
+}
+
+func (a *layoutImpl) Write(path string, mode vfs.FileMode, opts ...accessio.Option) error {
+	return a.base.Write(path, mode, opts...)
+}
+
+func (a *layoutImpl) Update() error {
+	return a.base.Update()
+}
+
+func (a *layoutImpl) Close() error {
+	return a.base.Close()
+}
+
+func (a *layoutImpl) IsClosed() bool {
+	return a.base.IsClosed()
+}
+
+// GetIndex returns the index of the included artifacts
+// (image manifests and image indices).
+// Tagged entries are described by the annotation
+// REFNAME_ANNOTATION.
+func (a *layoutImpl) GetIndex() *artdesc.Index {
+	if a.IsReadOnly() {
+		return a.base.GetState().GetOriginalState().(*artdesc.Index)
+	}
+	return a.base.GetState().GetState().(*artdesc.Index)
+}
+
+func (a *layoutImpl) GetBlobDescriptor(digest digest.Digest) *cpi.Descriptor {
+	return a.GetIndex().GetBlobDescriptor(digest)
+}
+
+func (a *layoutImpl) GetBlobData(digest digest.Digest) (int64, cpi.DataAccess, error) {
+	return a.base.GetBlobData(digest)
+}
+
+func (a *layoutImpl) AddBlob(blob cpi.BlobAccess) error {
+	if a.IsClosed() {
+		return accessio.ErrClosed
+	}
+	if a.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	if blob == nil {
+		return nil
+	}
+	a.base.Lock()
+	defer a.base.Unlock()
+	return a.base.AddBlob(blob)
+}
+
+func (a *layoutImpl) ListTags() ([]string, error) {
+	result := []string{}
+	for _, e := range a.GetIndex().Manifests {
+		if tag := RetrieveRefName(e.Annotations); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result, nil
+}
+
+func (a *layoutImpl) GetTags(digest digest.Digest) ([]string, error) {
+	result := []string{}
+	for _, e := range a.GetIndex().Manifests {
+		if e.Digest == digest {
+			if tag := RetrieveRefName(e.Annotations); tag != "" {
+				result = append(result, tag)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (a *layoutImpl) HasArtifact(ref string) (bool, error) {
+	if a.IsClosed() {
+		return false, accessio.ErrClosed
+	}
+	a.base.Lock()
+	defer a.base.Unlock()
+	return a.hasArtifact(ref)
+}
+
+func (a *layoutImpl) GetArtifact(ref string) (cpi.ArtifactAccess, error) {
+	if a.IsClosed() {
+		return nil, accessio.ErrClosed
+	}
+	a.base.Lock()
+	defer a.base.Unlock()
+	return a.getArtifact(ref)
+}
+
+func (a *layoutImpl) matcher(ref string) func(d *artdesc.Descriptor) bool {
+	if ok, digest := artdesc.IsDigest(ref); ok {
+		return func(desc *artdesc.Descriptor) bool {
+			return desc.Digest == digest
+		}
+	}
+	return func(d *artdesc.Descriptor) bool {
+		return RetrieveRefName(d.Annotations) == ref
+	}
+}
+
+func (a *layoutImpl) hasArtifact(ref string) (bool, error) {
+	idx := a.GetIndex()
+	match := a.matcher(ref)
+	for i := range idx.Manifests {
+		if match(&idx.Manifests[i]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (a *layoutImpl) getArtifact(ref string) (cpi.ArtifactAccess, error) {
+	idx := a.GetIndex()
+	match := a.matcher(ref)
+	for i, e := range idx.Manifests {
+		if match(&idx.Manifests[i]) {
+			return a.base.GetArtifact(a.impl, e.Digest)
+		}
+	}
+	return nil, errors.ErrUnknown(cpi.KIND_OCIARTIFACT, ref)
+}
+
+func (a *layoutImpl) AnnotateArtifact(digest digest.Digest, name, value string) error {
+	if a.IsClosed() {
+		return accessio.ErrClosed
+	}
+	if a.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	a.base.Lock()
+	defer a.base.Unlock()
+	idx := a.GetIndex()
+	found := false
+	for i, e := range idx.Manifests {
+		if e.Digest == digest {
+			if e.Annotations == nil {
+				idx.Manifests[i].Annotations = map[string]string{}
+			}
+			idx.Manifests[i].Annotations[name] = value
+			found = true
+		}
+	}
+	if !found {
+		return errors.ErrUnknown(cpi.KIND_OCIARTIFACT, digest.String())
+	}
+	return nil
+}
+
+func (a *layoutImpl) AddArtifact(artifact cpi.Artifact, tags ...string) (access accessio.BlobAccess, err error) {
+	blob, err := a.AddPlatformArtifact(artifact, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = a.AddTags(blob.Digest(), tags...)
+	if err != nil {
+		return nil, err
+	}
+	// maintain the referrers index according to the referrers tag schema
+	return blob, cpi.AddReferrerByTag(a, artifact, blob)
+}
+
+func (a *layoutImpl) AddPlatformArtifact(artifact cpi.Artifact, platform *artdesc.Platform) (access accessio.BlobAccess, err error) {
+	if a.IsClosed() {
+		return nil, accessio.ErrClosed
+	}
+	if a.IsReadOnly() {
+		return nil, accessio.ErrReadOnly
+	}
+	a.base.Lock()
+	defer a.base.Unlock()
+	idx := a.GetIndex()
+	blob, err := a.base.AddArtifactBlob(artifact)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range idx.Manifests {
+		if e.Digest == blob.Digest() {
+			// already described by the index
+			return blob, nil
+		}
+	}
+	idx.Manifests = append(idx.Manifests, cpi.Descriptor{
+		MediaType: blob.MimeType(),
+		Digest:    blob.Digest(),
+		Size:      blob.Size(),
+		Platform:  platform,
+	})
+	return blob, nil
+}
+
+func (a *layoutImpl) NewArtifact(artifact ...*artdesc.Artifact) (cpi.ArtifactAccess, error) {
+	if a.IsClosed() {
+		return nil, accessio.ErrClosed
+	}
+	if a.IsReadOnly() {
+		return nil, accessio.ErrReadOnly
+	}
+	return support.NewArtifact(a.impl, artifact...)
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ocilayout_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocilayout"
+	testenv "github.com/open-component-model/ocm/pkg/env"
+	"github.com/open-component-model/ocm/pkg/env/builder"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+func blobPath(d string) string {
+	return ocilayout.BlobsDirectoryName + "/sha256/" + d
+}
+
+var _ = Describe("oci image layout", func() {
+	var tempfs vfs.FileSystem
+	var opts accessio.Options
+
+	BeforeEach(func() {
+		tempfs = Must(osfs.NewTempFileSystem())
+		opts = Must(accessio.AccessOptions(nil, accessio.PathFileSystem(tempfs)))
+	})
+
+	AfterEach(func() {
+		vfs.Cleanup(tempfs)
+	})
+
+	It("creates a layout directory", func() {
+		a := Must(ocilayout.FormatDirectory.Create("test", opts, 0o700))
+		DefaultManifestFill(a)
+		MustBeSuccessful(a.AddTags(digest.Digest("sha256:"+DIGEST_MANIFEST), "latest"))
+		MustBeSuccessful(a.Close())
+
+		Expect(vfs.ReadFile(tempfs, "test/"+ocilayout.LayoutFileName)).To(MatchJSON(`{"imageLayoutVersion": "1.0.0"}`))
+		for _, d := range []string{DIGEST_MANIFEST, DIGEST_LAYER, DIGEST_CONFIG} {
+			Expect(vfs.FileExists(tempfs, "test/"+blobPath(d))).To(BeTrue())
+		}
+
+		var idx artdesc.Index
+		MustBeSuccessful(json.Unmarshal(Must(vfs.ReadFile(tempfs, "test/"+ocilayout.IndexFileName)), &idx))
+		Expect(len(idx.Manifests)).To(Equal(2))
+		for i, tag := range []string{TAG, "latest"} {
+			Expect(idx.Manifests[i].Digest).To(Equal(digest.Digest("sha256:" + DIGEST_MANIFEST)))
+			Expect(idx.Manifests[i].Annotations).To(Equal(map[string]string{ocilayout.REFNAME_ANNOTATION: tag}))
+		}
+	})
+
+	It("moves tags between artifacts", func() {
+		a := Must(ocilayout.FormatDirectory.Create("test", opts, 0o700))
+		defer Close(a)
+		DefaultManifestFill(a)
+
+		art := NewArtifact(a)
+		Expect(art.AddLayer(accessio.BlobAccessForString(mime.MIME_OCTET, "other"), nil)).To(Equal(1))
+		blob := Must(a.AddArtifact(art, "latest", TAG))
+		art.Close()
+
+		Expect(a.ListTags()).To(ConsistOf(TAG, "latest"))
+		Expect(a.GetTags(digest.Digest("sha256:" + DIGEST_MANIFEST))).To(BeEmpty())
+		Expect(a.GetTags(blob.Digest())).To(ConsistOf(TAG, "latest"))
+		found := Must(a.GetArtifact(TAG))
+		defer Close(found)
+		Expect(found.Digest()).To(Equal(blob.Digest()))
+
+		old := Must(a.GetArtifact("sha256:" + DIGEST_MANIFEST))
+		defer Close(old)
+		CheckArtifact(old)
+	})
+
+	for _, f := range []accessio.FileFormat{accessio.FormatDirectory, accessio.FormatTar, accessio.FormatTGZ} {
+		format := f
+		It("writes and reads "+format.String(), func() {
+			a := Must(ocilayout.Create(accessobj.ACC_CREATE, "test", 0o700, opts, format))
+			DefaultManifestFill(a)
+			MustBeSuccessful(a.Close())
+
+			a = Must(ocilayout.Open(accessobj.ACC_READONLY, "test", 0o700, opts))
+			defer Close(a)
+			Expect(a.ListTags()).To(Equal([]string{TAG}))
+			art := Must(a.GetArtifact(TAG))
+			defer Close(art)
+			CheckArtifact(art)
+		})
+	}
+
+	It("reads an externally written layout", func() {
+		layer := []byte("testdata")
+		config := []byte("{}")
+		m := artdesc.NewManifest()
+		m.Config = *artdesc.DefaultBlobDescriptor(accessio.BlobAccessForData(mime.MIME_OCTET, config))
+		m.Layers = append(m.Layers, *artdesc.DefaultBlobDescriptor(accessio.BlobAccessForData(mime.MIME_OCTET, layer)))
+		manifest := Must(json.Marshal(m))
+
+		idx := artdesc.NewIndex()
+		idx.Manifests = append(idx.Manifests, artdesc.Descriptor{
+			MediaType:   artdesc.MediaTypeImageManifest,
+			Digest:      digest.FromBytes(manifest),
+			Size:        int64(len(manifest)),
+			Annotations: map[string]string{ocilayout.REFNAME_ANNOTATION: "1.0"},
+		})
+
+		MustBeSuccessful(tempfs.MkdirAll("ext/"+ocilayout.BlobsDirectoryName+"/sha256", 0o700))
+		MustBeSuccessful(vfs.WriteFile(tempfs, "ext/"+ocilayout.LayoutFileName, []byte(`{"imageLayoutVersion":"1.0.0"}`), 0o600))
+		MustBeSuccessful(vfs.WriteFile(tempfs, "ext/"+ocilayout.IndexFileName, Must(json.Marshal(idx)), 0o600))
+		for _, b := range [][]byte{layer, config, manifest} {
+			MustBeSuccessful(vfs.WriteFile(tempfs, "ext/"+blobPath(digest.FromBytes(b).Encoded()), b, 0o600))
+		}
+
+		spec := Must(ocilayout.NewRepositorySpec(accessobj.ACC_READONLY, "ext", accessio.PathFileSystem(tempfs)))
+		r := Must(cpi.DefaultContext.RepositoryForSpec(spec))
+		defer Close(r)
+		ns := Must(r.LookupNamespace(""))
+		defer Close(ns)
+		Expect(ns.ListTags()).To(Equal([]string{"1.0"}))
+		art := Must(ns.GetArtifact("1.0"))
+		defer Close(art)
+		blob := Must(art.GetBlob(digest.FromBytes(layer)))
+		Expect(blob.Get()).To(Equal(layer))
+	})
+})
+
+var _ = Describe("uniform spec", func() {
+	var env *builder.Builder
+
+	BeforeEach(func() {
+		env = builder.NewBuilder(testenv.NewEnvironment())
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("creates a layout for an explicit type", func() {
+		u := Must(oci.ParseRepo(ocilayout.Type + "+tgz::/tmp/layout.tgz"))
+		u.CreateIfMissing = true
+		spec := Must(env.OCIContext().MapUniformRepositorySpec(&u))
+		Expect(spec.GetType()).To(Equal(ocilayout.Type))
+		Expect(*spec.(*ocilayout.RepositorySpec).GetFileFormat()).To(Equal(accessio.FormatTGZ))
+
+		r := Must(env.OCIContext().RepositoryForSpec(spec))
+		ns := Must(r.LookupNamespace(""))
+		DefaultManifestFill(ns)
+		MustBeSuccessful(ns.Close())
+		MustBeSuccessful(r.Close())
+
+		u = Must(oci.ParseRepo(ocilayout.Type + "::/tmp/layout.tgz"))
+		spec = Must(env.OCIContext().MapUniformRepositorySpec(&u))
+		Expect(spec.(*ocilayout.RepositorySpec).AccessMode).To(Equal(accessobj.ACC_WRITABLE))
+	})
+
+	It("does not claim untyped references", func() {
+		u := Must(oci.ParseRepo("/tmp/layout"))
+		u.CreateIfMissing = true
+		u.TypeHint = ocilayout.Type
+		_, err := env.OCIContext().MapUniformRepositorySpec(&u)
+		Expect(err).To(HaveOccurred())
+	})
+})
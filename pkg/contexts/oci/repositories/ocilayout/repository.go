@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ocilayout
+
+import (
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// Repository provides the repository view of an OCI image layout.
+// Like an artifact set, it offers a single anonymous namespace.
+type Repository struct {
+	ctx    cpi.Context
+	spec   *RepositorySpec
+	layout *Layout
+}
+
+var _ cpi.Repository = (*Repository)(nil)
+
+func NewRepository(ctx cpi.Context, s *RepositorySpec) (*Repository, error) {
+	if s.PathFileSystem == nil {
+		s.PathFileSystem = vfsattr.Get(ctx)
+	}
+	r := &Repository{ctx, s, nil}
+	_, err := r.Open()
+	if err != nil {
+		return nil, err
+	}
+	return r, err
+}
+
+func (r *Repository) Get() *Layout {
+	return r.layout
+}
+
+func (r *Repository) Open() (*Layout, error) {
+	a, err := Open(r.spec.AccessMode, r.spec.FilePath, 0o700, &r.spec.StandardOptions, accessio.PathFileSystem(r.spec.PathFileSystem))
+	if err != nil {
+		return nil, err
+	}
+	r.layout = a
+	return a, nil
+}
+
+func (r *Repository) GetContext() cpi.Context {
+	return r.ctx
+}
+
+func (r *Repository) GetSpecification() cpi.RepositorySpec {
+	return r.spec
+}
+
+func (r *Repository) NamespaceLister() cpi.NamespaceLister {
+	return &artifactset.NamespaceLister{}
+}
+
+func (r *Repository) ExistsArtifact(name string, ref string) (bool, error) {
+	if name != "" {
+		return false, nil
+	}
+	return r.layout.HasArtifact(ref)
+}
+
+func (r *Repository) LookupArtifact(name string, ref string) (cpi.ArtifactAccess, error) {
+	if name != "" {
+		return nil, cpi.ErrUnknownArtifact(name, ref)
+	}
+	return r.layout.GetArtifact(ref)
+}
+
+func (r *Repository) LookupNamespace(name string) (cpi.NamespaceAccess, error) {
+	if name != "" {
+		return nil, errors.ErrNotSupported("namespace", name)
+	}
+	return r.layout.Dup()
+}
+
+func (r *Repository) Close() error {
+	if r.layout != nil {
+		return r.layout.Close()
+	}
+	return nil
+}
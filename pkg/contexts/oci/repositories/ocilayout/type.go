@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ocilayout
+
+import (
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	Type   = "OCILayout"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(Type, cpi.NewRepositoryType(Type, &RepositorySpec{}))
+	cpi.RegisterRepositoryType(TypeV1, cpi.NewRepositoryType(TypeV1, &RepositorySpec{}))
+}
+
+// RepositorySpec describes a repository interface backed by an OCI image layout.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	accessio.StandardOptions    `json:",inline"`
+
+	// FilePath is the path of the image layout
+	FilePath string `json:"filePath"`
+	// AccessMode can be set to request readonly access or creation
+	AccessMode accessobj.AccessMode `json:"accessMode,omitempty"`
+}
+
+var _ cpi.RepositorySpec = (*RepositorySpec)(nil)
+
+// NewRepositorySpec creates a new RepositorySpec.
+func NewRepositorySpec(mode accessobj.AccessMode, filePath string, opts ...accessio.Option) (*RepositorySpec, error) {
+	o, err := accessio.AccessOptions(nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if o.GetFileFormat() == nil {
+		for _, v := range SupportedFormats() {
+			if strings.HasSuffix(filePath, "."+v.String()) {
+				o.SetFileFormat(v)
+				break
+			}
+		}
+	}
+	o.Default()
+	return &RepositorySpec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		FilePath:            filePath,
+		StandardOptions:     *o.(*accessio.StandardOptions),
+		AccessMode:          mode,
+	}, nil
+}
+
+func (a *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (s *RepositorySpec) Name() string {
+	return s.FilePath
+}
+
+func (s *RepositorySpec) UniformRepositorySpec() *cpi.UniformRepositorySpec {
+	u := &cpi.UniformRepositorySpec{
+		Type: Type,
+		Info: s.FilePath,
+	}
+	return u
+}
+
+func (a *RepositorySpec) Repository(ctx cpi.Context, creds credentials.Credentials) (cpi.Repository, error) {
+	return NewRepository(ctx, a)
+}
+
+func (a *RepositorySpec) AsUniformSpec(cpi.Context) cpi.UniformRepositorySpec {
+	opts, _ := accessio.AccessOptions(nil, &a.StandardOptions)
+	p, err := vfs.Canonical(opts.GetPathFileSystem(), a.FilePath, false)
+	if err != nil {
+		return cpi.UniformRepositorySpec{Type: a.GetKind(), Info: a.FilePath}
+	}
+	return cpi.UniformRepositorySpec{Type: a.GetKind(), Info: p}
+}
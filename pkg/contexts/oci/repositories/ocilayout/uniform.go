@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ocilayout
+
+import (
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+)
+
+// The index.json of an OCI image layout is also accepted by
+// the artifact set handler, therefore the handler is only
+// registered for the explicit repository type (optionally
+// with a file format, e.g. OCILayout+tgz).
+func init() {
+	h := &repospechandler{}
+	cpi.RegisterRepositorySpecHandler(h, Type)
+	for _, f := range SupportedFormats() {
+		cpi.RegisterRepositorySpecHandler(h, Type+"+"+string(f))
+	}
+}
+
+type repospechandler struct{}
+
+func (h *repospechandler) MapReference(ctx cpi.Context, u *cpi.UniformRepositorySpec) (cpi.RepositorySpec, error) {
+	path := u.Info
+	if u.Info == "" {
+		if u.Host == "" || u.Type == "" {
+			return nil, nil
+		}
+		path = u.Host
+	}
+	fs := vfsattr.Get(ctx)
+
+	// the type is always explicitly given, so creation
+	// is possible for this type regardless of the hint.
+	hint := ""
+	if u.CreateIfMissing {
+		hint = Type
+	}
+	create, ok, err := accessobj.CheckFile(Type, hint, true, path, fs, IndexFileName)
+	if !ok || err != nil {
+		return nil, err
+	}
+	mode := accessobj.ACC_WRITABLE
+	if create {
+		mode |= accessobj.ACC_CREATE
+	}
+	var opts []accessio.Option
+	if f := accessio.FileFormatForType(u.Type); f != Type {
+		opts = append(opts, f)
+	}
+	return NewRepositorySpec(mode, path, append(opts, accessio.PathFileSystem(fs))...)
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ocireg_test
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocireg"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
+)
+
+// rotatingCredentials provides the current password of the test registry.
+type rotatingCredentials struct {
+	lock     sync.Mutex
+	password string
+	queries  int
+}
+
+func (c *rotatingCredentials) Set(password string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.password = password
+}
+
+func (c *rotatingCredentials) Get() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.password
+}
+
+func (c *rotatingCredentials) Credentials(credentials.Context, ...credentials.CredentialsSource) (credentials.Credentials, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.queries++
+	return credentials.NewCredentials(common.Properties{
+		credentials.ATTR_USERNAME: "ocm",
+		credentials.ATTR_PASSWORD: c.password,
+	}), nil
+}
+
+var _ = Describe("ocireg credentials", func() {
+	var registry *testhelper.FakeRegistry
+	var creds *rotatingCredentials
+	var ctx oci.Context
+
+	BeforeEach(func() {
+		creds = &rotatingCredentials{password: "first"}
+		registry = testhelper.NewFakeRegistry()
+		registry.Filter = func(w http.ResponseWriter, r *http.Request) bool {
+			if user, pass, ok := r.BasicAuth(); ok && user == "ocm" && pass == creds.Get() {
+				return true
+			}
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		ctx = oci.New()
+		u := Must(url.Parse(registry.URL))
+		ctx.CredentialsContext().SetCredentialsForConsumer(credentials.ConsumerIdentity{
+			identity.ID_TYPE:     identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME: u.Hostname(),
+			identity.ID_PORT:     u.Port(),
+		}, creds)
+	})
+
+	AfterEach(func() {
+		registry.Close()
+	})
+
+	It("requests renewed credentials during the usage of a namespace", func() {
+		repo := Must(ctx.RepositoryForSpec(ocireg.NewRepositorySpec(registry.URL)))
+		defer Close(repo, "repo")
+		ns := Must(repo.LookupNamespace(NAMESPACE))
+		defer Close(ns, "namespace")
+
+		DefaultManifestFill(ns)
+		queries := creds.queries
+		Expect(queries).To(BeNumerically(">", 0))
+
+		creds.Set("second")
+
+		art := Must(ns.GetArtifact(TAG))
+		defer Close(art, "artifact")
+		Expect(creds.queries).To(BeNumerically(">", queries))
+	})
+})
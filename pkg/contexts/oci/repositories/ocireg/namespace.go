@@ -7,6 +7,7 @@ package ocireg
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/containerd/containerd/errdefs"
 	"github.com/opencontainers/go-digest"
@@ -40,6 +41,8 @@ type NamespaceContainer struct {
 var (
 	_ cpi.ArtifactSetContainer = (*NamespaceContainer)(nil)
 	_ cpi.NamespaceAccess      = (*Namespace)(nil)
+	_ cpi.BlobChecker          = (*Namespace)(nil)
+	_ cpi.ReferrersLister      = (*Namespace)(nil)
 )
 
 func NewNamespace(repo *Repository, name string) (*Namespace, error) {
@@ -137,6 +140,23 @@ func (n *NamespaceContainer) GetBlobData(digest digest.Digest) (int64, cpi.DataA
 	return size, acc, err
 }
 
+// HasBlob checks the existence of a blob without fetching its content,
+// if supported by the fetcher.
+func (n *NamespaceContainer) HasBlob(digest digest.Digest) (bool, int64, error) {
+	s, ok := n.fetcher.(resolve.BlobStater)
+	if !ok {
+		return false, accessio.BLOB_UNKNOWN_SIZE, nil
+	}
+	size, err := s.Stat(dummyContext, digest)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, accessio.BLOB_UNKNOWN_SIZE, nil
+		}
+		return false, accessio.BLOB_UNKNOWN_SIZE, err
+	}
+	return true, size, nil
+}
+
 func (n *NamespaceContainer) AddBlob(blob cpi.BlobAccess) error {
 	log := n.repo.ctx.Logger()
 	log.Debug("adding blob", "digest", blob.Digest())
@@ -230,7 +250,54 @@ func (n *NamespaceContainer) AddArtifact(artifact cpi.Artifact, tags ...string)
 		}
 	}
 
-	return blob, err
+	if subject := cpi.GetSubject(artifact); subject != nil {
+		// the artifact has already been pushed, so failing
+		// referrer registrations do not fail the operation.
+		if err := n.addReferrer(artifact, blob, subject.Digest); err != nil {
+			n.repo.ctx.Logger().Warn("cannot register referrer", "digest", blob.Digest().String(), "subject", subject.Digest.String(), "error", err.Error())
+		}
+	}
+	return blob, nil
+}
+
+func (n *NamespaceContainer) addReferrer(artifact cpi.Artifact, blob cpi.BlobAccess, subject digest.Digest) error {
+	_, err := n.fetchReferrers(subject)
+	if errdefs.IsNotImplemented(err) {
+		// registry without referrers API: use the referrers tag schema
+		return cpi.AddReferrerByTag(n, artifact, blob)
+	}
+	return err
+}
+
+// ListReferrers lists the referrers of a manifest using the referrers API.
+// For registries without support for this API the referrers tag schema
+// is used.
+func (n *NamespaceContainer) ListReferrers(subject digest.Digest, artifactType string) ([]artdesc.Descriptor, error) {
+	idx, err := n.fetchReferrers(subject)
+	if err != nil {
+		if errdefs.IsNotImplemented(err) {
+			return cpi.ListReferrersByTag(n, subject, artifactType)
+		}
+		return nil, err
+	}
+	return cpi.FilterReferrers(idx.Manifests, artifactType), nil
+}
+
+func (n *NamespaceContainer) fetchReferrers(subject digest.Digest) (*artdesc.Index, error) {
+	f, ok := n.fetcher.(resolve.ReferrersFetcher)
+	if !ok {
+		return nil, errdefs.ErrNotImplemented
+	}
+	r, err := f.FetchReferrers(dummyContext, subject)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return artdesc.DecodeIndex(data)
 }
 
 func (n *NamespaceContainer) AddTags(digest digest.Digest, tags ...string) error {
@@ -300,6 +367,14 @@ func (n *Namespace) AddTags(digest digest.Digest, tags ...string) error {
 	return n.access.AddTags(digest, tags...)
 }
 
+func (n *Namespace) ListReferrers(subject digest.Digest, artifactType string) ([]artdesc.Descriptor, error) {
+	return n.access.ListReferrers(subject, artifactType)
+}
+
+func (n *Namespace) HasBlob(digest digest.Digest) (bool, int64, error) {
+	return n.access.HasBlob(digest)
+}
+
 func (n *Namespace) AddBlob(blob cpi.BlobAccess) error {
 	return n.access.AddBlob(blob)
 }
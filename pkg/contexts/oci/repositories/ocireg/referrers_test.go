@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ocireg_test
+
+import (
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocireg"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	NAMESPACE      = "mandelsoft/test"
+	SIGNATURE_TYPE = "application/vnd.test.signature"
+)
+
+func attach(n cpi.NamespaceAccess, subject cpi.ArtifactAccess, typ, data string) digest.Digest {
+	art := Must(n.NewArtifact())
+	defer Close(art)
+	Expect(art.AddLayer(accessio.BlobAccessForString(mime.MIME_OCTET, data), nil)).To(Equal(0))
+	config := accessio.BlobAccessForString(typ, "{}")
+	MustBeSuccessful(n.AddBlob(config))
+	art.GetDescriptor().Manifest().Config = *artdesc.DefaultBlobDescriptor(config)
+	blob := Must(oci.AttachArtifact(n, subject, art))
+	return blob.Digest()
+}
+
+var _ = Describe("ocireg referrers", func() {
+	var registry *testhelper.FakeRegistry
+	var repo cpi.Repository
+	var ns cpi.NamespaceAccess
+	var subject cpi.ArtifactAccess
+
+	dig := digest.Digest("sha256:" + DIGEST_MANIFEST)
+
+	BeforeEach(func() {
+		registry = testhelper.NewFakeRegistry()
+		repo = Must(oci.DefaultContext().RepositoryForSpec(ocireg.NewRepositorySpec(registry.URL)))
+		ns = Must(repo.LookupNamespace(NAMESPACE))
+		DefaultManifestFill(ns)
+		subject = Must(ns.GetArtifact(TAG))
+	})
+
+	AfterEach(func() {
+		Close(subject)
+		Close(ns)
+		Close(repo)
+		registry.Close()
+	})
+
+	It("uses the referrers API", func() {
+		registry.Referrers = true
+
+		sig := attach(ns, subject, SIGNATURE_TYPE, "signature")
+
+		list := Must(oci.ListReferrers(ns, dig, ""))
+		Expect(len(list)).To(Equal(1))
+		Expect(list[0].Digest).To(Equal(sig))
+		Expect(list[0].ArtifactType).To(Equal(SIGNATURE_TYPE))
+		Expect(registry.Tags(NAMESPACE)).To(Equal([]string{TAG}))
+	})
+
+	It("uses the referrers tag schema", func() {
+		sig := attach(ns, subject, SIGNATURE_TYPE, "signature")
+
+		Expect(registry.Tags(NAMESPACE)).To(ConsistOf(TAG, cpi.ReferrersTag(dig)))
+		list := Must(oci.ListReferrers(ns, dig, SIGNATURE_TYPE))
+		Expect(len(list)).To(Equal(1))
+		Expect(list[0].Digest).To(Equal(sig))
+
+		ref := Must(ns.GetArtifact(sig.String()))
+		defer Close(ref)
+		m := Must(ref.Manifest())
+		Expect(m.Subject.Digest).To(Equal(dig))
+	})
+
+	It("keeps successful pushes if the referrers tag cannot be updated", func() {
+		registry.Filter = func(w http.ResponseWriter, r *http.Request) bool {
+			if r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/manifests/"+cpi.ReferrersTag(dig)) {
+				w.WriteHeader(http.StatusInternalServerError)
+				return false
+			}
+			return true
+		}
+
+		sig := attach(ns, subject, SIGNATURE_TYPE, "signature")
+
+		Expect(registry.Tags(NAMESPACE)).To(Equal([]string{TAG}))
+		ref := Must(ns.GetArtifact(sig.String()))
+		defer Close(ref)
+		Expect(Must(ref.Manifest()).Subject.Digest).To(Equal(dig))
+	})
+
+	It("keeps successful pushes if the referrers API fails", func() {
+		registry.Filter = func(w http.ResponseWriter, r *http.Request) bool {
+			if strings.Contains(r.URL.Path, "/referrers/") {
+				w.WriteHeader(http.StatusInternalServerError)
+				return false
+			}
+			return true
+		}
+
+		sig := attach(ns, subject, SIGNATURE_TYPE, "signature")
+
+		ref := Must(ns.GetArtifact(sig.String()))
+		defer Close(ref)
+		Expect(Must(ref.Manifest()).Subject.Digest).To(Equal(dig))
+	})
+})
@@ -82,27 +82,26 @@ func (r *Repository) getCreds(comp string) (credentials.Credentials, error) {
 }
 
 func (r *Repository) getResolver(comp string) (resolve.Resolver, error) {
-	creds, err := r.getCreds(comp)
-	if err != nil {
-		if !errors.IsErrUnknownKind(err, credentials.KIND_CONSUMER) {
-			return nil, err
-		}
-	}
 	logger := r.logger.BoundLogger().WithValues(ocmlog.ATTR_NAMESPACE, comp)
-	if creds == nil {
-		logger.Trace("no credentials")
-	}
 
 	opts := docker.ResolverOptions{
 		Hosts: docker.ConvertHosts(config.ConfigureHosts(context.Background(), config.HostOptions{
+			// the credentials are requested for every authorization, because
+			// short-lived credentials may expire during the usage of the resolver.
 			Credentials: func(host string) (string, string, error) {
+				creds, err := r.getCreds(comp)
+				if err != nil {
+					if !errors.IsErrUnknownKind(err, credentials.KIND_CONSUMER) {
+						return "", "", err
+					}
+				}
 				if creds != nil {
 					p := creds.GetProperty(credentials.ATTR_IDENTITY_TOKEN)
 					if p == "" {
 						p = creds.GetProperty(credentials.ATTR_PASSWORD)
 					}
 					pw := ""
-					if pw != "" {
+					if p != "" {
 						pw = "***"
 					}
 					logger.Trace("query credentials", ocmlog.ATTR_USER, creds.GetProperty(credentials.ATTR_USERNAME), "pass", pw)
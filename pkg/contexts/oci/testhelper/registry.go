@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package testhelper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// FakeRegistry is a minimal in-memory OCI distribution registry
+// to be used by tests. It supports pushing and pulling of
+// blobs and manifests, tag listing and, optionally, the
+// referrers API.
+type FakeRegistry struct {
+	*httptest.Server
+
+	// Referrers enables the referrers API.
+	// If disabled, the registry responds with 404 for
+	// referrers requests, like registries not supporting it.
+	Referrers bool
+	// Filter is called for every request before it is processed.
+	// If it returns false, the request is considered to be handled
+	// by the filter.
+	Filter func(w http.ResponseWriter, r *http.Request) bool
+
+	lock      sync.Mutex
+	uploads   int
+	blobs     map[string]map[digest.Digest][]byte
+	manifests map[string]map[string]*registryManifest
+}
+
+type registryManifest struct {
+	mediaType string
+	digest    digest.Digest
+	data      []byte
+}
+
+// NewFakeRegistry starts a new test registry. It must be closed
+// after usage.
+func NewFakeRegistry() *FakeRegistry {
+	r := &FakeRegistry{
+		blobs:     map[string]map[digest.Digest][]byte{},
+		manifests: map[string]map[string]*registryManifest{},
+	}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serve))
+	return r
+}
+
+// Tags provides the tags of a namespace.
+func (r *FakeRegistry) Tags(ns string) []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.tags(ns)
+}
+
+func (r *FakeRegistry) tags(ns string) []string {
+	tags := []string{}
+	for ref := range r.manifests[ns] {
+		if _, err := digest.Parse(ref); err != nil {
+			tags = append(tags, ref)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func (r *FakeRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if r.Filter != nil && !r.Filter(w, req) {
+		return
+	}
+	if !strings.HasPrefix(req.URL.Path, "/v2/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	if path == "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// the content may be streamed from this registry,
+	// so it must be read before locking the registry.
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if strings.HasSuffix(path, "/tags/list") {
+		ns := strings.TrimSuffix(path, "/tags/list")
+		writeJSON(w, "application/json", map[string]interface{}{"name": ns, "tags": r.tags(ns)})
+		return
+	}
+	for _, e := range []struct {
+		sep     string
+		handler func(w http.ResponseWriter, req *http.Request, ns, ref string)
+	}{
+		{"/manifests/", r.serveManifest},
+		{"/blobs/uploads/", r.serveUpload},
+		{"/blobs/", r.serveBlob},
+		{"/referrers/", r.serveReferrers},
+	} {
+		if i := strings.LastIndex(path, e.sep); i > 0 {
+			e.handler(w, req, path[:i], path[i+len(e.sep):])
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func (r *FakeRegistry) serveManifest(w http.ResponseWriter, req *http.Request, ns, ref string) {
+	switch req.Method {
+	case http.MethodHead, http.MethodGet:
+		m := r.manifests[ns][ref]
+		if m == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", m.mediaType)
+		w.Header().Set("Docker-Content-Digest", m.digest.String())
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(m.data)))
+		w.WriteHeader(http.StatusOK)
+		if req.Method == http.MethodGet {
+			w.Write(m.data)
+		}
+	case http.MethodPut:
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m := &registryManifest{
+			mediaType: req.Header.Get("Content-Type"),
+			digest:    digest.FromBytes(data),
+			data:      data,
+		}
+		if r.manifests[ns] == nil {
+			r.manifests[ns] = map[string]*registryManifest{}
+		}
+		r.manifests[ns][ref] = m
+		r.manifests[ns][m.digest.String()] = m
+		w.Header().Set("Docker-Content-Digest", m.digest.String())
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/manifests/%s", ns, m.digest))
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *FakeRegistry) serveUpload(w http.ResponseWriter, req *http.Request, ns, id string) {
+	switch req.Method {
+	case http.MethodPost:
+		r.uploads++
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%d", ns, r.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPut:
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		dig := digest.FromBytes(data)
+		if req.URL.Query().Get("digest") != dig.String() {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.blobs[ns] == nil {
+			r.blobs[ns] = map[digest.Digest][]byte{}
+		}
+		r.blobs[ns][dig] = data
+		w.Header().Set("Docker-Content-Digest", dig.String())
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/%s", ns, dig))
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *FakeRegistry) serveBlob(w http.ResponseWriter, req *http.Request, ns, ref string) {
+	if req.Method != http.MethodHead && req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	data, ok := r.blobs[ns][digest.Digest(ref)]
+	if !ok {
+		// manifests are also accessible as blobs
+		m := r.manifests[ns][ref]
+		if m == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		data = m.data
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Docker-Content-Digest", ref)
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(data)))
+	w.WriteHeader(http.StatusOK)
+	if req.Method == http.MethodGet {
+		w.Write(data)
+	}
+}
+
+func (r *FakeRegistry) serveReferrers(w http.ResponseWriter, req *http.Request, ns, subject string) {
+	if !r.Referrers || req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	idx := ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{},
+	}
+	idx.SchemaVersion = 2
+	var refs []string
+	for ref := range r.manifests[ns] {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		m := r.manifests[ns][ref]
+		if ref != m.digest.String() {
+			continue
+		}
+		var manifest ocispec.Manifest
+		if json.Unmarshal(m.data, &manifest) != nil || manifest.Subject == nil || manifest.Subject.Digest.String() != subject {
+			continue
+		}
+		idx.Manifests = append(idx.Manifests, ocispec.Descriptor{
+			MediaType:    m.mediaType,
+			Digest:       m.digest,
+			Size:         int64(len(m.data)),
+			ArtifactType: manifest.Config.MediaType,
+			Annotations:  manifest.Annotations,
+		})
+	}
+	writeJSON(w, ocispec.MediaTypeImageIndex, idx)
+}
+
+func writeJSON(w http.ResponseWriter, mime string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", mime)
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -5,6 +5,8 @@
 package transfer
 
 import (
+	"github.com/opencontainers/go-digest"
+
 	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
 	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/logging"
@@ -73,3 +75,62 @@ func TransferManifest(art cpi.ManifestAccess, set cpi.ArtifactSink, tags ...stri
 	}
 	return err
 }
+
+// TransferReferrers transfers the artifacts referring to a subject
+// manifest (referrers), including their own referrers, from an
+// artifact source to an artifact sink.
+func TransferReferrers(src cpi.ArtifactSource, subject digest.Digest, set cpi.ArtifactSink) error {
+	list, err := cpi.ListReferrers(src, subject, "")
+	if err != nil {
+		return errors.Wrapf(err, "listing referrers for %s", subject)
+	}
+	for _, d := range list {
+		logging.Logger().Debug("transfer referrer", "subject", subject, "digest", d.Digest, "artifactType", d.ArtifactType)
+		art, err := src.GetArtifact(d.Digest.String())
+		if err != nil {
+			return errors.Wrapf(err, "getting referrer %s", d.Digest)
+		}
+		err = TransferArtifact(art, set)
+		art.Close()
+		if err != nil {
+			return errors.Wrapf(err, "transferring referrer %s", d.Digest)
+		}
+		err = TransferReferrers(src, d.Digest, set)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TransferSideArtifacts transfers the side artifacts associated with a
+// subject manifest by tags (see cpi.RegisterSideArtifactTagSuffix)
+// from an artifact source to an artifact sink keeping their tags.
+func TransferSideArtifacts(src cpi.ArtifactSource, subject digest.Digest, set cpi.ArtifactSink) error {
+	for _, tag := range cpi.SideArtifactTags(subject) {
+		art, err := src.GetArtifact(tag)
+		if err != nil {
+			if errors.IsErrNotFound(err) || errors.IsErrUnknown(err) {
+				continue
+			}
+			return errors.Wrapf(err, "getting side artifact %s", tag)
+		}
+		logging.Logger().Debug("transfer side artifact", "subject", subject, "tag", tag)
+		err = TransferArtifact(art, set, tag)
+		art.Close()
+		if err != nil {
+			return errors.Wrapf(err, "transferring side artifact %s", tag)
+		}
+	}
+	return nil
+}
+
+// TransferAttachedArtifacts transfers all artifacts attached to a subject
+// manifest, the referrers and the side artifacts associated by tags.
+func TransferAttachedArtifacts(src cpi.ArtifactSource, subject digest.Digest, set cpi.ArtifactSink) error {
+	err := TransferReferrers(src, subject, set)
+	if err != nil {
+		return err
+	}
+	return TransferSideArtifacts(src, subject, set)
+}
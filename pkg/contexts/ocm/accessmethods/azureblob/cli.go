@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.AccountOption,
+		options.ContainerOption,
+		options.ReferenceOption,
+		options.EndpointOption,
+		options.MediatypeOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.AccountOption, config, "account")
+	flagsets.AddFieldByOptionP(opts, options.ContainerOption, config, "container")
+	flagsets.AddFieldByOptionP(opts, options.ReferenceOption, config, "blobName")
+	flagsets.AddFieldByOptionP(opts, options.EndpointOption, config, "endpoint")
+	flagsets.AddFieldByOptionP(opts, options.MediatypeOption, config, "mediaType")
+	return nil
+}
+
+var usage = `
+This method implements the access of a blob stored in a container of an
+Azure Blob Storage account. It uses the Blob service REST API, so it can also
+be used with compatible emulators like Azurite.
+
+Credentials are requested for the consumer type <code>` + identity.CONSUMER_TYPE + `</code>.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>account</code>** *string*
+
+  The name of the storage account.
+
+- **<code>container</code>** *string*
+
+  The name of the blob container.
+
+- **<code>blobName</code>** *string*
+
+  The name of the blob in the container.
+
+- **<code>endpoint</code>** (optional) *string*
+
+  The blob service endpoint. By default, it is
+  <code>https://&lt;account>.blob.core.windows.net</code>. For path-style
+  endpoints (like Azurite) the URL includes the account name, for example
+  <code>http://127.0.0.1:10000/devstoreaccount1</code>.
+
+- **<code>mediaType</code>** (optional) *string*
+
+  The media type of the content.
+`
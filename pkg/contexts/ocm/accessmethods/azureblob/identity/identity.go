@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader/azureblob"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+)
+
+// CONSUMER_TYPE is the Azure Blob Storage type.
+const CONSUMER_TYPE = "AzureBlobStorage"
+
+// ID_TYPE is the type field of a consumer identity.
+const ID_TYPE = cpi.ID_TYPE
+
+// ID_HOSTNAME is the hostname of the blob service endpoint.
+const ID_HOSTNAME = hostpath.ID_HOSTNAME
+
+// ID_PORT is the port number of the blob service endpoint.
+const ID_PORT = hostpath.ID_PORT
+
+// ID_PATHPREFIX is the path of a blob container.
+const ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+
+// ID_SCHEME is the URL scheme.
+const ID_SCHEME = hostpath.ID_SCHEME
+
+func init() {
+	cpi.RegisterStandardIdentityMatcher(CONSUMER_TYPE, IdentityMatcher, `Azure Blob Storage credential matcher
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type. The hostname is the one of the
+blob service endpoint and the path prefix starts with the container name
+(preceded by the account name for path-style endpoints like Azurite).
+
+The following credential attributes are used to authenticate requests:
+- <code>`+cpi.ATTR_AZURE_ACCOUNT_KEY+`</code>: the storage account key (Shared Key authorization)
+- <code>`+cpi.ATTR_AZURE_SAS_TOKEN+`</code>: a shared access signature
+- <code>`+cpi.ATTR_TOKEN+`</code>: an OAuth bearer token`)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+// GetConsumerId provides the consumer identity for a blob container.
+func GetConsumerId(endpoint, account, container string) (cpi.ConsumerIdentity, error) {
+	loc := azureblob.Location{Endpoint: endpoint, Account: account}
+	u, err := url.Parse(loc.GetEndpoint())
+	if err != nil {
+		return nil, err
+	}
+	id := cpi.ConsumerIdentity{
+		ID_TYPE:     CONSUMER_TYPE,
+		ID_HOSTNAME: u.Hostname(),
+	}
+	if u.Port() != "" {
+		id[ID_PORT] = u.Port()
+	}
+	if u.Scheme != "" {
+		id[ID_SCHEME] = u.Scheme
+	}
+	if p := strings.Trim(path.Join(u.Path, container), "/"); p != "" {
+		id[ID_PATHPREFIX] = p
+	}
+	return id, nil
+}
+
+// GetCredentials provides the credentials configured for a blob container.
+func GetCredentials(ctx cpi.ContextProvider, endpoint, account, container string) (*azureblob.Credentials, error) {
+	id, err := GetConsumerId(endpoint, account, container)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, IdentityMatcher)
+	if err != nil || creds == nil {
+		return nil, err
+	}
+	return &azureblob.Credentials{
+		AccountKey: creds.GetProperty(cpi.ATTR_AZURE_ACCOUNT_KEY),
+		SASToken:   creds.GetProperty(cpi.ATTR_AZURE_SAS_TOKEN),
+		Token:      creds.GetProperty(cpi.ATTR_TOKEN),
+	}, nil
+}
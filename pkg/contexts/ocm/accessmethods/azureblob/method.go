@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader"
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader/azureblob"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the access type of a blob in an Azure Blob Storage container.
+const (
+	Type   = "azureBlob"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(Type, &AccessSpec{}, cpi.WithDescription(usage)))
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(TypeV1, &AccessSpec{}, cpi.WithFormatSpec(formatV1), cpi.WithConfigHandler(ConfigHandler())))
+}
+
+// AccessSpec describes the access for a blob in an Azure Blob Storage container.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// Account is the name of the storage account.
+	Account string `json:"account"`
+	// Container is the name of the blob container.
+	Container string `json:"container"`
+	// BlobName is the name of the blob in the container.
+	BlobName string `json:"blobName"`
+	// Endpoint is the blob service endpoint. It defaults to
+	// https://<account>.blob.core.windows.net.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// MediaType defines the mime type of the object to download.
+	// +optional
+	MediaType  string `json:"mediaType,omitempty"`
+	downloader downloader.Downloader
+}
+
+var _ cpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new Azure Blob Storage access spec version v1.
+func New(account, container, blob, endpoint, mediaType string, downloader downloader.Downloader) *AccessSpec {
+	return &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		Account:             account,
+		Container:           container,
+		BlobName:            blob,
+		Endpoint:            endpoint,
+		MediaType:           mediaType,
+		downloader:          downloader,
+	}
+}
+
+func (a *AccessSpec) Describe(ctx cpi.Context) string {
+	return fmt.Sprintf("Azure blob %s in container %s of account %s", a.BlobName, a.Container, a.Account)
+}
+
+func (_ *AccessSpec) IsLocal(cpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(ctx cpi.Context) cpi.AccessSpec {
+	return a
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c cpi.ComponentVersionAccess) (cpi.AccessMethod, error) {
+	return newMethod(c, a)
+}
+
+// Location provides the location of the described blob.
+func (a *AccessSpec) Location() azureblob.Location {
+	return azureblob.Location{
+		Endpoint:  a.Endpoint,
+		Account:   a.Account,
+		Container: a.Container,
+		Blob:      a.BlobName,
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type accessMethod struct {
+	accessio.BlobAccess
+
+	comp cpi.ComponentVersionAccess
+	spec *AccessSpec
+}
+
+var _ cpi.AccessMethod = (*accessMethod)(nil)
+
+func newMethod(c cpi.ComponentVersionAccess, a *AccessSpec) (*accessMethod, error) {
+	d := a.downloader
+	if d == nil {
+		creds, err := identity.GetCredentials(c.GetContext(), a.Endpoint, a.Account, a.Container)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get creds: %w", err)
+		}
+		d = azureblob.NewDownloader(a.Location(), creds)
+	}
+	w := accessio.NewWriteAtWriter(d.Download)
+	// don't change the spec, leave it empty.
+	mediaType := a.MediaType
+	if mediaType == "" {
+		mediaType = mime.MIME_OCTET
+	}
+	return &accessMethod{
+		spec:       a,
+		comp:       c,
+		BlobAccess: accessobj.CachedBlobAccessForWriter(c.GetContext(), mediaType, w),
+	}, nil
+}
+
+func (m *accessMethod) GetKind() string {
+	return Type
+}
+
+func (m *accessMethod) AccessSpec() cpi.AccessSpec {
+	return m.spec
+}
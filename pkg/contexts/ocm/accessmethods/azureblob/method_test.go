@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	CONTENT = "some test content"
+	SAS     = "sv=2021-08-06&sig=secret"
+)
+
+var _ = Describe("Method", func() {
+	var ctx ocm.Context
+	var cv ocm.ComponentVersionAccess
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		cv = &cpi.DummyComponentVersionAccess{ctx}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("sig") != "secret" {
+				http.Error(w, "access denied", http.StatusForbidden)
+				return
+			}
+			if r.URL.Path == "/acme/artifacts/some/blob" {
+				w.Write([]byte(CONTENT))
+				return
+			}
+			http.NotFound(w, r)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("accesses blob with credentials", func() {
+		acc := azureblob.New("acme", "artifacts", "some/blob", server.URL+"/acme", mime.MIME_TEXT, nil)
+
+		m := Must(acc.AccessMethod(cv))
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("403 Forbidden")))
+		m.Close()
+
+		id := Must(identity.GetConsumerId(server.URL+"/acme", "acme", "artifacts"))
+		Expect(id[identity.ID_PATHPREFIX]).To(Equal("acme/artifacts"))
+		ctx.CredentialsContext().SetCredentialsForConsumer(id, credentials.DirectCredentials{
+			credentials.ATTR_AZURE_SAS_TOKEN: "?" + SAS,
+		})
+		m = Must(acc.AccessMethod(cv))
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("fails for missing blob", func() {
+		ctx.CredentialsContext().SetCredentialsForConsumer(credentials.ConsumerIdentity{identity.ID_TYPE: identity.CONSUMER_TYPE}, credentials.DirectCredentials{
+			credentials.ATTR_AZURE_SAS_TOKEN: SAS,
+		})
+		acc := azureblob.New("acme", "artifacts", "other", server.URL+"/acme", "", nil)
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(mime.MIME_OCTET))
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+	})
+
+	It("provides consumer id for default endpoint", func() {
+		id := Must(identity.GetConsumerId("", "acme", "artifacts"))
+		Expect(id).To(Equal(credentials.ConsumerIdentity{
+			identity.ID_TYPE:       identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME:   "acme.blob.core.windows.net",
+			identity.ID_SCHEME:     "https",
+			identity.ID_PATHPREFIX: "artifacts",
+		}))
+	})
+
+	It("decodes spec", func() {
+		data := `{"type":"azureBlob/v1","account":"acme","container":"artifacts","blobName":"some/blob"}`
+		spec := Must(ocm.DefaultContext().AccessSpecForConfig([]byte(data), runtime.DefaultJSONEncoding))
+		Expect(spec).To(BeAssignableToTypeOf(&azureblob.AccessSpec{}))
+		Expect(spec.(*azureblob.AccessSpec).Location().URL()).To(Equal("https://acme.blob.core.windows.net/artifacts/some/blob"))
+	})
+})
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/gcs/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.BucketOption,
+		options.ReferenceOption,
+		options.VersionOption,
+		options.EndpointOption,
+		options.MediatypeOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.BucketOption, config, "bucket")
+	flagsets.AddFieldByOptionP(opts, options.ReferenceOption, config, "object")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "generation")
+	flagsets.AddFieldByOptionP(opts, options.EndpointOption, config, "endpoint")
+	flagsets.AddFieldByOptionP(opts, options.MediatypeOption, config, "mediaType")
+	return nil
+}
+
+var usage = `
+This method implements the access of an object stored in a Google Cloud
+Storage bucket. It uses the JSON API, so it can also be used with compatible
+emulators like fake-gcs-server.
+
+Credentials are requested for the consumer type <code>` + identity.CONSUMER_TYPE + `</code>.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>bucket</code>** *string*
+
+  The name of the bucket containing the object.
+
+- **<code>object</code>** *string*
+
+  The name of the object.
+
+- **<code>generation</code>** (optional) *string*
+
+  The generation of the object. If given, exactly this generation is
+  accessed, otherwise the latest one.
+
+- **<code>endpoint</code>** (optional) *string*
+
+  The storage API endpoint. By default, it is
+  <code>https://storage.googleapis.com</code>.
+
+- **<code>mediaType</code>** (optional) *string*
+
+  The media type of the content.
+`
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"net/url"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader/gcs"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+)
+
+// CONSUMER_TYPE is the Google Cloud Storage type.
+const CONSUMER_TYPE = "GoogleCloudStorage"
+
+// ID_TYPE is the type field of a consumer identity.
+const ID_TYPE = cpi.ID_TYPE
+
+// ID_HOSTNAME is the hostname of the storage API endpoint.
+const ID_HOSTNAME = hostpath.ID_HOSTNAME
+
+// ID_PORT is the port number of the storage API endpoint.
+const ID_PORT = hostpath.ID_PORT
+
+// ID_PATHPREFIX is the name of a bucket.
+const ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+
+// ID_SCHEME is the URL scheme.
+const ID_SCHEME = hostpath.ID_SCHEME
+
+func init() {
+	cpi.RegisterStandardIdentityMatcher(CONSUMER_TYPE, IdentityMatcher, `Google Cloud Storage credential matcher
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type. The hostname is the one of the
+storage API endpoint and the path prefix is the bucket name.
+
+The following credential attributes are used to authenticate requests:
+- <code>`+cpi.ATTR_TOKEN+`</code>: an OAuth2 access token`)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+// GetConsumerId provides the consumer identity for a bucket.
+func GetConsumerId(endpoint, bucket string) (cpi.ConsumerIdentity, error) {
+	loc := gcs.Location{Endpoint: endpoint}
+	u, err := url.Parse(loc.GetEndpoint())
+	if err != nil {
+		return nil, err
+	}
+	id := cpi.ConsumerIdentity{
+		ID_TYPE:     CONSUMER_TYPE,
+		ID_HOSTNAME: u.Hostname(),
+	}
+	if u.Port() != "" {
+		id[ID_PORT] = u.Port()
+	}
+	if u.Scheme != "" {
+		id[ID_SCHEME] = u.Scheme
+	}
+	if bucket != "" {
+		id[ID_PATHPREFIX] = bucket
+	}
+	return id, nil
+}
+
+// GetCredentials provides the credentials configured for a bucket.
+func GetCredentials(ctx cpi.ContextProvider, endpoint, bucket string) (*gcs.Credentials, error) {
+	id, err := GetConsumerId(endpoint, bucket)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, IdentityMatcher)
+	if err != nil || creds == nil {
+		return nil, err
+	}
+	return &gcs.Credentials{
+		Token: creds.GetProperty(cpi.ATTR_TOKEN),
+	}, nil
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader"
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader/gcs"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/gcs/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the access type of an object in a Google Cloud Storage bucket.
+const (
+	Type   = "gcs"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(Type, &AccessSpec{}, cpi.WithDescription(usage)))
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(TypeV1, &AccessSpec{}, cpi.WithFormatSpec(formatV1), cpi.WithConfigHandler(ConfigHandler())))
+}
+
+// AccessSpec describes the access for an object in a Google Cloud Storage bucket.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// Object is the name of the object in the bucket.
+	Object string `json:"object"`
+	// Generation pins a dedicated generation of the object.
+	// +optional
+	Generation string `json:"generation,omitempty"`
+	// Endpoint is the storage API endpoint. It defaults to
+	// https://storage.googleapis.com.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// MediaType defines the mime type of the object to download.
+	// +optional
+	MediaType  string `json:"mediaType,omitempty"`
+	downloader downloader.Downloader
+}
+
+var _ cpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new Google Cloud Storage access spec version v1.
+func New(bucket, object, generation, endpoint, mediaType string, downloader downloader.Downloader) *AccessSpec {
+	return &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		Bucket:              bucket,
+		Object:              object,
+		Generation:          generation,
+		Endpoint:            endpoint,
+		MediaType:           mediaType,
+		downloader:          downloader,
+	}
+}
+
+func (a *AccessSpec) Describe(ctx cpi.Context) string {
+	return fmt.Sprintf("GCS object %s in bucket %s", a.Object, a.Bucket)
+}
+
+func (_ *AccessSpec) IsLocal(cpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(ctx cpi.Context) cpi.AccessSpec {
+	return a
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c cpi.ComponentVersionAccess) (cpi.AccessMethod, error) {
+	return newMethod(c, a)
+}
+
+// Location provides the location of the described object.
+func (a *AccessSpec) Location() gcs.Location {
+	return gcs.Location{
+		Endpoint:   a.Endpoint,
+		Bucket:     a.Bucket,
+		Object:     a.Object,
+		Generation: a.Generation,
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type accessMethod struct {
+	accessio.BlobAccess
+
+	comp cpi.ComponentVersionAccess
+	spec *AccessSpec
+}
+
+var _ cpi.AccessMethod = (*accessMethod)(nil)
+
+func newMethod(c cpi.ComponentVersionAccess, a *AccessSpec) (*accessMethod, error) {
+	d := a.downloader
+	if d == nil {
+		creds, err := identity.GetCredentials(c.GetContext(), a.Endpoint, a.Bucket)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get creds: %w", err)
+		}
+		d = gcs.NewDownloader(a.Location(), creds)
+	}
+	w := accessio.NewWriteAtWriter(d.Download)
+	// don't change the spec, leave it empty.
+	mediaType := a.MediaType
+	if mediaType == "" {
+		mediaType = mime.MIME_OCTET
+	}
+	return &accessMethod{
+		spec:       a,
+		comp:       c,
+		BlobAccess: accessobj.CachedBlobAccessForWriter(c.GetContext(), mediaType, w),
+	}, nil
+}
+
+func (m *accessMethod) GetKind() string {
+	return Type
+}
+
+func (m *accessMethod) AccessSpec() cpi.AccessSpec {
+	return m.spec
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/gcs"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/gcs/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const CONTENT = "some test content"
+
+var _ = Describe("Method", func() {
+	var ctx ocm.Context
+	var cv ocm.ComponentVersionAccess
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		cv = &cpi.DummyComponentVersionAccess{ctx}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				http.Error(w, "access denied", http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Path == "/storage/v1/b/ocm/o/some/object" && r.URL.Query().Get("alt") == "media" {
+				if g := r.URL.Query().Get("generation"); g != "" && g != "42" {
+					http.NotFound(w, r)
+					return
+				}
+				w.Write([]byte(CONTENT))
+				return
+			}
+			http.NotFound(w, r)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("accesses object with credentials", func() {
+		acc := gcs.New("ocm", "some/object", "", server.URL, mime.MIME_TEXT, nil)
+
+		m := Must(acc.AccessMethod(cv))
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+		m.Close()
+
+		id := Must(identity.GetConsumerId(server.URL, "ocm"))
+		ctx.CredentialsContext().SetCredentialsForConsumer(id, credentials.DirectCredentials{
+			credentials.ATTR_TOKEN: "token",
+		})
+		m = Must(acc.AccessMethod(cv))
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("accesses dedicated generation", func() {
+		ctx.CredentialsContext().SetCredentialsForConsumer(credentials.ConsumerIdentity{identity.ID_TYPE: identity.CONSUMER_TYPE}, credentials.DirectCredentials{
+			credentials.ATTR_TOKEN: "token",
+		})
+		m := Must(gcs.New("ocm", "some/object", "42", server.URL, "", nil).AccessMethod(cv))
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+
+		m2 := Must(gcs.New("ocm", "some/object", "41", server.URL, "", nil).AccessMethod(cv))
+		defer Close(m2)
+		_, err := m2.Get()
+		Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+	})
+
+	It("decodes spec", func() {
+		data := `{"type":"gcs/v1","bucket":"ocm","object":"some/object","generation":"42"}`
+		spec := Must(ocm.DefaultContext().AccessSpecForConfig([]byte(data), runtime.DefaultJSONEncoding))
+		Expect(spec).To(BeAssignableToTypeOf(&gcs.AccessSpec{}))
+		Expect(spec.(*gcs.AccessSpec).Location().URL()).To(Equal("https://storage.googleapis.com/storage/v1/b/ocm/o/some%2Fobject"))
+	})
+})
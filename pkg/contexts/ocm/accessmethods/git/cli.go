@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/git/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.RepositoryOption,
+		options.RefOption,
+		options.CommitOption,
+		options.PathSpecOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.RepositoryOption, config, "repoUrl")
+	flagsets.AddFieldByOptionP(opts, options.RefOption, config, "ref")
+	flagsets.AddFieldByOptionP(opts, options.CommitOption, config, "commit")
+	flagsets.AddFieldByOptionP(opts, options.PathSpecOption, config, "pathSpec")
+	return nil
+}
+
+var usage = `
+This method implements the access of the file tree of a commit
+of an arbitrary git repository. The repository is accessed with a
+git client library, no local git installation is required.
+The tree is provided as tar archive with media type
+<code>application/x-tar</code>.
+
+Credentials are taken from the credentials context for the consumer
+type <code>` + identity.CONSUMER_TYPE + `</code> using the hostname, port,
+scheme and path of the repository URL. SSH private keys
+(attribute <code>privateKey</code>), tokens (attribute <code>token</code>)
+and username/password pairs are supported.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>repoUrl</code>**  *string*
+
+  Repository URL. Besides HTTP(S), SSH and file URLs the
+  scp-like syntax <code>user@host:path</code> is supported.
+
+- **<code>ref</code>** (optional) *string*
+
+  The branch or tag the commit is taken from. Short names are
+  looked up as branch and as tag, fully qualified names
+  (<code>refs/...</code>) are used as they are. It is only used
+  to look up the commit for servers not supporting the fetch
+  of plain commits.
+
+- **<code>commit</code>** *string*
+
+  The sha/id of the git commit. Only this commit is fetched,
+  without its history, to provide stable content for the
+  access specification.
+
+- **<code>pathSpec</code>** (optional) *string*
+
+  A path in the repository. If given, only the denoted file or the
+  content of the denoted directory is archived.
+`
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+)
+
+// CONSUMER_TYPE is the git repository type.
+const CONSUMER_TYPE = "Git"
+
+// ID_TYPE is the type field of a consumer identity.
+const ID_TYPE = cpi.ID_TYPE
+
+// ID_HOSTNAME is the hostname of a git server.
+const ID_HOSTNAME = hostpath.ID_HOSTNAME
+
+// ID_PORT is the port number of a git server.
+const ID_PORT = hostpath.ID_PORT
+
+// ID_PATHPREFIX is the repository path prefix.
+const ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+
+// ID_SCHEME is the transport protocol.
+const ID_SCHEME = hostpath.ID_SCHEME
+
+func init() {
+	cpi.RegisterStandardIdentityMatcher(CONSUMER_TYPE, IdentityMatcher, `Git repository credential matcher
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type.
+
+The following credential attributes are used to authenticate:
+- <code>`+cpi.ATTR_USERNAME+`</code> and <code>`+cpi.ATTR_PASSWORD+`</code>: basic authentication for
+  HTTP(S) or password authentication for SSH
+- <code>`+cpi.ATTR_TOKEN+`</code>: access token used as basic authentication password for HTTP(S)
+- <code>`+cpi.ATTR_PRIVATE_KEY+`</code>: PEM encoded private key for SSH, optionally
+  encrypted with <code>`+cpi.ATTR_PASSWORD+`</code>`)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+// GetConsumerId provides the consumer identity for a git repository URL.
+// Besides regular URLs the scp-like syntax user@host:path is supported.
+func GetConsumerId(repoURL string) (cpi.ConsumerIdentity, error) {
+	ep, err := transport.NewEndpoint(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	id := cpi.ConsumerIdentity{
+		ID_TYPE: CONSUMER_TYPE,
+	}
+	if ep.Host != "" {
+		id[ID_HOSTNAME] = ep.Host
+	}
+	if ep.Port != 0 {
+		id[ID_PORT] = strconv.Itoa(ep.Port)
+	}
+	if ep.Protocol != "" {
+		id[ID_SCHEME] = ep.Protocol
+	}
+	if p := strings.Trim(ep.Path, "/"); p != "" {
+		id[ID_PATHPREFIX] = p
+	}
+	return id, nil
+}
+
+// GetCredentials provides the credentials configured for a git repository URL.
+func GetCredentials(ctx cpi.ContextProvider, repoURL string) (cpi.Credentials, error) {
+	id, err := GetConsumerId(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	return cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, IdentityMatcher)
+}
@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/git/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+	"github.com/open-component-model/ocm/pkg/utils/gitutils"
+)
+
+// Type is the access type of a git repository.
+const (
+	Type   = "git"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(Type, &AccessSpec{}, cpi.WithDescription(usage)))
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(TypeV1, &AccessSpec{}, cpi.WithFormatSpec(formatV1), cpi.WithConfigHandler(ConfigHandler())))
+}
+
+// AccessSpec describes the access for a commit of a git repository.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// RepoURL is the URL of the git repository.
+	RepoURL string `json:"repoUrl"`
+	// Ref is the branch or tag the commit is taken from.
+	// Short names are looked up as branch and as tag, a fully
+	// qualified name (refs/...) is used as it is.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+	// Commit is the id of the git commit.
+	Commit string `json:"commit"`
+	// PathSpec restricts the archive to a sub path of the repository.
+	// +optional
+	PathSpec string `json:"pathSpec,omitempty"`
+}
+
+var _ cpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new git access spec version v1.
+func New(url, ref, commit string, pathspec ...string) *AccessSpec {
+	s := &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		RepoURL:             url,
+		Ref:                 ref,
+		Commit:              commit,
+	}
+	if len(pathspec) > 0 {
+		s.PathSpec = pathspec[0]
+	}
+	return s
+}
+
+func (a *AccessSpec) Describe(ctx cpi.Context) string {
+	rev := a.Commit
+	if rev == "" {
+		rev = a.Ref
+	}
+	if rev == "" {
+		rev = plumbing.HEAD.String()
+	}
+	if a.PathSpec != "" {
+		return fmt.Sprintf("git %s:%s in repository %s", rev, a.PathSpec, a.RepoURL)
+	}
+	return fmt.Sprintf("git %s in repository %s", rev, a.RepoURL)
+}
+
+func (_ *AccessSpec) IsLocal(cpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(ctx cpi.Context) cpi.AccessSpec {
+	return a
+}
+
+func (a *AccessSpec) GetReferenceHint(cv cpi.ComponentVersionAccess) string {
+	return ""
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c cpi.ComponentVersionAccess) (cpi.AccessMethod, error) {
+	return newMethod(c, a)
+}
+
+// ReferenceNames provides the git references to look up for the
+// configured ref. Short names are resolved as branch and as tag.
+func (a *AccessSpec) ReferenceNames() []plumbing.ReferenceName {
+	if a.Ref == "" {
+		return nil
+	}
+	if strings.HasPrefix(a.Ref, "refs/") {
+		return []plumbing.ReferenceName{plumbing.ReferenceName(a.Ref)}
+	}
+	return []plumbing.ReferenceName{plumbing.NewBranchReferenceName(a.Ref), plumbing.NewTagReferenceName(a.Ref)}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// commitRef is the local reference used to fetch a commit.
+const commitRef = "refs/ocm/commit"
+
+func newMethod(c cpi.ComponentVersionAccess, a *AccessSpec) (cpi.AccessMethod, error) {
+	// the commit is required to get a stable content for the
+	// access specification.
+	if !plumbing.IsHash(a.Commit) {
+		if a.Commit == "" {
+			return nil, errors.Newf("commit required for git access")
+		}
+		return nil, errors.ErrInvalid("git commit", a.Commit)
+	}
+	factory := func() (accessio.BlobAccess, error) {
+		creds, err := identity.GetCredentials(c.GetContext(), a.RepoURL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get credentials for %s", a.RepoURL)
+		}
+		auth, err := GetAuthMethod(a.RepoURL, creds)
+		if err != nil {
+			return nil, err
+		}
+		return accessobj.CachedBlobAccessForWriter(c.GetContext(), mime.MIME_TAR, &archiveWriter{a, auth}), nil
+	}
+	return cpi.NewDefaultMethod(c, a, mime.MIME_TAR, factory), nil
+}
+
+// GetAuthMethod maps credentials to the authentication method
+// used for the transport protocol of a repository URL.
+func GetAuthMethod(repoURL string, creds credentials.Credentials) (transport.AuthMethod, error) {
+	if creds == nil {
+		return nil, nil
+	}
+	ep, err := transport.NewEndpoint(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	user := creds.GetProperty(credentials.ATTR_USERNAME)
+	switch ep.Protocol {
+	case "ssh":
+		if user == "" {
+			user = ep.User
+		}
+		if user == "" {
+			user = "git"
+		}
+		if key := creds.GetProperty(credentials.ATTR_PRIVATE_KEY); key != "" {
+			keys, err := ssh.NewPublicKeys(user, []byte(key), creds.GetProperty(credentials.ATTR_PASSWORD))
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid private key for %s", repoURL)
+			}
+			return keys, nil
+		}
+		if pass := creds.GetProperty(credentials.ATTR_PASSWORD); pass != "" {
+			return &ssh.Password{User: user, Password: pass}, nil
+		}
+	case "http", "https":
+		if token := creds.GetProperty(credentials.ATTR_TOKEN); token != "" {
+			if user == "" {
+				user = "git"
+			}
+			return &http.BasicAuth{Username: user, Password: token}, nil
+		}
+		if user != "" {
+			return &http.BasicAuth{Username: user, Password: creds.GetProperty(credentials.ATTR_PASSWORD)}, nil
+		}
+	}
+	return nil, nil
+}
+
+type archiveWriter struct {
+	spec *AccessSpec
+	auth transport.AuthMethod
+}
+
+func (w *archiveWriter) WriteTo(out accessio.Writer) (int64, digest.Digest, error) {
+	a := w.spec
+	commit, err := w.fetchCommit()
+	if err != nil {
+		return accessio.BLOB_UNKNOWN_SIZE, accessio.BLOB_UNKNOWN_DIGEST, errors.Wrapf(err, "cannot fetch commit %s from repository %s", a.Commit, a.RepoURL)
+	}
+	dw := accessio.NewDefaultDigestWriter(accessio.NopWriteCloser(out))
+	err = gitutils.Archive(commit, a.PathSpec, dw)
+	if err != nil {
+		return accessio.BLOB_UNKNOWN_SIZE, accessio.BLOB_UNKNOWN_DIGEST, errors.Wrapf(err, "cannot archive commit %s of repository %s", commit.Hash, a.RepoURL)
+	}
+	return dw.Size(), dw.Digest(), nil
+}
+
+// fetchCommit fetches only the requested commit without its history.
+// Servers not supporting the fetch of plain commits, get the head
+// of the configured ref (a short name is tried as branch and as tag),
+// and, if this is not the requested commit, the history of the ref or
+// of all branches and tags.
+func (w *archiveWriter) fetchCommit() (*object.Commit, error) {
+	a := w.spec
+	attempts := []*git.FetchOptions{
+		{RefSpecs: []config.RefSpec{config.RefSpec(a.Commit + ":" + commitRef)}, Depth: 1},
+	}
+	if refs := a.ReferenceNames(); len(refs) > 0 {
+		for _, ref := range refs {
+			spec := []config.RefSpec{config.RefSpec("+" + ref + ":" + ref)}
+			attempts = append(attempts, &git.FetchOptions{RefSpecs: spec, Depth: 1}, &git.FetchOptions{RefSpecs: spec})
+		}
+	} else {
+		attempts = append(attempts, &git.FetchOptions{RefSpecs: []config.RefSpec{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"}})
+	}
+
+	var err error
+	for _, opts := range attempts {
+		var commit *object.Commit
+		opts.Auth = w.auth
+		opts.Tags = git.NoTags
+		commit, err = w.fetch(opts)
+		if err == nil {
+			return commit, nil
+		}
+	}
+	return nil, err
+}
+
+// fetch fetches into a new repository, because shallow
+// repositories cannot be deepened by fetching a ref again.
+func (w *archiveWriter) fetch(opts *git.FetchOptions) (*object.Commit, error) {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := repo.CreateRemote(&config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{w.spec.RepoURL},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = remote.Fetch(opts)
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return nil, err
+	}
+	return repo.CommitObject(plumbing.NewHash(w.spec.Commit))
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/git"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/git/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+func commit(repo *gogit.Repository, dir string, files map[string]string) plumbing.Hash {
+	wt := Must(repo.Worktree())
+	for n, c := range files {
+		p := filepath.Join(dir, n)
+		MustBeSuccessful(os.MkdirAll(filepath.Dir(p), 0o755))
+		MustBeSuccessful(os.WriteFile(p, []byte(c), 0o644))
+		Must(wt.Add(n))
+	}
+	return Must(wt.Commit("test", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@acme.org", When: time.Unix(1680000000, 0)},
+	}))
+}
+
+func content(data []byte) map[string]string {
+	result := map[string]string{}
+	tr := tar.NewReader(bytes.NewReader(data))
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		Expect(err).To(Succeed())
+		result[h.Name] = string(Must(io.ReadAll(tr)))
+	}
+	return result
+}
+
+var _ = Describe("Method", func() {
+	var ctx ocm.Context
+	var cv ocm.ComponentVersionAccess
+	var dir string
+	var first, head, feature, tagged plumbing.Hash
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		cv = &cpi.DummyComponentVersionAccess{ctx}
+		dir = Must(os.MkdirTemp("", "gitrepo-"))
+		repo := Must(gogit.PlainInit(dir, false))
+		first = commit(repo, dir, map[string]string{"README.md": "readme", "src/main.go": "package main"})
+		head = commit(repo, dir, map[string]string{"src/main.go": "package main // changed"})
+
+		wt := Must(repo.Worktree())
+		MustBeSuccessful(wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true}))
+		feature = commit(repo, dir, map[string]string{"feature.txt": "feature"})
+
+		// a commit only reachable by a tag
+		MustBeSuccessful(wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("release"), Create: true}))
+		tagged = commit(repo, dir, map[string]string{"release.txt": "release"})
+		Must(repo.CreateTag("v1.0.0", tagged, nil))
+		MustBeSuccessful(wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.Master}))
+		MustBeSuccessful(repo.Storer.RemoveReference(plumbing.NewBranchReferenceName("release")))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("archives commit", func() {
+		m := Must(git.New(dir, "", head.String()).AccessMethod(cv))
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(mime.MIME_TAR))
+		Expect(content(Must(m.Get()))).To(Equal(map[string]string{
+			"README.md":   "readme",
+			"src/main.go": "package main // changed",
+		}))
+	})
+
+	It("archives older commit", func() {
+		m := Must(git.New(dir, "", first.String()).AccessMethod(cv))
+		defer Close(m)
+		Expect(content(Must(m.Get()))).To(Equal(map[string]string{
+			"README.md":   "readme",
+			"src/main.go": "package main",
+		}))
+	})
+
+	It("archives older commit of a branch", func() {
+		m := Must(git.New(dir, "master", first.String()).AccessMethod(cv))
+		defer Close(m)
+		Expect(content(Must(m.Get()))).To(HaveKeyWithValue("src/main.go", "package main"))
+	})
+
+	It("archives branch commit", func() {
+		m := Must(git.New(dir, "feature", feature.String()).AccessMethod(cv))
+		defer Close(m)
+		Expect(content(Must(m.Get()))).To(HaveKeyWithValue("feature.txt", "feature"))
+	})
+
+	It("archives commit only reachable by a tag", func() {
+		m := Must(git.New(dir, "refs/tags/v1.0.0", tagged.String()).AccessMethod(cv))
+		defer Close(m)
+		Expect(content(Must(m.Get()))).To(HaveKeyWithValue("release.txt", "release"))
+
+		m = Must(git.New(dir, "", tagged.String()).AccessMethod(cv))
+		defer Close(m)
+		Expect(content(Must(m.Get()))).To(HaveKeyWithValue("release.txt", "release"))
+	})
+
+	It("archives commit of a tag given by short name", func() {
+		m := Must(git.New(dir, "v1.0.0", tagged.String()).AccessMethod(cv))
+		defer Close(m)
+		Expect(content(Must(m.Get()))).To(HaveKeyWithValue("release.txt", "release"))
+	})
+
+	It("resolves short refs as branch and tag", func() {
+		Expect(git.New(dir, "v1.0.0", tagged.String()).ReferenceNames()).To(Equal([]plumbing.ReferenceName{
+			"refs/heads/v1.0.0",
+			"refs/tags/v1.0.0",
+		}))
+		Expect(git.New(dir, "refs/tags/v1.0.0", tagged.String()).ReferenceNames()).To(Equal([]plumbing.ReferenceName{
+			"refs/tags/v1.0.0",
+		}))
+		Expect(git.New(dir, "", tagged.String()).ReferenceNames()).To(BeNil())
+	})
+
+	It("archives directory", func() {
+		m := Must(git.New(dir, "", head.String(), "src").AccessMethod(cv))
+		defer Close(m)
+		Expect(content(Must(m.Get()))).To(Equal(map[string]string{
+			"main.go": "package main // changed",
+		}))
+	})
+
+	It("archives file", func() {
+		m := Must(git.New(dir, "", head.String(), "src/main.go").AccessMethod(cv))
+		defer Close(m)
+		Expect(content(Must(m.Get()))).To(Equal(map[string]string{
+			"main.go": "package main // changed",
+		}))
+	})
+
+	It("provides reproducible archives", func() {
+		m := Must(git.New(dir, "", first.String()).AccessMethod(cv))
+		defer Close(m)
+		o := Must(git.New(dir, "", first.String()).AccessMethod(&cpi.DummyComponentVersionAccess{ocm.New()}))
+		defer Close(o)
+		Expect(Must(m.Get())).To(Equal(Must(o.Get())))
+	})
+
+	It("requires a commit", func() {
+		_, err := git.New(dir, "feature", "").AccessMethod(cv)
+		Expect(err).To(MatchError("commit required for git access"))
+		_, err = git.New(dir, "", "feature").AccessMethod(cv)
+		Expect(err).To(MatchError(ContainSubstring(`git commit "feature" is invalid`)))
+	})
+
+	It("fails for unknown path", func() {
+		m := Must(git.New(dir, "", head.String(), "unknown").AccessMethod(cv))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring(`path "unknown"`)))
+	})
+
+	It("fails for unknown commit", func() {
+		m := Must(git.New(dir, "", "0123456789012345678901234567890123456789").AccessMethod(cv))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("cannot fetch commit 0123456789012345678901234567890123456789")))
+	})
+})
+
+var _ = Describe("Credentials", func() {
+	It("provides consumer ids", func() {
+		Expect(Must(identity.GetConsumerId("https://github.com/open-component-model/ocm.git"))).To(Equal(credentials.ConsumerIdentity{
+			identity.ID_TYPE:       identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME:   "github.com",
+			identity.ID_SCHEME:     "https",
+			identity.ID_PATHPREFIX: "open-component-model/ocm.git",
+		}))
+		Expect(Must(identity.GetConsumerId("git@github.com:open-component-model/ocm.git"))).To(Equal(credentials.ConsumerIdentity{
+			identity.ID_TYPE:       identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME:   "github.com",
+			identity.ID_PORT:       "22",
+			identity.ID_SCHEME:     "ssh",
+			identity.ID_PATHPREFIX: "open-component-model/ocm.git",
+		}))
+	})
+
+	It("resolves credentials", func() {
+		ctx := ocm.New()
+		id := Must(identity.GetConsumerId("https://github.com/open-component-model"))
+		ctx.CredentialsContext().SetCredentialsForConsumer(id, credentials.DirectCredentials{
+			credentials.ATTR_TOKEN: "token",
+		})
+		url := "https://github.com/open-component-model/ocm.git"
+		creds := Must(identity.GetCredentials(ctx, url))
+		Expect(creds).NotTo(BeNil())
+		Expect(Must(git.GetAuthMethod(url, creds))).To(Equal(&http.BasicAuth{Username: "git", Password: "token"}))
+
+		Expect(Must(identity.GetCredentials(ctx, "https://github.com/other/ocm.git"))).To(BeNil())
+	})
+
+	It("maps basic auth", func() {
+		creds := credentials.DirectCredentials{
+			credentials.ATTR_USERNAME: "user",
+			credentials.ATTR_PASSWORD: "pass",
+		}
+		Expect(Must(git.GetAuthMethod("https://acme.org/repo.git", creds))).To(Equal(&http.BasicAuth{Username: "user", Password: "pass"}))
+	})
+})
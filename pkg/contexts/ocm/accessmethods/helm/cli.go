@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helm
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/helm/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.RepositoryOption,
+		options.PackageOption,
+		options.VersionOption,
+		options.KeyringOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.RepositoryOption, config, "helmRepository")
+	flagsets.AddFieldByOptionP(opts, options.PackageOption, config, "helmChart")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.KeyringOption, config, "keyring")
+	return nil
+}
+
+var usage = `
+This method implements the access of a helm chart stored in a classic
+helm chart repository described by an <code>index.yaml</code> file.
+The chart version is resolved with the index of the repository and the
+chart archive is provided as OCI artifact set containing the chart and,
+if available, its provenance file. This is the same format as used by the
+<code>helm</code> input type and the <code>ociArtifact</code> access method.
+
+Credentials for the repository are taken from the credentials context for
+the consumer type <code>` + identity.CONSUMER_TYPE + `</code> using the
+hostname, port, scheme and URL path of the repository URL. The credential
+attributes <code>username</code> and <code>password</code> are used for basic
+authentication and the attribute <code>token</code> for bearer token
+authentication.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>helmRepository</code>** *string*
+
+  URL of the helm chart repository. <code>file://</code> URLs
+  describe a repository in the local file system.
+
+- **<code>helmChart</code>** *string*
+
+  The name of the helm chart, optionally followed by a colon
+  and the chart version.
+
+- **<code>version</code>** (optional) *string*
+
+  The version of the helm chart, if not given as part of the chart name.
+  It may be a semver constraint. If no version is given, the latest
+  version found in the index is used.
+
+- **<code>keyring</code>** (optional) *string*
+
+  An ASCII armored public key ring. If given, the provenance file
+  (<code>.prov</code>) of the chart is required and verified.
+`
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+)
+
+// CONSUMER_TYPE is the Helm chart repository type.
+const CONSUMER_TYPE = "HelmChartRepository"
+
+func init() {
+	cpi.RegisterStandardIdentityMatcher(CONSUMER_TYPE, IdentityMatcher, `Helm chart repository credential matcher
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type.
+
+The following credential attributes are used to authenticate requests:
+- <code>`+cpi.ATTR_USERNAME+`</code> and <code>`+cpi.ATTR_PASSWORD+`</code>: basic authentication
+- <code>`+cpi.ATTR_TOKEN+`</code>: bearer token authentication`)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
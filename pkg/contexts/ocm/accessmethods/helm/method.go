@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/ociutils/helm"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/helm/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the access type for a helm chart stored in a helm chart repository.
+const (
+	Type   = "helm"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+// MimeType is the media type of the provided blob, an artifact set
+// containing the chart as OCI artifact.
+var MimeType = artdesc.ToContentMediaType(artdesc.MediaTypeImageManifest) + artifactset.SynthesizedBlobFormat
+
+func init() {
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(Type, &AccessSpec{}, cpi.WithDescription(usage)))
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(TypeV1, &AccessSpec{}, cpi.WithFormatSpec(formatV1), cpi.WithConfigHandler(ConfigHandler())))
+}
+
+// AccessSpec describes the access for a helm chart stored in a helm chart repository.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// HelmRepository is the URL of the helm chart repository.
+	HelmRepository string `json:"helmRepository"`
+	// HelmChart is the name of the helm chart and its version separated by a colon.
+	HelmChart string `json:"helmChart"`
+	// Version can either be specified as part of the chart name or separately.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Keyring is an ASCII armored public key ring used to verify the
+	// provenance file of the chart.
+	// +optional
+	Keyring string `json:"keyring,omitempty"`
+}
+
+var _ cpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new helm chart repository access spec version v1.
+func New(repo, chart string, version ...string) *AccessSpec {
+	s := &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		HelmRepository:      repo,
+		HelmChart:           chart,
+	}
+	if len(version) > 0 {
+		s.Version = version[0]
+	}
+	return s
+}
+
+func (a *AccessSpec) Describe(ctx cpi.Context) string {
+	return fmt.Sprintf("Helm chart %s:%s in repository %s", a.GetChartName(), a.GetVersion(), a.HelmRepository)
+}
+
+func (_ *AccessSpec) IsLocal(cpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(ctx cpi.Context) cpi.AccessSpec {
+	return a
+}
+
+func (a *AccessSpec) GetReferenceHint(cv cpi.ComponentVersionAccess) string {
+	return a.GetChartName() + ":" + a.GetVersion()
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c cpi.ComponentVersionAccess) (cpi.AccessMethod, error) {
+	return newMethod(c, a)
+}
+
+func (a *AccessSpec) GetMimeType() string {
+	return MimeType
+}
+
+// GetChartName provides the name of the chart without version.
+func (a *AccessSpec) GetChartName() string {
+	if i := strings.LastIndex(a.HelmChart, ":"); i >= 0 {
+		return a.HelmChart[:i]
+	}
+	return a.HelmChart
+}
+
+// GetVersion provides the chart version, either given by the chart
+// name or the version field.
+func (a *AccessSpec) GetVersion() string {
+	if i := strings.LastIndex(a.HelmChart, ":"); i >= 0 {
+		return a.HelmChart[i+1:]
+	}
+	return a.Version
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newMethod(c cpi.ComponentVersionAccess, a *AccessSpec) (cpi.AccessMethod, error) {
+	if a.HelmRepository == "" {
+		return nil, errors.Newf("helm repository URL required for helm access")
+	}
+	if a.GetChartName() == "" {
+		return nil, errors.Newf("helm chart name required for helm access")
+	}
+	if strings.Contains(a.HelmChart, ":") && a.Version != "" && a.Version != a.GetVersion() {
+		return nil, errors.Newf("version mismatch for helm chart %s: %s", a.HelmChart, a.Version)
+	}
+	factory := func() (accessio.BlobAccess, error) {
+		creds, err := hostpath.GetCredentials(c.GetContext(), identity.CONSUMER_TYPE, a.HelmRepository)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get credentials for %s", a.HelmRepository)
+		}
+		repo := NewRepository(a.HelmRepository, vfsattr.Get(c.GetContext()), creds)
+		return a.download(repo)
+	}
+	return cpi.NewDefaultMethod(c, a, a.GetMimeType(), factory), nil
+}
+
+func (a *AccessSpec) download(repo *Repository) (accessio.BlobAccess, error) {
+	cv, err := repo.Lookup(a.GetChartName(), a.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	u, err := repo.ResolveURL(cv.URLs[0])
+	if err != nil {
+		return nil, err
+	}
+	data, err := repo.Get(u)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot download helm chart %s:%s", cv.Name, cv.Version)
+	}
+	if cv.Digest != "" {
+		sum := sha256.Sum256(data)
+		if dig := hex.EncodeToString(sum[:]); dig != strings.ToLower(cv.Digest) {
+			return nil, errors.Newf("sha256 digest mismatch for helm chart %s:%s: expected %s, found %s", cv.Name, cv.Version, cv.Digest, dig)
+		}
+	}
+	prov, err := repo.Get(u + ".prov")
+	if err != nil {
+		if !errors.IsErrNotFound(err) {
+			return nil, errors.Wrapf(err, "cannot download provenance file for helm chart %s:%s", cv.Name, cv.Version)
+		}
+		prov = nil
+	}
+
+	// provenance verification and chart loading require the OS file system.
+	dir, err := os.MkdirTemp("", "helmchart-")
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create temporary directory for helm chart")
+	}
+	defer os.RemoveAll(dir)
+	fs := osfs.New()
+
+	chartpath := filepath.Join(dir, helm.ChartFileName(cv.Name, cv.Version))
+	err = vfs.WriteFile(fs, chartpath, data, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if prov != nil {
+		err = vfs.WriteFile(fs, chartpath+".prov", prov, 0o600)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if a.Keyring != "" {
+		if prov == nil {
+			return nil, errors.Newf("no provenance file found for helm chart %s:%s", cv.Name, cv.Version)
+		}
+		_, err = helm.VerifyProvenance(chartpath, chartpath+".prov", []byte(a.Keyring))
+		if err != nil {
+			return nil, errors.Wrapf(err, "provenance verification failed for helm chart %s:%s", cv.Name, cv.Version)
+		}
+	}
+	return helm.SynthesizeArtifactBlob(chartpath, fs)
+}
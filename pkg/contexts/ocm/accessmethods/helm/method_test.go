@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helm_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"golang.org/x/crypto/openpgp"       //nolint: staticcheck // required by helm provenance
+	"golang.org/x/crypto/openpgp/armor" //nolint: staticcheck // required by helm provenance
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	ocihelm "github.com/open-component-model/ocm/pkg/contexts/oci/ociutils/helm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/helm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/helm/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+func saveChart(dir, name, version string) (string, *chart.Chart) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: chart.APIVersionV2,
+			Name:       name,
+			Version:    version,
+		},
+		Templates: []*chart.File{
+			{Name: "templates/configmap.yaml", Data: []byte("kind: ConfigMap\n")},
+		},
+	}
+	return Must(chartutil.Save(c, dir)), c
+}
+
+func publicKeyring(e *openpgp.Entity) string {
+	buf := &bytes.Buffer{}
+	w := Must(armor.Encode(buf, openpgp.PublicKeyType, nil))
+	MustBeSuccessful(e.Serialize(w))
+	MustBeSuccessful(w.Close())
+	return buf.String()
+}
+
+var _ = Describe("Method", func() {
+	var ctx ocm.Context
+	var cv ocm.ComponentVersionAccess
+	var dir string
+	var server *httptest.Server
+	var signer *openpgp.Entity
+	var index *repo.IndexFile
+
+	writeIndex := func() {
+		MustBeSuccessful(index.WriteFile(filepath.Join(dir, "index.yaml"), 0o644))
+	}
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		cv = &cpi.DummyComponentVersionAccess{ctx}
+
+		dir = Must(os.MkdirTemp("", "helmrepo-"))
+		signer = Must(openpgp.NewEntity("signer", "", "signer@acme.org", nil))
+
+		index = repo.NewIndexFile()
+		for _, v := range []string{"0.1.0", "0.2.0"} {
+			path, c := saveChart(dir, "testchart", v)
+			index.Add(c.Metadata, filepath.Base(path), "", Must(provenance.DigestFile(path)))
+		}
+		sig := &provenance.Signatory{Entity: signer}
+		prov := Must(sig.ClearSign(filepath.Join(dir, "testchart-0.1.0.tgz")))
+		MustBeSuccessful(os.WriteFile(filepath.Join(dir, "testchart-0.1.0.tgz.prov"), []byte(prov), 0o644))
+		writeIndex()
+
+		mux := http.NewServeMux()
+		mux.Handle("/charts/", http.StripPrefix("/charts", http.FileServer(http.Dir(dir))))
+		mux.Handle("/secure/", http.StripPrefix("/secure", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+				http.Error(w, "access denied", http.StatusUnauthorized)
+				return
+			}
+			http.FileServer(http.Dir(dir)).ServeHTTP(w, r)
+		})))
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	extract := func(m ocm.AccessMethod) (*chart.Chart, string) {
+		blob := Must(m.Get())
+		Expect(m.MimeType()).To(Equal(helm.MimeType))
+		c, _, prov, err := ocihelm.ExtractChart(accessio.BlobAccessForData(m.MimeType(), blob), "/", memoryfs.New())
+		Expect(err).To(Succeed())
+		return c, prov
+	}
+
+	It("accesses latest chart version", func() {
+		acc := helm.New(server.URL+"/charts", "testchart")
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		c, prov := extract(m)
+		Expect(c.Metadata.Version).To(Equal("0.2.0"))
+		Expect(prov).To(Equal(""))
+	})
+
+	It("accesses chart version with provenance file", func() {
+		acc := helm.New(server.URL+"/charts", "testchart:0.1.0")
+		Expect(acc.GetReferenceHint(cv)).To(Equal("testchart:0.1.0"))
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		c, prov := extract(m)
+		Expect(c.Metadata.Version).To(Equal("0.1.0"))
+		Expect(prov).To(Equal("/testchart-0.1.0.tgz.prov"))
+	})
+
+	It("resolves version constraint", func() {
+		acc := helm.New(server.URL+"/charts", "testchart", "<0.2.0")
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		c, _ := extract(m)
+		Expect(c.Metadata.Version).To(Equal("0.1.0"))
+	})
+
+	It("verifies provenance", func() {
+		acc := helm.New(server.URL+"/charts", "testchart", "0.1.0")
+		acc.Keyring = publicKeyring(signer)
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		c, _ := extract(m)
+		Expect(c.Metadata.Version).To(Equal("0.1.0"))
+	})
+
+	It("rejects provenance of other signer", func() {
+		other := Must(openpgp.NewEntity("other", "", "other@acme.org", nil))
+		acc := helm.New(server.URL+"/charts", "testchart", "0.1.0")
+		acc.Keyring = publicKeyring(other)
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("provenance verification failed for helm chart testchart:0.1.0")))
+	})
+
+	It("requires provenance file for verification", func() {
+		acc := helm.New(server.URL+"/charts", "testchart", "0.2.0")
+		acc.Keyring = publicKeyring(signer)
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError("no provenance file found for helm chart testchart:0.2.0"))
+	})
+
+	It("detects digest mismatch", func() {
+		for _, e := range index.Entries["testchart"] {
+			e.Digest = "0000"
+		}
+		writeIndex()
+		acc := helm.New(server.URL+"/charts", "testchart")
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("sha256 digest mismatch for helm chart testchart:0.2.0")))
+	})
+
+	It("fails for unknown chart", func() {
+		acc := helm.New(server.URL+"/charts", "unknown")
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("not found")))
+	})
+
+	It("uses credentials", func() {
+		acc := helm.New(server.URL+"/secure", "testchart")
+
+		m := Must(acc.AccessMethod(cv))
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+		m.Close()
+
+		id := Must(hostpath.GetConsumerId(identity.CONSUMER_TYPE, server.URL+"/secure"))
+		ctx.CredentialsContext().SetCredentialsForConsumer(id, credentials.DirectCredentials{
+			credentials.ATTR_USERNAME: "user",
+			credentials.ATTR_PASSWORD: "pass",
+		})
+		m = Must(acc.AccessMethod(cv))
+		defer Close(m)
+		c, _ := extract(m)
+		Expect(c.Metadata.Version).To(Equal("0.2.0"))
+	})
+
+	It("does not send credentials to foreign chart hosts", func() {
+		auth := []string{}
+		foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth = append(auth, r.Header.Get("Authorization"))
+			http.FileServer(http.Dir(dir)).ServeHTTP(w, r)
+		}))
+		defer foreign.Close()
+		for _, e := range index.Entries["testchart"] {
+			e.URLs = []string{foreign.URL + "/" + e.URLs[0]}
+		}
+		writeIndex()
+
+		id := Must(hostpath.GetConsumerId(identity.CONSUMER_TYPE, server.URL+"/secure"))
+		ctx.CredentialsContext().SetCredentialsForConsumer(id, credentials.DirectCredentials{
+			credentials.ATTR_USERNAME: "user",
+			credentials.ATTR_PASSWORD: "pass",
+		})
+		acc := helm.New(server.URL+"/secure", "testchart")
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		c, _ := extract(m)
+		Expect(c.Metadata.Version).To(Equal("0.2.0"))
+		Expect(auth).NotTo(BeEmpty())
+		for _, a := range auth {
+			Expect(a).To(Equal(""))
+		}
+	})
+
+	It("rejects file URLs in remote index", func() {
+		for _, e := range index.Entries["testchart"] {
+			e.URLs = []string{"file://" + filepath.Join(dir, e.URLs[0])}
+		}
+		writeIndex()
+		acc := helm.New(server.URL+"/charts", "testchart")
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("not allowed for remote chart repository")))
+	})
+
+	It("decodes spec", func() {
+		data := `{"type":"helm/v1","helmRepository":"https://charts.acme.org","helmChart":"testchart:0.1.0"}`
+		spec := Must(ctx.AccessSpecForConfig([]byte(data), runtime.DefaultJSONEncoding))
+		Expect(spec).To(BeAssignableToTypeOf(&helm.AccessSpec{}))
+		Expect(spec.(*helm.AccessSpec).GetChartName()).To(Equal("testchart"))
+		Expect(spec.(*helm.AccessSpec).GetVersion()).To(Equal("0.1.0"))
+	})
+})
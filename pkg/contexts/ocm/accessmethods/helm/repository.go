@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helm
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"helm.sh/helm/v3/pkg/repo"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+// Repository provides access to a classic helm chart repository
+// described by an index.yaml file, either served by an HTTP(S) server
+// or, for file URLs, located in a local file system.
+type Repository struct {
+	url   string
+	fs    vfs.FileSystem
+	creds credentials.Credentials
+}
+
+// NewRepository provides access to the chart repository with the given URL.
+// The file system is used for <code>file://</code> URLs.
+func NewRepository(url string, fs vfs.FileSystem, creds credentials.Credentials) *Repository {
+	return &Repository{
+		url:   strings.TrimSuffix(url, "/"),
+		fs:    fs,
+		creds: creds,
+	}
+}
+
+func (r *Repository) URL() string {
+	return r.url
+}
+
+// GetIndex reads the index.yaml of the repository.
+func (r *Repository) GetIndex() (*repo.IndexFile, error) {
+	data, err := r.Get(r.url + "/index.yaml")
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read chart repository index")
+	}
+	index := &repo.IndexFile{}
+	err = yaml.Unmarshal(data, index)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid chart repository index")
+	}
+	if index.APIVersion == "" {
+		return nil, errors.Newf("invalid chart repository index: no API version")
+	}
+	index.SortEntries()
+	return index, nil
+}
+
+// Lookup resolves a chart version in the index of the repository.
+// The version may be a semver constraint. If it is empty,
+// the latest version is used.
+func (r *Repository) Lookup(name, version string) (*repo.ChartVersion, error) {
+	index, err := r.GetIndex()
+	if err != nil {
+		return nil, err
+	}
+	cv, err := index.Get(name, version)
+	if err != nil {
+		return nil, errors.ErrNotFoundWrap(err, "helm chart", name+":"+version, r.url)
+	}
+	if len(cv.URLs) == 0 {
+		return nil, errors.Newf("no download URL found for helm chart %s:%s", cv.Name, cv.Version)
+	}
+	return cv, nil
+}
+
+// ResolveURL resolves a chart URL found in the index relative to the
+// repository URL.
+func (r *Repository) ResolveURL(ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid chart URL %q", ref)
+	}
+	if u.IsAbs() {
+		return ref, nil
+	}
+	base, err := url.Parse(r.url + "/")
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid repository URL %q", r.url)
+	}
+	return base.ResolveReference(u).String(), nil
+}
+
+// Get reads the content of the given URL. For non-existing files
+// a not found error is returned. File URLs are only accepted for
+// repositories located in the local file system, because the URLs
+// are taken from the repository index.
+func (r *Repository) Get(u string) ([]byte, error) {
+	if strings.HasPrefix(u, "file://") {
+		if !strings.HasPrefix(r.url, "file://") {
+			return nil, errors.Newf("file URL %q not allowed for remote chart repository %s", u, r.url)
+		}
+		path := u[len("file://"):]
+		data, err := vfs.ReadFile(r.fs, path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, errors.ErrNotFound("file", path)
+			}
+			return nil, err
+		}
+		return data, nil
+	}
+	resp, err := r.Request(http.MethodGet, u, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.ErrNotFound("file", u)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, httputils.ResponseError(u, resp)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Request executes an HTTP request. The credentials of the repository
+// are only used for URLs with the scheme and host of the repository URL,
+// charts hosted on foreign hosts are requested without authorization.
+func (r *Repository) Request(method, u string, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := httputils.NewRequestForOrigin(method, u, body, r.url, r.creds)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return http.DefaultClient.Do(req)
+}
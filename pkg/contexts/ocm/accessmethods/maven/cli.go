@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.RepositoryOption,
+		options.GroupIdOption,
+		options.ArtifactIdOption,
+		options.VersionOption,
+		options.ClassifierOption,
+		options.ExtensionOption,
+		options.MediatypeOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.RepositoryOption, config, "repoUrl")
+	flagsets.AddFieldByOptionP(opts, options.GroupIdOption, config, "groupId")
+	flagsets.AddFieldByOptionP(opts, options.ArtifactIdOption, config, "artifactId")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.ClassifierOption, config, "classifier")
+	flagsets.AddFieldByOptionP(opts, options.ExtensionOption, config, "extension")
+	flagsets.AddFieldByOptionP(opts, options.MediatypeOption, config, "mediaType")
+	return nil
+}
+
+var usage = `
+This method implements the access of a single artifact file stored in a
+Maven repository, either served by an HTTP(S) server or, for
+<code>file://</code> URLs, located in a local file system.
+
+If the repository provides a <code>.sha256</code> or <code>.sha1</code>
+side file for the artifact file, the content is verified while it is
+downloaded.
+
+Credentials for the repository are taken from the credentials context for
+the consumer type <code>` + identity.CONSUMER_TYPE + `</code> using the
+hostname, port, scheme and URL path of the repository URL. The credential
+attributes <code>username</code> and <code>password</code> are used for basic
+authentication and the attribute <code>token</code> for bearer token
+authentication.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>repoUrl</code>** *string*
+
+  Base URL of the Maven repository.
+
+- **<code>groupId</code>** *string*
+
+  The group id of the Maven artifact.
+
+- **<code>artifactId</code>** *string*
+
+  The artifact id of the Maven artifact.
+
+- **<code>version</code>** *string*
+
+  The version of the Maven artifact.
+
+- **<code>classifier</code>** (optional) *string*
+
+  The classifier of the artifact file.
+
+- **<code>extension</code>** (optional) *string*
+
+  The extension of the artifact file. The default is <code>jar</code>.
+
+- **<code>mediaType</code>** (optional) *string*
+
+  The media type of the artifact file. By default, it is derived
+  from the extension.
+`
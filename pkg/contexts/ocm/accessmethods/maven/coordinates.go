@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"path"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	DEFAULT_EXTENSION = "jar"
+
+	MIME_JAR = "application/java-archive"
+	MIME_POM = "application/xml"
+	MIME_ZIP = "application/zip"
+)
+
+// Coordinates describe a Maven artifact file.
+type Coordinates struct {
+	// GroupId of the Maven artifact.
+	GroupId string `json:"groupId"`
+	// ArtifactId of the Maven artifact.
+	ArtifactId string `json:"artifactId"`
+	// Version of the Maven artifact.
+	Version string `json:"version"`
+	// Classifier of the Maven artifact file.
+	// +optional
+	Classifier string `json:"classifier,omitempty"`
+	// Extension of the Maven artifact file, the default is jar.
+	// +optional
+	Extension string `json:"extension,omitempty"`
+}
+
+// ParseCoordinates parses coordinates in the format
+// <groupId>:<artifactId>:<version>[:<classifier>[:<extension>]].
+func ParseCoordinates(s string) (*Coordinates, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 3 || len(parts) > 5 {
+		return nil, errors.ErrInvalid("maven coordinates", s)
+	}
+	c := &Coordinates{
+		GroupId:    parts[0],
+		ArtifactId: parts[1],
+		Version:    parts[2],
+	}
+	if len(parts) > 3 {
+		c.Classifier = parts[3]
+	}
+	if len(parts) > 4 {
+		c.Extension = parts[4]
+	}
+	if c.GroupId == "" || c.ArtifactId == "" || c.Version == "" {
+		return nil, errors.ErrInvalid("maven coordinates", s)
+	}
+	return c, nil
+}
+
+func (c *Coordinates) String() string {
+	s := c.GroupId + ":" + c.ArtifactId + ":" + c.Version
+	if c.Classifier != "" || c.Extension != "" {
+		s += ":" + c.Classifier
+	}
+	if c.Extension != "" {
+		s += ":" + c.Extension
+	}
+	return s
+}
+
+// GetExtension provides the file extension, the default is jar.
+func (c *Coordinates) GetExtension() string {
+	if c.Extension == "" {
+		return DEFAULT_EXTENSION
+	}
+	return c.Extension
+}
+
+// FileName provides the name of the artifact file.
+func (c *Coordinates) FileName() string {
+	n := c.ArtifactId + "-" + c.Version
+	if c.Classifier != "" {
+		n += "-" + c.Classifier
+	}
+	return n + "." + c.GetExtension()
+}
+
+// Path provides the path of the artifact file in a Maven repository
+// layout.
+func (c *Coordinates) Path() string {
+	return path.Join(strings.ReplaceAll(c.GroupId, ".", "/"), c.ArtifactId, c.Version, c.FileName())
+}
+
+// MimeType provides the media type derived from the file extension.
+func (c *Coordinates) MimeType() string {
+	switch c.GetExtension() {
+	case "jar", "war", "ear":
+		return MIME_JAR
+	case "pom", "xml":
+		return MIME_POM
+	case "zip":
+		return MIME_ZIP
+	case "tar.gz", "tgz":
+		return mime.MIME_TGZ
+	case "tar":
+		return mime.MIME_TAR
+	case "json":
+		return mime.MIME_JSON
+	default:
+		return mime.MIME_OCTET
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the access type for an artifact file stored in a Maven repository.
+const (
+	Type   = "maven"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(Type, &AccessSpec{}, cpi.WithDescription(usage)))
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(TypeV1, &AccessSpec{}, cpi.WithFormatSpec(formatV1), cpi.WithConfigHandler(ConfigHandler())))
+}
+
+// AccessSpec describes the access for an artifact file stored in a Maven repository.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// RepoURL is the base URL of the Maven repository.
+	RepoURL string `json:"repoUrl"`
+
+	Coordinates `json:",inline"`
+
+	// MediaType is the media type of the artifact file.
+	// +optional
+	MediaType string `json:"mediaType,omitempty"`
+}
+
+var _ cpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new Maven access spec version v1.
+func New(repoURL, groupId, artifactId, version string, classifier, extension string) *AccessSpec {
+	return &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		RepoURL:             repoURL,
+		Coordinates: Coordinates{
+			GroupId:    groupId,
+			ArtifactId: artifactId,
+			Version:    version,
+			Classifier: classifier,
+			Extension:  extension,
+		},
+	}
+}
+
+// NewForCoordinates creates a new Maven access spec version v1 for the
+// given coordinates.
+func NewForCoordinates(repoURL string, c *Coordinates) *AccessSpec {
+	return New(repoURL, c.GroupId, c.ArtifactId, c.Version, c.Classifier, c.Extension)
+}
+
+func (a *AccessSpec) Describe(ctx cpi.Context) string {
+	return fmt.Sprintf("Maven artifact %s in %s", a.Coordinates.String(), a.RepoURL)
+}
+
+func (_ *AccessSpec) IsLocal(cpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(ctx cpi.Context) cpi.AccessSpec {
+	return a
+}
+
+func (a *AccessSpec) GetReferenceHint(cv cpi.ComponentVersionAccess) string {
+	return a.Coordinates.String()
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c cpi.ComponentVersionAccess) (cpi.AccessMethod, error) {
+	return newMethod(c, a)
+}
+
+func (a *AccessSpec) GetMimeType() string {
+	if a.MediaType == "" {
+		return a.Coordinates.MimeType()
+	}
+	return a.MediaType
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newMethod(c cpi.ComponentVersionAccess, a *AccessSpec) (cpi.AccessMethod, error) {
+	if a.RepoURL == "" {
+		return nil, errors.Newf("repository URL required for maven access")
+	}
+	if a.GroupId == "" || a.ArtifactId == "" || a.Version == "" {
+		return nil, errors.ErrInvalid("maven coordinates", a.Coordinates.String())
+	}
+	factory := func() (accessio.BlobAccess, error) {
+		creds, err := hostpath.GetCredentials(c.GetContext(), identity.CONSUMER_TYPE, a.RepoURL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get credentials for %s", a.RepoURL)
+		}
+		repo := NewRepository(a.RepoURL, vfsattr.Get(c.GetContext()), creds)
+		f := func() (io.ReadCloser, error) {
+			return repo.Download(&a.Coordinates)
+		}
+		acc := accessio.DataAccessForReaderFunction(f, repo.URL()+"/"+a.Coordinates.Path())
+		return accessobj.CachedBlobAccessForWriter(c.GetContext(), a.GetMimeType(), accessio.NewDataAccessWriter(acc)), nil
+	}
+	return cpi.NewDefaultMethod(c, a, a.GetMimeType(), factory), nil
+}
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven_test
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	CONTENT  = "some test content"
+	JAR_PATH = "com/acme/lib/1.0.0/lib-1.0.0.jar"
+)
+
+func sha1Hex(s string) string {
+	h := sha1.Sum([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func sha256Hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+var _ = Describe("Method", func() {
+	Context("coordinates", func() {
+		It("parses coordinates", func() {
+			c := Must(maven.ParseCoordinates("com.acme:lib:1.0.0"))
+			Expect(c.Path()).To(Equal(JAR_PATH))
+			Expect(c.MimeType()).To(Equal(maven.MIME_JAR))
+			Expect(c.String()).To(Equal("com.acme:lib:1.0.0"))
+
+			c = Must(maven.ParseCoordinates("com.acme:lib:1.0.0:sources:zip"))
+			Expect(c.Path()).To(Equal("com/acme/lib/1.0.0/lib-1.0.0-sources.zip"))
+			Expect(c.String()).To(Equal("com.acme:lib:1.0.0:sources:zip"))
+
+			c = Must(maven.ParseCoordinates("com.acme:lib:1.0.0::pom"))
+			Expect(c.Path()).To(Equal("com/acme/lib/1.0.0/lib-1.0.0.pom"))
+		})
+
+		It("rejects invalid coordinates", func() {
+			_, err := maven.ParseCoordinates("com.acme:lib")
+			Expect(err).To(HaveOccurred())
+			_, err = maven.ParseCoordinates("com.acme::1.0.0")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("http repository", func() {
+		var ctx ocm.Context
+		var cv ocm.ComponentVersionAccess
+		var server *httptest.Server
+		var files map[string]string
+
+		BeforeEach(func() {
+			ctx = ocm.New()
+			cv = &cpi.DummyComponentVersionAccess{ctx}
+			files = map[string]string{
+				JAR_PATH: CONTENT,
+			}
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path := strings.TrimPrefix(r.URL.Path, "/")
+				if strings.HasPrefix(path, "secure/") {
+					if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+						http.Error(w, "access denied", http.StatusUnauthorized)
+						return
+					}
+					path = strings.TrimPrefix(path, "secure/")
+				}
+				if data, ok := files[path]; ok {
+					w.Write([]byte(data))
+					return
+				}
+				http.NotFound(w, r)
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("accesses artifact without checksum", func() {
+			acc := maven.New(server.URL, "com.acme", "lib", "1.0.0", "", "")
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			Expect(m.MimeType()).To(Equal(maven.MIME_JAR))
+			Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		})
+
+		It("verifies sha256 checksum", func() {
+			files[JAR_PATH+".sha256"] = sha256Hex(CONTENT) + "  lib-1.0.0.jar\n"
+			files[JAR_PATH+".sha1"] = "invalid"
+			acc := maven.New(server.URL, "com.acme", "lib", "1.0.0", "", "")
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		})
+
+		It("verifies sha1 checksum", func() {
+			files[JAR_PATH+".sha1"] = sha1Hex(CONTENT)
+			acc := maven.New(server.URL, "com.acme", "lib", "1.0.0", "", "")
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		})
+
+		It("detects checksum mismatch", func() {
+			files[JAR_PATH+".sha1"] = sha1Hex("other")
+			acc := maven.New(server.URL, "com.acme", "lib", "1.0.0", "", "")
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			_, err := m.Get()
+			Expect(err).To(MatchError(ContainSubstring("digest mismatch")))
+		})
+
+		It("uses credentials", func() {
+			acc := maven.New(server.URL+"/secure", "com.acme", "lib", "1.0.0", "", "")
+
+			m := Must(acc.AccessMethod(cv))
+			_, err := m.Get()
+			Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+			m.Close()
+
+			id := Must(hostpath.GetConsumerId(identity.CONSUMER_TYPE, server.URL+"/secure"))
+			ctx.CredentialsContext().SetCredentialsForConsumer(id, credentials.DirectCredentials{
+				credentials.ATTR_USERNAME: "user",
+				credentials.ATTR_PASSWORD: "pass",
+			})
+			m = Must(acc.AccessMethod(cv))
+			defer Close(m)
+			Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		})
+	})
+
+	Context("local repository", func() {
+		var ctx ocm.Context
+		var cv ocm.ComponentVersionAccess
+		var fs vfs.FileSystem
+
+		BeforeEach(func() {
+			ctx = ocm.New()
+			cv = &cpi.DummyComponentVersionAccess{ctx}
+			fs = memoryfs.New()
+			vfsattr.Set(ctx, fs)
+		})
+
+		It("uploads and accesses artifact", func() {
+			c := Must(maven.ParseCoordinates("com.acme:lib:1.0.0"))
+			repo := maven.NewRepository("file:///repo", fs, nil)
+			MustBeSuccessful(repo.Upload(c, strings.NewReader(CONTENT)))
+
+			Expect(vfs.ReadFile(fs, "/repo/"+JAR_PATH+".sha1")).To(Equal([]byte(sha1Hex(CONTENT))))
+			Expect(vfs.ReadFile(fs, "/repo/"+JAR_PATH+".sha256")).To(Equal([]byte(sha256Hex(CONTENT))))
+
+			acc := maven.NewForCoordinates("file:///repo", c)
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		})
+	})
+
+	It("decodes spec", func() {
+		data := `{"type":"maven/v1","repoUrl":"https://repo.acme.org","groupId":"com.acme","artifactId":"lib","version":"1.0.0","classifier":"sources"}`
+		spec := Must(ocm.DefaultContext().AccessSpecForConfig([]byte(data), runtime.DefaultJSONEncoding))
+		Expect(spec).To(BeAssignableToTypeOf(&maven.AccessSpec{}))
+		Expect(spec.(*maven.AccessSpec).Coordinates.String()).To(Equal("com.acme:lib:1.0.0:sources"))
+		Expect(spec.(*maven.AccessSpec).RepoURL).To(Equal("https://repo.acme.org"))
+	})
+})
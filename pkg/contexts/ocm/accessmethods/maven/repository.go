@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"bytes"
+	"crypto"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+// Repository provides access to the files of a Maven repository layout,
+// either served by an HTTP(S) server or, for file URLs, located in
+// a local file system.
+type Repository struct {
+	url   string
+	fs    vfs.FileSystem
+	creds credentials.Credentials
+}
+
+// NewRepository provides access to the Maven repository with the given URL.
+// The file system is used for <code>file://</code> URLs.
+func NewRepository(url string, fs vfs.FileSystem, creds credentials.Credentials) *Repository {
+	return &Repository{
+		url:   strings.TrimSuffix(url, "/"),
+		fs:    fs,
+		creds: creds,
+	}
+}
+
+func (r *Repository) URL() string {
+	return r.url
+}
+
+func (r *Repository) IsLocal() bool {
+	return strings.HasPrefix(r.url, "file://")
+}
+
+func (r *Repository) localPath(p string) string {
+	return r.url[len("file://"):] + "/" + p
+}
+
+// Download provides a reader for the artifact file described by the given
+// coordinates. If the repository provides a <code>.sha256</code> or
+// <code>.sha1</code> side file, the content is verified while reading.
+func (r *Repository) Download(c *Coordinates) (io.ReadCloser, error) {
+	p := c.Path()
+	hash, dig, err := r.getChecksum(p)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := r.get(p)
+	if err != nil {
+		return nil, err
+	}
+	if dig != "" {
+		return accessio.VerifyingReaderWithHash(reader, hash, dig), nil
+	}
+	return reader, nil
+}
+
+var checksums = []struct {
+	suffix string
+	hash   crypto.Hash
+}{
+	{"sha256", crypto.SHA256},
+	{"sha1", crypto.SHA1},
+}
+
+func (r *Repository) getChecksum(p string) (crypto.Hash, string, error) {
+	for _, c := range checksums {
+		reader, err := r.get(p + "." + c.suffix)
+		if err != nil {
+			if errors.IsErrNotFound(err) {
+				continue
+			}
+			return 0, "", err
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return 0, "", errors.Wrapf(err, "cannot read %s checksum for %s", c.suffix, p)
+		}
+		// checksum files may contain additional file names after the hex value.
+		fields := strings.Fields(string(data))
+		if len(fields) == 0 {
+			continue
+		}
+		return c.hash, strings.ToLower(fields[0]), nil
+	}
+	return 0, "", nil
+}
+
+func (r *Repository) get(p string) (io.ReadCloser, error) {
+	if r.IsLocal() {
+		path := r.localPath(p)
+		f, err := r.fs.OpenFile(path, vfs.O_RDONLY, 0o600)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, errors.ErrNotFound("file", path)
+			}
+			return nil, err
+		}
+		return f, nil
+	}
+	u := r.url + "/" + p
+	resp, err := r.request(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, errors.ErrNotFound("file", u)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, httputils.ResponseError(u, resp)
+	}
+	return resp.Body, nil
+}
+
+// Upload stores the artifact file described by the given coordinates
+// together with <code>.sha1</code> and <code>.sha256</code> side files.
+func (r *Repository) Upload(c *Coordinates, src io.Reader) error {
+	p := c.Path()
+	sha1 := accessio.NewDigestReaderWithHash(crypto.SHA1, src)
+	sha256 := accessio.NewDigestReaderWithHash(crypto.SHA256, sha1)
+	err := r.put(p, sha256)
+	if err != nil {
+		return err
+	}
+	err = r.put(p+".sha1", bytes.NewReader([]byte(sha1.Digest().Hex())))
+	if err != nil {
+		return err
+	}
+	return r.put(p+".sha256", bytes.NewReader([]byte(sha256.Digest().Hex())))
+}
+
+func (r *Repository) put(p string, data io.Reader) error {
+	if r.IsLocal() {
+		path := r.localPath(p)
+		err := r.fs.MkdirAll(vfs.Dir(r.fs, path), 0o755)
+		if err != nil {
+			return err
+		}
+		f, err := r.fs.OpenFile(path, vfs.O_WRONLY|vfs.O_CREATE|vfs.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(f, data)
+		if err != nil {
+			f.Close()
+			return errors.Wrapf(err, "cannot write %s", path)
+		}
+		return f.Close()
+	}
+	u := r.url + "/" + p
+	resp, err := r.request(http.MethodPut, u, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return httputils.ResponseError(u, resp)
+	}
+	return nil
+}
+
+func (r *Repository) request(method, u string, body io.Reader) (*http.Response, error) {
+	req, err := httputils.NewRequest(method, u, body, r.creds)
+	if err != nil {
+		return nil, err
+	}
+	return http.DefaultClient.Do(req)
+}
@@ -175,6 +175,27 @@ func (m *accessMethod) GetArtifact(finalizer *Finalizer) (oci.ArtifactAccess, *o
 	return art, ref, err
 }
 
+// GetNamespace provides access to the OCI namespace of the referenced
+// artifact without accessing the artifact itself.
+func (m *accessMethod) GetNamespace(finalizer *Finalizer) (oci.NamespaceAccess, *oci.RefSpec, error) {
+	repo, ref, err := m.eval()
+	if err != nil {
+		return nil, nil, err
+	}
+	finalizer.Close(repo)
+	ns, err := repo.LookupNamespace(ref.Repository)
+	if err != nil {
+		return nil, nil, err
+	}
+	finalizer.Close(ns)
+	return ns, ref, nil
+}
+
+func (m *accessMethod) getNamespace() (oci.NamespaceAccess, error) {
+	ns, _, err := m.GetNamespace(&m.finalizer)
+	return ns, err
+}
+
 func (m *accessMethod) getArtifact() (oci.ArtifactAccess, *oci.RefSpec, error) {
 	if m.art == nil && m.err == nil {
 		m.art, m.ref, m.err = m.GetArtifact(&m.finalizer)
@@ -241,13 +262,18 @@ func (m *accessMethod) getBlob() (artifactset.ArtifactBlob, error) {
 		return m.blob, nil
 	}
 
-	art, ref, err := m.getArtifact()
+	_, ref, err := m.getArtifact()
+	if err != nil {
+		return nil, err
+	}
+	// the namespace is required to include the referrers of the artifact
+	ns, err := m.getNamespace()
 	if err != nil {
 		return nil, err
 	}
 	logger := Logger(m.comp)
 	logger.Info("synthesize artifact blob", "ref", m.spec.ImageReference)
-	m.blob, err = artifactset.SynthesizeArtifactBlobForArtifact(art, ref.Version())
+	m.blob, err = artifactset.SynthesizeArtifactBlob(ns, ref.Version())
 	logger.Info("synthesize artifact blob done", "ref", m.spec.ImageReference, "error", logging.ErrorMessage(err))
 	if err != nil {
 		return nil, err
@@ -45,3 +45,45 @@ var BucketOption = RegisterOption(NewStringOptionType("bucket", "bucket name"))
 
 // VersionOption.
 var VersionOption = RegisterOption(NewStringOptionType("accessVersion", "version for access specification"))
+
+// URLOption.
+var URLOption = RegisterOption(NewStringOptionType("url", "artifact or server url"))
+
+// HTTPHeaderOption.
+var HTTPHeaderOption = RegisterOption(NewStringMapOptionType("header", "http headers"))
+
+// NoRedirectOption.
+var NoRedirectOption = RegisterOption(NewBoolOptionType("noRedirect", "http redirect behavior"))
+
+// RefOption.
+var RefOption = RegisterOption(NewStringOptionType("ref", "git reference (branch or tag)"))
+
+// PathSpecOption.
+var PathSpecOption = RegisterOption(NewStringOptionType("pathSpec", "path filter for repository content"))
+
+// GroupIdOption.
+var GroupIdOption = RegisterOption(NewStringOptionType("groupId", "maven group id"))
+
+// ArtifactIdOption.
+var ArtifactIdOption = RegisterOption(NewStringOptionType("artifactId", "maven artifact id"))
+
+// ClassifierOption.
+var ClassifierOption = RegisterOption(NewStringOptionType("classifier", "maven classifier"))
+
+// ExtensionOption.
+var ExtensionOption = RegisterOption(NewStringOptionType("extension", "maven type extension"))
+
+// KeyringOption.
+var KeyringOption = RegisterOption(NewStringOptionType("keyring", "ASCII armored public keyring for verification"))
+
+// FilenameOption.
+var FilenameOption = RegisterOption(NewStringOptionType("accessFilename", "file name of a package"))
+
+// AccountOption.
+var AccountOption = RegisterOption(NewStringOptionType("accessAccount", "storage account name"))
+
+// ContainerOption.
+var ContainerOption = RegisterOption(NewStringOptionType("container", "blob container name"))
+
+// EndpointOption.
+var EndpointOption = RegisterOption(NewStringOptionType("endpoint", "storage service endpoint URL"))
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pypi
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/pypi/identity"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.RegistryOption,
+		options.PackageOption,
+		options.VersionOption,
+		options.FilenameOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.RegistryOption, config, "registry")
+	flagsets.AddFieldByOptionP(opts, options.PackageOption, config, "package")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.FilenameOption, config, "filename")
+	return nil
+}
+
+var usage = `
+This method implements the access of a Python package file (wheel or sdist)
+in a Python package index. The file is resolved with the simple repository
+API of the index (PEP 503 and PEP 691). If the index publishes a hash for the
+file, the content is verified while it is downloaded.
+
+Credentials for the index are taken from the credentials context for
+the consumer type <code>` + identity.CONSUMER_TYPE + `</code> using the
+hostname, port, scheme and URL path of the index URL. The credential
+attributes <code>username</code> and <code>password</code> are used for basic
+authentication and the attribute <code>token</code> for bearer token
+authentication.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>registry</code>** *string*
+
+  Base URL of the simple repository API of the package index,
+  for example <code>https://pypi.org/simple</code>.
+
+- **<code>package</code>** *string*
+
+  The name of the Python package.
+
+- **<code>version</code>** *string*
+
+  The version of the Python package.
+
+- **<code>filename</code>** (optional) *string*
+
+  The name of the wheel or sdist file. If not given, the sdist
+  file (<code>.tar.gz</code>) of the version is used.
+`
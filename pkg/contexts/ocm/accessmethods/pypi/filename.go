@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pypi
+
+import (
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	// MIME_WHEEL is the media type used for wheel files.
+	MIME_WHEEL = "application/zip"
+
+	FILETYPE_SDIST = "sdist"
+	FILETYPE_WHEEL = "bdist_wheel"
+)
+
+var sdistSuffixes = []string{".tar.gz", ".zip"}
+
+// ParseFilename provides the project name, the version and the
+// file type of a wheel or sdist file name.
+func ParseFilename(filename string) (string, string, string, error) {
+	if strings.HasSuffix(filename, ".whl") {
+		// {distribution}-{version}(-{build tag})?-{python tag}-{abi tag}-{platform tag}.whl
+		parts := strings.Split(strings.TrimSuffix(filename, ".whl"), "-")
+		if len(parts) < 5 {
+			return "", "", "", errors.ErrInvalid("wheel file name", filename)
+		}
+		return parts[0], parts[1], FILETYPE_WHEEL, nil
+	}
+	for _, s := range sdistSuffixes {
+		if strings.HasSuffix(filename, s) {
+			// {name}-{version}.tar.gz
+			base := strings.TrimSuffix(filename, s)
+			i := strings.LastIndex(base, "-")
+			if i <= 0 || i == len(base)-1 {
+				return "", "", "", errors.ErrInvalid("sdist file name", filename)
+			}
+			return base[:i], base[i+1:], FILETYPE_SDIST, nil
+		}
+	}
+	return "", "", "", errors.ErrInvalid("python package file name", filename)
+}
+
+// WheelPythonTag provides the python tag of a wheel file name.
+func WheelPythonTag(filename string) string {
+	parts := strings.Split(strings.TrimSuffix(filename, ".whl"), "-")
+	if len(parts) < 5 {
+		return ""
+	}
+	return parts[len(parts)-3]
+}
+
+// MimeType provides the media type for a package file name.
+func MimeType(filename string) string {
+	switch {
+	case strings.HasSuffix(filename, ".tar.gz"):
+		return mime.MIME_TGZ
+	case strings.HasSuffix(filename, ".whl"), strings.HasSuffix(filename, ".zip"):
+		return MIME_WHEEL
+	default:
+		return mime.MIME_OCTET
+	}
+}
+
+// SdistFilename provides the standard sdist file name for a package version
+// according to PEP 625.
+func SdistFilename(pkg, version string) string {
+	return strings.ReplaceAll(NormalizeName(pkg), "-", "_") + "-" + version + ".tar.gz"
+}
@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pypi
+
+import (
+	"encoding/json"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+const (
+	// MIME_SIMPLE_JSON is the media type of the JSON variant of the simple repository API (PEP 691).
+	MIME_SIMPLE_JSON = "application/vnd.pypi.simple.v1+json"
+	// MIME_SIMPLE_HTML is the media type of the HTML variant of the simple repository API (PEP 691).
+	MIME_SIMPLE_HTML = "application/vnd.pypi.simple.v1+html"
+)
+
+// File describes a distribution file of a project in a package index.
+type File struct {
+	Filename string            `json:"filename"`
+	URL      string            `json:"url"`
+	Hashes   map[string]string `json:"hashes,omitempty"`
+}
+
+type projectPage struct {
+	Files []File `json:"files"`
+}
+
+// Index provides access to a Python package index implementing the
+// simple repository API (PEP 503 and PEP 691), either served by an HTTP(S)
+// server or, for file URLs, located in a local file system.
+type Index struct {
+	url   string
+	fs    vfs.FileSystem
+	creds credentials.Credentials
+}
+
+// NewIndex provides access to the package index with the given URL.
+// The file system is used for <code>file://</code> URLs.
+func NewIndex(url string, fs vfs.FileSystem, creds credentials.Credentials) *Index {
+	return &Index{
+		url:   strings.TrimSuffix(url, "/"),
+		fs:    fs,
+		creds: creds,
+	}
+}
+
+func (i *Index) URL() string {
+	return i.url
+}
+
+var normalizeExp = regexp.MustCompile(`[-_.]+`)
+
+// NormalizeName provides the normalized project name according to PEP 503.
+func NormalizeName(name string) string {
+	return strings.ToLower(normalizeExp.ReplaceAllString(name, "-"))
+}
+
+// Files lists the distribution files of a project.
+func (i *Index) Files(project string) ([]File, error) {
+	u := i.url + "/" + NormalizeName(project) + "/"
+	var data []byte
+	var err error
+	ctype := "text/html"
+	if strings.HasPrefix(u, "file://") {
+		data, err = i.readFile(u + "index.html")
+	} else {
+		var resp *http.Response
+		resp, err = i.Request(http.MethodGet, u, "", nil, MIME_SIMPLE_JSON+", "+MIME_SIMPLE_HTML+";q=0.2, text/html;q=0.1")
+		if err == nil {
+			defer resp.Body.Close()
+			switch resp.StatusCode {
+			case http.StatusOK:
+				ctype = resp.Header.Get("Content-Type")
+				data, err = io.ReadAll(resp.Body)
+			case http.StatusNotFound:
+				err = errors.ErrNotFound("python package", project, i.url)
+			default:
+				err = httputils.ResponseError(u, resp)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(ctype, MIME_SIMPLE_JSON) || strings.HasPrefix(ctype, mime.MIME_JSON) {
+		var page projectPage
+		err = json.Unmarshal(data, &page)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid project page for %s", project)
+		}
+		for n, f := range page.Files {
+			page.Files[n].URL, err = resolve(u, f.URL)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return page.Files, nil
+	}
+	return parseHTML(u, data)
+}
+
+var anchorExp = regexp.MustCompile(`(?is)<a\s[^>]*href\s*=\s*["']([^"']*)["'][^>]*>(.*?)</a>`)
+
+// parseHTML parses the HTML variant of a project page (PEP 503).
+// Hashes are provided as URL fragment.
+func parseHTML(base string, data []byte) ([]File, error) {
+	var files []File
+	for _, m := range anchorExp.FindAllSubmatch(data, -1) {
+		ref := html.UnescapeString(string(m[1]))
+		f := File{
+			Filename: strings.TrimSpace(html.UnescapeString(string(m[2]))),
+		}
+		if i := strings.Index(ref, "#"); i >= 0 {
+			if alg, val, ok := strings.Cut(ref[i+1:], "="); ok {
+				f.Hashes = map[string]string{alg: val}
+			}
+			ref = ref[:i]
+		}
+		u, err := resolve(base, ref)
+		if err != nil {
+			return nil, err
+		}
+		f.URL = u
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func resolve(base, ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid file URL %q", ref)
+	}
+	if u.IsAbs() {
+		return ref, nil
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid index URL %q", base)
+	}
+	return b.ResolveReference(u).String(), nil
+}
+
+// Reader provides a reader for the content of the given file URL.
+// File URLs are only accepted for indices located in the local file
+// system, because the URLs are taken from the project pages of the index.
+func (i *Index) Reader(u string) (io.ReadCloser, error) {
+	if strings.HasPrefix(u, "file://") {
+		if !strings.HasPrefix(i.url, "file://") {
+			return nil, errors.Newf("file URL %q not allowed for remote package index %s", u, i.url)
+		}
+		path := u[len("file://"):]
+		f, err := i.fs.OpenFile(path, vfs.O_RDONLY, 0o600)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, errors.ErrNotFound("file", path)
+			}
+			return nil, err
+		}
+		return f, nil
+	}
+	resp, err := i.Request(http.MethodGet, u, "", nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, httputils.ResponseError(u, resp)
+	}
+	return resp.Body, nil
+}
+
+func (i *Index) readFile(u string) ([]byte, error) {
+	r, err := i.Reader(u)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// Request executes an HTTP request. The credentials of the index are
+// only used for URLs with the scheme and host of the index URL, file
+// URLs on foreign hosts are requested without authorization.
+func (i *Index) Request(method, u string, contentType string, body io.Reader, accept string) (*http.Response, error) {
+	req, err := httputils.NewRequestForOrigin(method, u, body, i.url, i.creds)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	return http.DefaultClient.Do(req)
+}
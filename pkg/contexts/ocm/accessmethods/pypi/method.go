@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pypi
+
+import (
+	"crypto"
+	_ "crypto/md5"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/pypi/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the access type for a Python package in a Python package index.
+const (
+	Type   = "pypi"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(Type, &AccessSpec{}, cpi.WithDescription(usage)))
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(TypeV1, &AccessSpec{}, cpi.WithFormatSpec(formatV1), cpi.WithConfigHandler(ConfigHandler())))
+}
+
+// AccessSpec describes the access for a Python package in a Python package index.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// Registry is the base URL of the simple repository API of the package index.
+	Registry string `json:"registry"`
+	// Package is the name of the Python package (project).
+	Package string `json:"package"`
+	// Version of the Python package.
+	Version string `json:"version"`
+	// Filename is the name of the wheel or sdist file. If not given,
+	// the sdist file of the version is used.
+	// +optional
+	Filename string `json:"filename,omitempty"`
+}
+
+var _ cpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new Python package access spec version v1.
+func New(registry, pkg, version string, filename ...string) *AccessSpec {
+	s := &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		Registry:            registry,
+		Package:             pkg,
+		Version:             version,
+	}
+	if len(filename) > 0 {
+		s.Filename = filename[0]
+	}
+	return s
+}
+
+func (a *AccessSpec) Describe(ctx cpi.Context) string {
+	if a.Filename != "" {
+		return fmt.Sprintf("Python package %s:%s (%s) in index %s", a.Package, a.Version, a.Filename, a.Registry)
+	}
+	return fmt.Sprintf("Python package %s:%s in index %s", a.Package, a.Version, a.Registry)
+}
+
+func (_ *AccessSpec) IsLocal(cpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(ctx cpi.Context) cpi.AccessSpec {
+	return a
+}
+
+// GetReferenceHint provides the hint <package>:<version>[:<filename>].
+func (a *AccessSpec) GetReferenceHint(cv cpi.ComponentVersionAccess) string {
+	if a.Filename != "" {
+		return a.Package + ":" + a.Version + ":" + a.Filename
+	}
+	return a.Package + ":" + a.Version
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c cpi.ComponentVersionAccess) (cpi.AccessMethod, error) {
+	return newMethod(c, a)
+}
+
+func (a *AccessSpec) GetMimeType() string {
+	if a.Filename == "" {
+		return MimeType(".tar.gz")
+	}
+	return MimeType(a.Filename)
+}
+
+// Lookup finds the described package file in the given index.
+func (a *AccessSpec) Lookup(index *Index) (*File, error) {
+	files, err := index.Files(a.Package)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if a.Filename != "" {
+			if f.Filename == a.Filename {
+				return &f, nil
+			}
+			continue
+		}
+		if name, vers, ftype, err := ParseFilename(f.Filename); err == nil {
+			if ftype == FILETYPE_SDIST && strings.HasSuffix(f.Filename, ".tar.gz") && vers == a.Version && NormalizeName(name) == NormalizeName(a.Package) {
+				return &f, nil
+			}
+		}
+	}
+	if a.Filename != "" {
+		return nil, errors.ErrNotFound("python package file", a.Filename, a.Registry)
+	}
+	return nil, errors.ErrNotFound("python package sdist", a.Package+":"+a.Version, a.Registry)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// hashes describes the supported hash algorithms in order of preference.
+var hashes = []struct {
+	name string
+	hash crypto.Hash
+}{
+	{"sha256", crypto.SHA256},
+	{"sha512", crypto.SHA512},
+	{"sha384", crypto.SHA384},
+	{"md5", crypto.MD5},
+}
+
+func newMethod(c cpi.ComponentVersionAccess, a *AccessSpec) (cpi.AccessMethod, error) {
+	if a.Registry == "" || a.Package == "" || a.Version == "" {
+		return nil, errors.Newf("registry, package and version required for python package access")
+	}
+	if a.Filename != "" {
+		name, vers, _, err := ParseFilename(a.Filename)
+		if err != nil {
+			return nil, err
+		}
+		if vers != a.Version || NormalizeName(name) != NormalizeName(a.Package) {
+			return nil, errors.Newf("file %s does not match python package %s:%s", a.Filename, a.Package, a.Version)
+		}
+	}
+	factory := func() (accessio.BlobAccess, error) {
+		creds, err := hostpath.GetCredentials(c.GetContext(), identity.CONSUMER_TYPE, a.Registry)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get credentials for %s", a.Registry)
+		}
+		index := NewIndex(a.Registry, vfsattr.Get(c.GetContext()), creds)
+		file, err := a.Lookup(index)
+		if err != nil {
+			return nil, err
+		}
+		f := func() (io.ReadCloser, error) {
+			return index.Reader(file.URL)
+		}
+		for _, h := range hashes {
+			if v := file.Hashes[h.name]; v != "" {
+				tf, hash, dig := f, h.hash, strings.ToLower(v)
+				f = func() (io.ReadCloser, error) {
+					r, err := tf()
+					if err != nil {
+						return nil, err
+					}
+					return accessio.VerifyingReaderWithHash(r, hash, dig), nil
+				}
+				break
+			}
+		}
+		acc := accessio.DataAccessForReaderFunction(f, file.URL)
+		return accessobj.CachedBlobAccessForWriter(c.GetContext(), a.GetMimeType(), accessio.NewDataAccessWriter(acc)), nil
+	}
+	return cpi.NewDefaultMethod(c, a, a.GetMimeType(), factory), nil
+}
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pypi_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/pypi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/pypi/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	SDIST = "my_pkg-1.0.0.tar.gz"
+	WHEEL = "my_pkg-1.0.0-py3-none-any.whl"
+)
+
+func sha256Hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+var _ = Describe("Method", func() {
+	Context("file names", func() {
+		It("parses file names", func() {
+			name, vers, ftype, err := pypi.ParseFilename(WHEEL)
+			Expect(err).To(Succeed())
+			Expect([]string{name, vers, ftype}).To(Equal([]string{"my_pkg", "1.0.0", pypi.FILETYPE_WHEEL}))
+			Expect(pypi.WheelPythonTag(WHEEL)).To(Equal("py3"))
+
+			name, vers, ftype, err = pypi.ParseFilename(SDIST)
+			Expect(err).To(Succeed())
+			Expect([]string{name, vers, ftype}).To(Equal([]string{"my_pkg", "1.0.0", pypi.FILETYPE_SDIST}))
+			Expect(pypi.SdistFilename("My.Pkg", "1.0.0")).To(Equal(SDIST))
+			Expect(pypi.NormalizeName("My_Pkg")).To(Equal("my-pkg"))
+		})
+	})
+
+	Context("http index", func() {
+		var ctx ocm.Context
+		var cv ocm.ComponentVersionAccess
+		var server *httptest.Server
+		var content map[string]string
+		var hashes map[string]string
+		var html bool
+
+		BeforeEach(func() {
+			ctx = ocm.New()
+			cv = &cpi.DummyComponentVersionAccess{ctx}
+			html = false
+			content = map[string]string{
+				SDIST: "sdist content",
+				WHEEL: "wheel content",
+			}
+			hashes = map[string]string{
+				SDIST: sha256Hex(content[SDIST]),
+				WHEEL: sha256Hex(content[WHEEL]),
+			}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/simple/my-pkg/", func(w http.ResponseWriter, r *http.Request) {
+				if user, pass, ok := r.BasicAuth(); ok && (user != "user" || pass != "pass") {
+					http.Error(w, "access denied", http.StatusUnauthorized)
+					return
+				}
+				if html || !strings.Contains(r.Header.Get("Accept"), pypi.MIME_SIMPLE_JSON) {
+					w.Header().Set("Content-Type", "text/html")
+					fmt.Fprintf(w, "<html><body>\n")
+					for _, n := range []string{SDIST, WHEEL} {
+						fmt.Fprintf(w, "<a href=\"../../files/%s#sha256=%s\" data-requires-python=\"&gt;=3.7\">%s</a><br/>\n", n, hashes[n], n)
+					}
+					fmt.Fprintf(w, "</body></html>\n")
+					return
+				}
+				w.Header().Set("Content-Type", pypi.MIME_SIMPLE_JSON)
+				var files []pypi.File
+				for _, n := range []string{SDIST, WHEEL} {
+					files = append(files, pypi.File{Filename: n, URL: "/files/" + n, Hashes: map[string]string{"sha256": hashes[n]}})
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"files": files})
+			})
+			mux.HandleFunc("/files/", func(w http.ResponseWriter, r *http.Request) {
+				data, ok := content[strings.TrimPrefix(r.URL.Path, "/files/")]
+				if !ok {
+					http.NotFound(w, r)
+					return
+				}
+				w.Write([]byte(data))
+			})
+			server = httptest.NewServer(mux)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("accesses sdist by default", func() {
+			acc := pypi.New(server.URL+"/simple", "My.Pkg", "1.0.0")
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			Expect(m.MimeType()).To(Equal(mime.MIME_TGZ))
+			Expect(string(Must(m.Get()))).To(Equal(content[SDIST]))
+		})
+
+		It("accesses wheel", func() {
+			acc := pypi.New(server.URL+"/simple", "my-pkg", "1.0.0", WHEEL)
+			Expect(acc.GetReferenceHint(cv)).To(Equal("my-pkg:1.0.0:" + WHEEL))
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			Expect(m.MimeType()).To(Equal(pypi.MIME_WHEEL))
+			Expect(string(Must(m.Get()))).To(Equal(content[WHEEL]))
+		})
+
+		It("accesses wheel with html project page", func() {
+			html = true
+			acc := pypi.New(server.URL+"/simple/", "my-pkg", "1.0.0", WHEEL)
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			Expect(string(Must(m.Get()))).To(Equal(content[WHEEL]))
+		})
+
+		It("detects hash mismatch", func() {
+			html = true
+			hashes[WHEEL] = sha256Hex("other")
+			acc := pypi.New(server.URL+"/simple", "my-pkg", "1.0.0", WHEEL)
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			_, err := m.Get()
+			Expect(err).To(MatchError(ContainSubstring("digest mismatch")))
+		})
+
+		It("fails for unknown file", func() {
+			acc := pypi.New(server.URL+"/simple", "my-pkg", "1.0.0", "my_pkg-1.0.0-cp311-cp311-linux_x86_64.whl")
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			_, err := m.Get()
+			Expect(err).To(MatchError(ContainSubstring("not found")))
+		})
+
+		It("rejects file of other version", func() {
+			acc := pypi.New(server.URL+"/simple", "my-pkg", "2.0.0", WHEEL)
+
+			_, err := acc.AccessMethod(cv)
+			Expect(err).To(MatchError("file " + WHEEL + " does not match python package my-pkg:2.0.0"))
+		})
+
+		It("uses credentials", func() {
+			id := Must(hostpath.GetConsumerId(identity.CONSUMER_TYPE, server.URL+"/simple"))
+			ctx.CredentialsContext().SetCredentialsForConsumer(id, credentials.DirectCredentials{
+				credentials.ATTR_USERNAME: "other",
+				credentials.ATTR_PASSWORD: "pass",
+			})
+			acc := pypi.New(server.URL+"/simple", "my-pkg", "1.0.0")
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			_, err := m.Get()
+			Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+		})
+
+		It("does not send credentials to foreign file hosts", func() {
+			var auth []string
+			files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth = append(auth, r.Header.Get("Authorization"))
+				w.Write([]byte(content[WHEEL]))
+			}))
+			defer files.Close()
+
+			index := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if _, _, ok := r.BasicAuth(); !ok {
+					http.Error(w, "access denied", http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("Content-Type", pypi.MIME_SIMPLE_JSON)
+				json.NewEncoder(w).Encode(map[string]interface{}{"files": []pypi.File{
+					{Filename: WHEEL, URL: files.URL + "/" + WHEEL, Hashes: map[string]string{"sha256": hashes[WHEEL]}},
+				}})
+			}))
+			defer index.Close()
+
+			id := Must(hostpath.GetConsumerId(identity.CONSUMER_TYPE, index.URL+"/simple"))
+			ctx.CredentialsContext().SetCredentialsForConsumer(id, credentials.DirectCredentials{
+				credentials.ATTR_USERNAME: "user",
+				credentials.ATTR_PASSWORD: "pass",
+			})
+			acc := pypi.New(index.URL+"/simple", "my-pkg", "1.0.0", WHEEL)
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			Expect(string(Must(m.Get()))).To(Equal(content[WHEEL]))
+			Expect(auth).To(Equal([]string{""}))
+		})
+
+		It("rejects file URLs in remote index", func() {
+			index := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", pypi.MIME_SIMPLE_JSON)
+				json.NewEncoder(w).Encode(map[string]interface{}{"files": []pypi.File{
+					{Filename: WHEEL, URL: "file:///files/" + WHEEL, Hashes: map[string]string{"sha256": hashes[WHEEL]}},
+				}})
+			}))
+			defer index.Close()
+			acc := pypi.New(index.URL+"/simple", "my-pkg", "1.0.0", WHEEL)
+
+			m := Must(acc.AccessMethod(cv))
+			defer Close(m)
+			_, err := m.Get()
+			Expect(err).To(MatchError(ContainSubstring("not allowed for remote package index")))
+		})
+	})
+
+	Context("local index", func() {
+		It("accesses package", func() {
+			ctx := ocm.New()
+			fs := memoryfs.New()
+			vfsattr.Set(ctx, fs)
+			MustBeSuccessful(fs.MkdirAll("/index/my-pkg", 0o755))
+			MustBeSuccessful(fs.MkdirAll("/files", 0o755))
+			MustBeSuccessful(vfs.WriteFile(fs, "/files/"+SDIST, []byte("sdist content"), 0o644))
+			MustBeSuccessful(vfs.WriteFile(fs, "/index/my-pkg/index.html", []byte(`<a href="../../files/`+SDIST+`">`+SDIST+`</a>`), 0o644))
+
+			acc := pypi.New("file:///index", "my-pkg", "1.0.0")
+			m := Must(acc.AccessMethod(&cpi.DummyComponentVersionAccess{ctx}))
+			defer Close(m)
+			Expect(string(Must(m.Get()))).To(Equal("sdist content"))
+		})
+	})
+
+	It("decodes spec", func() {
+		data := `{"type":"pypi/v1","registry":"https://pypi.org/simple","package":"my-pkg","version":"1.0.0","filename":"` + WHEEL + `"}`
+		spec := Must(ocm.DefaultContext().AccessSpecForConfig([]byte(data), runtime.DefaultJSONEncoding))
+		Expect(spec).To(BeAssignableToTypeOf(&pypi.AccessSpec{}))
+		Expect(spec.(*pypi.AccessSpec).Filename).To(Equal(WHEEL))
+	})
+})
@@ -7,6 +7,7 @@ package s3
 import (
 	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/s3/identity"
 )
 
 func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
@@ -17,6 +18,7 @@ func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
 		options.ReferenceOption,
 		options.MediatypeOption,
 		options.VersionOption,
+		options.EndpointOption,
 	)
 }
 
@@ -26,11 +28,15 @@ func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
 	flagsets.AddFieldByOptionP(opts, options.RegionOption, config, "region")
 	flagsets.AddFieldByOptionP(opts, options.BucketOption, config, "bucket")
 	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.EndpointOption, config, "endpoint")
 	return nil
 }
 
 var usage = `
 This method implements the access of a blob stored in an S3 bucket.
+
+Credentials are requested for the consumer type <code>` + identity.CONSUMER_TYPE + `</code>
+with the host of the endpoint and the path prefix <code>&lt;bucket>/&lt;key></code>.
 `
 
 var formatV1 = `
@@ -47,4 +53,17 @@ The type specific specification fields are:
 - **<code>key</code>** *string*
 
   The key of the desired blob
+
+- **<code>version</code>** (optional) *string*
+
+  The version id of the desired blob
+
+- **<code>mediaType</code>** (optional) *string*
+
+  The media type of the content
+
+- **<code>endpoint</code>** (optional) *string*
+
+  The URL of an S3 compatible service like MinIO. If not set, AWS S3 is
+  used. Custom endpoints are accessed with path-style addressing.
 `
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader/s3"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+)
+
+// CONSUMER_TYPE is the S3 type.
+const CONSUMER_TYPE = "S3"
+
+// ID_TYPE is the type field of a consumer identity.
+const ID_TYPE = cpi.ID_TYPE
+
+// ID_HOSTNAME is the hostname of the S3 endpoint.
+const ID_HOSTNAME = hostpath.ID_HOSTNAME
+
+// ID_PORT is the port number of the S3 endpoint.
+const ID_PORT = hostpath.ID_PORT
+
+// ID_PATHPREFIX is the bucket name followed by the object key.
+const ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+
+// ID_SCHEME is the URL scheme.
+const ID_SCHEME = hostpath.ID_SCHEME
+
+// AWS_HOSTNAME is the hostname used for AWS S3, if no endpoint is given.
+const AWS_HOSTNAME = "s3.amazonaws.com"
+
+func init() {
+	cpi.RegisterStandardIdentityMatcher(CONSUMER_TYPE, IdentityMatcher, `S3 credential matcher
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type. The hostname is the one of the
+S3 endpoint (<code>`+AWS_HOSTNAME+`</code> for AWS S3) and the path prefix
+is the bucket name followed by the object key.
+
+The following credential attributes are used to authenticate requests:
+- <code>`+cpi.ATTR_AWS_ACCESS_KEY_ID+`</code> and <code>`+cpi.ATTR_AWS_SECRET_ACCESS_KEY+`</code>: AWS access keys`)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+// GetConsumerId provides the consumer identity for an object in a bucket.
+// An empty endpoint describes AWS S3.
+func GetConsumerId(endpoint, bucket, key string) (cpi.ConsumerIdentity, error) {
+	id := cpi.ConsumerIdentity{
+		ID_TYPE:     CONSUMER_TYPE,
+		ID_HOSTNAME: AWS_HOSTNAME,
+	}
+	if endpoint != "" {
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "https://" + endpoint
+		}
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		id[ID_HOSTNAME] = u.Hostname()
+		if u.Port() != "" {
+			id[ID_PORT] = u.Port()
+		}
+		id[ID_SCHEME] = u.Scheme
+	}
+	if p := strings.Trim(path.Join(bucket, key), "/"); p != "" {
+		id[ID_PATHPREFIX] = p
+	}
+	return id, nil
+}
+
+// GetCredentials provides the AWS credentials configured for an object
+// in a bucket.
+func GetCredentials(ctx cpi.ContextProvider, endpoint, bucket, key string) (*s3.AWSCreds, error) {
+	id, err := GetConsumerId(endpoint, bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, IdentityMatcher)
+	if err != nil || creds == nil {
+		return nil, err
+	}
+	accessKeyID := creds.GetProperty(cpi.ATTR_AWS_ACCESS_KEY_ID)
+	if accessKeyID == "" {
+		return nil, nil
+	}
+	return &s3.AWSCreds{
+		AccessKeyID:  accessKeyID,
+		AccessSecret: creds.GetProperty(cpi.ATTR_AWS_SECRET_ACCESS_KEY),
+	}, nil
+}
@@ -6,15 +6,12 @@ package s3
 
 import (
 	"fmt"
-	"path"
 
 	"github.com/open-component-model/ocm/pkg/common/accessio"
 	"github.com/open-component-model/ocm/pkg/common/accessio/downloader"
 	"github.com/open-component-model/ocm/pkg/common/accessio/downloader/s3"
 	"github.com/open-component-model/ocm/pkg/common/accessobj"
-	"github.com/open-component-model/ocm/pkg/contexts/credentials"
-	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
-	"github.com/open-component-model/ocm/pkg/contexts/oci/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/s3/identity"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
 	"github.com/open-component-model/ocm/pkg/mime"
 	"github.com/open-component-model/ocm/pkg/runtime"
@@ -27,7 +24,7 @@ const (
 
 	LegacyType    = "S3"
 	LegacyTypeV1  = LegacyType + runtime.VersionSeparator + "v1"
-	CONSUMER_TYPE = "S3"
+	CONSUMER_TYPE = identity.CONSUMER_TYPE
 )
 
 func init() {
@@ -54,7 +51,11 @@ type AccessSpec struct {
 	Version string `json:"version,omitempty"`
 	// MediaType defines the mime type of the object to download.
 	// +optional
-	MediaType  string `json:"mediaType,omitempty"`
+	MediaType string `json:"mediaType,omitempty"`
+	// Endpoint is the URL of an S3 compatible service like MinIO.
+	// If not set, AWS S3 is used.
+	// +optional
+	Endpoint   string `json:"endpoint,omitempty"`
 	downloader downloader.Downloader
 }
 
@@ -105,27 +106,11 @@ type accessMethod struct {
 var _ cpi.AccessMethod = (*accessMethod)(nil)
 
 func newMethod(c cpi.ComponentVersionAccess, a *AccessSpec) (*accessMethod, error) {
-	creds, err := getCreds(a, c.GetContext().CredentialsContext())
+	awsCreds, err := identity.GetCredentials(c.GetContext(), a.Endpoint, a.Bucket, a.Key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get creds: %w", err)
 	}
-
-	var (
-		accessKeyID  string
-		accessSecret string
-	)
-	if creds != nil {
-		accessKeyID = creds.GetProperty(credentials.ATTR_AWS_ACCESS_KEY_ID)
-		accessSecret = creds.GetProperty(credentials.ATTR_AWS_SECRET_ACCESS_KEY)
-	}
-	var awsCreds *s3.AWSCreds
-	if accessKeyID != "" {
-		awsCreds = &s3.AWSCreds{
-			AccessKeyID:  accessKeyID,
-			AccessSecret: accessSecret,
-		}
-	}
-	var d downloader.Downloader = s3.NewDownloader(a.Region, a.Bucket, a.Key, a.Version, awsCreds)
+	var d downloader.Downloader = s3.NewDownloaderForEndpoint(a.Endpoint, a.Region, a.Bucket, a.Key, a.Version, awsCreds)
 	if a.downloader != nil {
 		d = a.downloader
 	}
@@ -143,22 +128,6 @@ func newMethod(c cpi.ComponentVersionAccess, a *AccessSpec) (*accessMethod, erro
 	}, nil
 }
 
-func getCreds(a *AccessSpec, cctx credentials.Context) (credentials.Credentials, error) {
-	id := credentials.ConsumerIdentity{
-		identity.ID_TYPE:     CONSUMER_TYPE,
-		identity.ID_HOSTNAME: a.Bucket,
-	}
-	if a.Version != "" {
-		id[identity.ID_PORT] = a.Version
-	}
-	id[identity.ID_PATHPREFIX] = path.Join(a.Bucket, a.Key, a.Version)
-	return credentials.CredentialsForConsumer(cctx,
-		credentials.ConsumerIdentity{
-			identity.ID_TYPE: CONSUMER_TYPE,
-		},
-		hostpath.IdentityMatcher(CONSUMER_TYPE))
-}
-
 func (m *accessMethod) GetKind() string {
 	return Type
 }
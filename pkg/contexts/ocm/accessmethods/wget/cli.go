@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package wget
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/wget/identity"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return configHandler(Type)
+}
+
+func configHandler(name string) flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		name, AddConfig,
+		options.URLOption,
+		options.MediatypeOption,
+		options.HTTPHeaderOption,
+		options.DigestOption,
+		options.NoRedirectOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.URLOption, config, "url")
+	flagsets.AddFieldByOptionP(opts, options.MediatypeOption, config, "mediaType")
+	flagsets.AddFieldByOptionP(opts, options.HTTPHeaderOption, config, "header")
+	flagsets.AddFieldByOptionP(opts, options.DigestOption, config, "digest")
+	flagsets.AddFieldByOptionP(opts, options.NoRedirectOption, config, "noRedirect")
+	return nil
+}
+
+var usage = `
+This method implements the access of a blob provided by an HTTP(S) server
+with a plain GET request. The type <code>http</code> is accepted as alias.
+
+Credentials for the request are taken from the credentials context for
+the consumer type <code>` + identity.CONSUMER_TYPE + `</code> using the
+hostname, port, scheme and URL path of the URL. The credential attributes
+<code>username</code> and <code>password</code> are used for basic
+authentication and the attribute <code>token</code> for bearer token
+authentication.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>url</code>** *string*
+
+  The URL of the blob to download.
+
+- **<code>mediaType</code>** (optional) *string*
+
+  The media type of the blob. The default is
+  <code>application/octet-stream</code>.
+
+- **<code>header</code>** (optional) *map[string]string*
+
+  Additional HTTP headers used for the request.
+
+- **<code>digest</code>** (optional) *string*
+
+  The digest (<code>&lt;algorithm>:&lt;hex value></code>) of the blob.
+  If given, the blob content is verified while it is downloaded.
+
+- **<code>noRedirect</code>** (optional) *bool*
+
+  Redirects are followed by default. If set to <code>true</code>,
+  redirects are rejected.
+`
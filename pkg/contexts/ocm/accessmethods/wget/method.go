@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package wget
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/wget/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+// Type is the access type for a blob provided by an HTTP(S) server.
+const (
+	Type   = "wget"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+
+	AliasType   = "http"
+	AliasTypeV1 = AliasType + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(Type, &AccessSpec{}, cpi.WithDescription(usage)))
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(TypeV1, &AccessSpec{}, cpi.WithFormatSpec(formatV1), cpi.WithConfigHandler(ConfigHandler())))
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(AliasType, &AccessSpec{}, cpi.WithConfigHandler(configHandler(AliasType))))
+	cpi.RegisterAccessType(cpi.NewAccessSpecType(AliasTypeV1, &AccessSpec{}))
+}
+
+// AccessSpec describes the access for a blob provided by an HTTP(S) server.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// URL is the URL of the blob.
+	URL string `json:"url"`
+	// MediaType is the media type of the blob.
+	// +optional
+	MediaType string `json:"mediaType,omitempty"`
+	// Header contains additional HTTP headers used for the request.
+	// +optional
+	Header map[string]string `json:"header,omitempty"`
+	// Digest is the expected digest of the blob.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// NoRedirect disables following redirects.
+	// +optional
+	NoRedirect bool `json:"noRedirect,omitempty"`
+}
+
+var _ cpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new HTTP access spec version v1.
+func New(url string, mediaType string, dig ...digest.Digest) *AccessSpec {
+	s := &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		URL:                 url,
+		MediaType:           mediaType,
+	}
+	if len(dig) > 0 {
+		s.Digest = dig[0].String()
+	}
+	return s
+}
+
+func (a *AccessSpec) Describe(ctx cpi.Context) string {
+	return fmt.Sprintf("HTTP resource %s", a.URL)
+}
+
+func (_ *AccessSpec) IsLocal(cpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(ctx cpi.Context) cpi.AccessSpec {
+	return a
+}
+
+func (a *AccessSpec) GetReferenceHint(cv cpi.ComponentVersionAccess) string {
+	return ""
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c cpi.ComponentVersionAccess) (cpi.AccessMethod, error) {
+	return newMethod(c, a)
+}
+
+func (a *AccessSpec) GetMimeType() string {
+	if a.MediaType == "" {
+		return mime.MIME_OCTET
+	}
+	return a.MediaType
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newMethod(c cpi.ComponentVersionAccess, a *AccessSpec) (cpi.AccessMethod, error) {
+	var dig digest.Digest
+	if a.Digest != "" {
+		d, err := digest.Parse(a.Digest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid digest %q for %s", a.Digest, a.URL)
+		}
+		dig = d
+	}
+	factory := func() (accessio.BlobAccess, error) {
+		creds, err := hostpath.GetCredentials(c.GetContext(), identity.CONSUMER_TYPE, a.URL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get credentials for %s", a.URL)
+		}
+		f := func() (io.ReadCloser, error) {
+			r, err := a.reader(creds)
+			if err != nil {
+				return nil, err
+			}
+			if dig != "" {
+				return accessio.VerifyingReader(r, dig), nil
+			}
+			return r, nil
+		}
+		acc := accessio.DataAccessForReaderFunction(f, a.URL)
+		return accessobj.CachedBlobAccessForWriter(c.GetContext(), a.GetMimeType(), accessio.NewDataAccessWriter(acc)), nil
+	}
+	return cpi.NewDefaultMethod(c, a, a.GetMimeType(), factory), nil
+}
+
+func (a *AccessSpec) reader(creds credentials.Credentials) (io.ReadCloser, error) {
+	c := &http.Client{}
+	if a.NoRedirect {
+		c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	req, err := httputils.NewRequest(http.MethodGet, a.URL, nil, creds)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range a.Header {
+		req.Header.Set(k, v)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, httputils.ResponseError(a.URL, resp)
+	}
+	return resp.Body, nil
+}
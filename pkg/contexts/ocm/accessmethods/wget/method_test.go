@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package wget_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/wget"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/wget/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const CONTENT = "some test content"
+
+var _ = Describe("Method", func() {
+	var ctx ocm.Context
+	var cv ocm.ComponentVersionAccess
+	var server *httptest.Server
+	var header http.Header
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		cv = &cpi.DummyComponentVersionAccess{ctx}
+		header = nil
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/blob", func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			w.Write([]byte(CONTENT))
+		})
+		mux.HandleFunc("/secure/blob", func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			if user, pass, ok := r.BasicAuth(); ok && user == "user" && pass == "pass" {
+				w.Write([]byte(CONTENT))
+				return
+			}
+			if r.Header.Get("Authorization") == "Bearer token" {
+				w.Write([]byte(CONTENT))
+				return
+			}
+			http.Error(w, "access denied", http.StatusUnauthorized)
+		})
+		mux.Handle("/redirect", http.RedirectHandler("/blob", http.StatusFound))
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("accesses blob", func() {
+		acc := wget.New(server.URL+"/blob", "")
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(mime.MIME_OCTET))
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("passes headers", func() {
+		acc := wget.New(server.URL+"/blob", mime.MIME_TEXT)
+		acc.Header = map[string]string{"X-Test": "value"}
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		Expect(header.Get("X-Test")).To(Equal("value"))
+	})
+
+	It("fails without credentials", func() {
+		acc := wget.New(server.URL+"/secure/blob", "")
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("401 Unauthorized: access denied")))
+	})
+
+	It("uses basic auth credentials", func() {
+		id := Must(hostpath.GetConsumerId(identity.CONSUMER_TYPE, server.URL+"/secure"))
+		ctx.CredentialsContext().SetCredentialsForConsumer(id, credentials.DirectCredentials{
+			credentials.ATTR_USERNAME: "user",
+			credentials.ATTR_PASSWORD: "pass",
+		})
+		acc := wget.New(server.URL+"/secure/blob", "")
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("uses bearer token credentials", func() {
+		id := Must(hostpath.GetConsumerId(identity.CONSUMER_TYPE, server.URL))
+		ctx.CredentialsContext().SetCredentialsForConsumer(id, credentials.DirectCredentials{
+			credentials.ATTR_TOKEN: "token",
+		})
+		acc := wget.New(server.URL+"/secure/blob", "")
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		Expect(header.Get("Authorization")).To(Equal("Bearer token"))
+	})
+
+	It("follows redirects", func() {
+		acc := wget.New(server.URL+"/redirect", "")
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("rejects redirects", func() {
+		acc := wget.New(server.URL+"/redirect", "")
+		acc.NoRedirect = true
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("302 Found")))
+	})
+
+	It("verifies digest", func() {
+		acc := wget.New(server.URL+"/blob", "", digest.FromString(CONTENT))
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("detects digest mismatch", func() {
+		acc := wget.New(server.URL+"/blob", "", digest.FromString("other"))
+
+		m := Must(acc.AccessMethod(cv))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("digest mismatch")))
+	})
+
+	It("rejects invalid digest", func() {
+		acc := wget.New(server.URL+"/blob", "")
+		acc.Digest = "invalid"
+
+		_, err := acc.AccessMethod(cv)
+		Expect(err).To(MatchError(ContainSubstring("invalid digest")))
+	})
+
+	It("decodes http alias", func() {
+		data := `{"type":"http","url":"https://example.com/blob","digest":"` + digest.FromString(CONTENT).String() + `"}`
+		spec := Must(ctx.AccessSpecForConfig([]byte(data), runtime.DefaultJSONEncoding))
+		Expect(spec).To(BeAssignableToTypeOf(&wget.AccessSpec{}))
+		Expect(spec.(*wget.AccessSpec).URL).To(Equal("https://example.com/blob"))
+	})
+})
@@ -10,4 +10,5 @@ import (
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/ociuploadattr"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/plugindirattr"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/verificationpolicyattr"
 )
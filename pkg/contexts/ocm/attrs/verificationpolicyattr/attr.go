@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package verificationpolicyattr
+
+import (
+	"reflect"
+
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/signing"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	ATTR_KEY   = "github.com/mandelsoft/ocm/verificationpolicy"
+	ATTR_SHORT = "verificationpolicy"
+)
+
+func init() {
+	datacontext.RegisterAttributeType(ATTR_KEY, AttributeType{}, ATTR_SHORT)
+}
+
+type AttributeType struct{}
+
+func (a AttributeType) Name() string {
+	return ATTR_KEY
+}
+
+func (a AttributeType) Description() string {
+	return `
+*JSON*
+Verification policy given as JSON document with the following format:
+
+<pre>
+{
+  "rules": [
+    {
+      "component": "&lt;name pattern>",
+      "signatures": [ "&lt;signature name>" ],
+      "issuers": [ "&lt;issuer>" ],
+      "subjects": [ "&lt;certificate subject>" ],
+      "verifyDigests": true,
+      "skipAccessTypes": [ "&lt;access type>" ]
+    }
+  ]
+}
+</pre>
+
+For every component version the first rule matching the component name
+is used to check the verification. This includes referenced component
+versions, whose signatures are verified if they are matched by a rule.
+`
+}
+
+func (a AttributeType) Encode(v interface{}, marshaller runtime.Marshaler) ([]byte, error) {
+	return marshaller.Marshal(v)
+}
+
+func (a AttributeType) Decode(data []byte, unmarshaller runtime.Unmarshaler) (interface{}, error) {
+	var value signing.VerificationPolicy
+	err := unmarshaller.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+	return &value, value.Validate()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Get provides the verification policy configured for a context.
+// If no policy is configured, nil is returned.
+func Get(ctx datacontext.Context) *signing.VerificationPolicy {
+	a := ctx.GetAttributes().GetAttribute(ATTR_KEY)
+	if a == nil {
+		return nil
+	}
+	return a.(*signing.VerificationPolicy)
+}
+
+func Set(ctx datacontext.Context, policy *signing.VerificationPolicy) error {
+	return ctx.GetAttributes().SetAttribute(ATTR_KEY, policy)
+}
+
+// Add adds rules to the verification policy of a context.
+// Rules already present in the policy are ignored, because
+// configurations may be applied multiple times.
+func Add(ctx datacontext.Context, rules ...signing.PolicyRule) error {
+	policy := &signing.VerificationPolicy{}
+	if old := Get(ctx); old != nil {
+		policy.AddRules(old.Rules...)
+	}
+	changed := false
+outer:
+	for _, r := range rules {
+		for _, o := range policy.Rules {
+			if reflect.DeepEqual(r, o) {
+				continue outer
+			}
+		}
+		policy.AddRules(r)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return Set(ctx, policy)
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package verificationpolicyattr_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/open-component-model/ocm/pkg/contexts/config"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/verificationpolicyattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/signing"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+var _ = Describe("attribute", func() {
+	var cfgctx config.Context
+
+	rule1 := signing.PolicyRule{
+		Component:     "acme.org/*",
+		Signatures:    []string{"acme"},
+		Issuers:       []string{"acme.org"},
+		VerifyDigests: true,
+	}
+	rule2 := signing.PolicyRule{
+		Component:       "*",
+		SkipAccessTypes: []string{"ociArtifact"},
+	}
+
+	BeforeEach(func() {
+		cfgctx = config.WithSharedAttributes(datacontext.New(nil)).New()
+	})
+
+	It("marshal/unmarshal", func() {
+		cfg := verificationpolicyattr.New(rule1)
+
+		data, err := json.Marshal(cfg)
+		Expect(err).To(Succeed())
+
+		r := &verificationpolicyattr.Config{}
+		Expect(json.Unmarshal(data, r)).To(Succeed())
+		Expect(r).To(Equal(cfg))
+	})
+
+	It("applies", func() {
+		Expect(verificationpolicyattr.Get(cfgctx)).To(BeNil())
+		Expect(cfgctx.ApplyConfig(verificationpolicyattr.New(rule1), "from test")).To(Succeed())
+		Expect(cfgctx.ApplyConfig(verificationpolicyattr.New(rule2), "from test")).To(Succeed())
+		Expect(verificationpolicyattr.Get(cfgctx)).To(Equal(&signing.VerificationPolicy{Rules: []signing.PolicyRule{rule1, rule2}}))
+	})
+
+	It("rejects invalid rules", func() {
+		cfg := verificationpolicyattr.New(signing.PolicyRule{Signatures: []string{"acme"}})
+		Expect(cfgctx.ApplyConfig(cfg, "from test")).To(HaveOccurred())
+	})
+
+	It("decodes attribute", func() {
+		data := `{"rules":[{"component":"acme.org/*","signatures":["acme"],"issuers":["acme.org"],"verifyDigests":true}]}`
+		Expect(cfgctx.GetAttributes().SetEncodedAttribute(verificationpolicyattr.ATTR_SHORT, []byte(data), runtime.DefaultJSONEncoding)).To(Succeed())
+		Expect(verificationpolicyattr.Get(cfgctx)).To(Equal(&signing.VerificationPolicy{Rules: []signing.PolicyRule{rule1}}))
+	})
+})
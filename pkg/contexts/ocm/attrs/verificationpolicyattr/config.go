@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package verificationpolicyattr
+
+import (
+	"github.com/open-component-model/ocm/pkg/contexts/config"
+	cfgcpi "github.com/open-component-model/ocm/pkg/contexts/config/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/signing"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	ConfigType   = "verificationpolicy" + cfgcpi.OCM_CONFIG_TYPE_SUFFIX
+	ConfigTypeV1 = ConfigType + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cfgcpi.RegisterConfigType(ConfigType, cfgcpi.NewConfigType(ConfigType, &Config{}, usage))
+	cfgcpi.RegisterConfigType(ConfigTypeV1, cfgcpi.NewConfigType(ConfigTypeV1, &Config{}, usage))
+}
+
+// Config describes a verification policy for component versions.
+type Config struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	Rules                       []signing.PolicyRule `json:"rules,omitempty"`
+}
+
+// New creates a new verification policy ConfigSpec.
+func New(rules ...signing.PolicyRule) *Config {
+	return &Config{
+		ObjectVersionedType: runtime.NewVersionedObjectType(ConfigType),
+		Rules:               rules,
+	}
+}
+
+func (a *Config) GetType() string {
+	return ConfigType
+}
+
+func (a *Config) AddRule(rule signing.PolicyRule) {
+	a.Rules = append(a.Rules, rule)
+}
+
+func (a *Config) ApplyTo(ctx cfgcpi.Context, target interface{}) error {
+	t, ok := target.(config.Context)
+	if !ok {
+		return cfgcpi.ErrNoContext(ConfigType)
+	}
+	policy := &signing.VerificationPolicy{Rules: a.Rules}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	return errors.Wrapf(Add(t, a.Rules...), "applying config failed")
+}
+
+const usage = `
+The config type <code>` + ConfigType + `</code> can be used to define
+a verification policy for component versions used by the <CMD>ocm verify</CMD>
+command. Once configured, the policy is always enforced and signatures
+are verified, regardless of the given command line options.
+
+The policy consists of a list of rules. For every verified component
+version the first rule matching the component name is used. Multiple
+configurations add their rules to the policy. A rule has the following
+fields:
+- <code>component</code>: the component name pattern (<code>*</code> matches
+  any sequence of characters)
+- <code>signatures</code>: the names of the signatures which must be present
+  and successfully verified
+- <code>issuers</code>: the list of allowed issuers of verified signatures
+- <code>subjects</code>: the list of allowed subjects (common name or
+  distinguished name) of the certificates used to verify signatures
+- <code>verifyDigests</code>: enforce the verification of the digests
+  of resources and references
+- <code>skipAccessTypes</code>: access types of resources not taken
+  into account for the digest calculation
+
+<pre>
+    type: ` + ConfigType + `
+    rules:
+      - component: acme.org/*
+        signatures:
+          - acme
+        issuers:
+          - acme.org
+        verifyDigests: true
+      ...
+</pre>
+`
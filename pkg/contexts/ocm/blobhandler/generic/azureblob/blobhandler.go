@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"path"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader/azureblob"
+	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// Config describes the target container of the blob handler.
+type Config struct {
+	// Account is the name of the storage account.
+	Account string `json:"account"`
+	// Container is the name of the blob container.
+	Container string `json:"container"`
+	// Prefix is an optional path prefix for the names of the stored blobs.
+	Prefix string `json:"prefix,omitempty"`
+	// Endpoint is an optional blob service endpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// blobHandler stores blobs in an Azure Blob Storage container.
+// The blobs are named by their digest.
+type blobHandler struct {
+	spec *Config
+}
+
+func NewBlobHandler(spec *Config) cpi.BlobHandler {
+	return &blobHandler{spec}
+}
+
+func (b *blobHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, global cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil || b.spec.Container == "" {
+		return nil, nil
+	}
+
+	dig := blob.Digest()
+	if dig == "" {
+		var err error
+		dig, err = accessio.Digest(blob)
+		if err != nil {
+			return nil, err
+		}
+	}
+	loc := azureblob.Location{
+		Endpoint:  b.spec.Endpoint,
+		Account:   b.spec.Account,
+		Container: b.spec.Container,
+		Blob:      path.Join(b.spec.Prefix, dig.Algorithm().String(), dig.Hex()),
+	}
+
+	values := []interface{}{
+		"arttype", artType,
+		"mediatype", blob.MimeType(),
+		"target", loc.URL(),
+	}
+	cpi.BlobHandlerLogger(ctx.GetContext()).Debug("azure blob handler", values...)
+
+	creds, err := identity.GetCredentials(ctx.GetContext(), b.spec.Endpoint, b.spec.Account, b.spec.Container)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get credentials for container %s", b.spec.Container)
+	}
+
+	r, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	err = azureblob.Upload(loc, creds, r, blob.MimeType())
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot upload blob to %s", loc.URL())
+	}
+	return access.New(loc.Account, loc.Container, loc.Blob, loc.Endpoint, blob.MimeType(), nil), nil
+}
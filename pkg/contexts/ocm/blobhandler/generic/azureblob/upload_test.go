@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob/identity"
+	v1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/registration"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+	ctfocm "github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+	tenv "github.com/open-component-model/ocm/pkg/env"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const COMP = "github.com/compa"
+const VERS = "1.0.0"
+const CA = "ca"
+const CTF = "ctf"
+const COPY = "ctf.copy"
+
+const CONTENT = "some text content"
+
+var _ = Describe("upload", func() {
+	var env *Builder
+	var server *httptest.Server
+	var lock sync.Mutex
+	var blobs map[string]string
+
+	BeforeEach(func() {
+		blobs = map[string]string{}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+			if r.URL.Query().Get("sig") != "secret" {
+				http.Error(w, "access denied", http.StatusForbidden)
+				return
+			}
+			switch r.Method {
+			case http.MethodPut:
+				data, _ := io.ReadAll(r.Body)
+				blobs[r.URL.Path] = string(data)
+				w.WriteHeader(http.StatusCreated)
+			case http.MethodGet:
+				if data, ok := blobs[r.URL.Path]; ok {
+					w.Write([]byte(data))
+					return
+				}
+				http.NotFound(w, r)
+			}
+		}))
+
+		env = NewBuilder(tenv.NewEnvironment())
+
+		env.ComponentArchive(CA, accessio.FormatDirectory, COMP, VERS, func() {
+			env.Provider("mandelsoft")
+			env.Resource("text", "", resourcetypes.PLAIN_TEXT, v1.LocalRelation, func() {
+				env.BlobStringData(mime.MIME_TEXT, CONTENT)
+			})
+		})
+
+		ca := Must(comparch.Open(env.OCMContext(), accessobj.ACC_READONLY, CA, 0, env))
+		oca := accessio.OnceCloser(ca)
+		defer Close(oca)
+
+		ctf := Must(ctfocm.Create(env.OCMContext(), accessobj.ACC_CREATE, CTF, 0o700, env))
+		octf := accessio.OnceCloser(ctf)
+		defer Close(octf)
+
+		handler := Must(standard.New(standard.ResourcesByValue()))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, ca, ctf, handler))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		env.Cleanup()
+	})
+
+	It("transfers blob with named handler", func() {
+		ctx := env.OCMContext()
+		endpoint := server.URL + "/acme"
+		ctx.CredentialsContext().SetCredentialsForConsumer(Must(identity.GetConsumerId(endpoint, "acme", "artifacts")), credentials.DirectCredentials{
+			credentials.ATTR_AZURE_SAS_TOKEN: "sig=secret",
+		})
+
+		ctf := Must(ctfocm.Open(ctx, accessobj.ACC_READONLY, CTF, 0o700, env))
+		defer Close(ctf, "ctf")
+
+		cv := Must(ctf.LookupComponentVersion(COMP, VERS))
+		ocv := accessio.OnceCloser(cv)
+		defer Close(ocv)
+
+		copy := Must(ctfocm.Create(ctx, accessobj.ACC_CREATE, COPY, 0o700, env))
+		ocopy := accessio.OnceCloser(copy)
+		defer Close(ocopy)
+
+		MustBeSuccessful(registration.RegisterBlobHandlerByName(ctx, "ocm/azureBlob", []byte(`{"account":"acme","container":"artifacts","prefix":"ocm","endpoint":"`+endpoint+`"}`)))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, copy, nil))
+
+		cv2 := Must(copy.LookupComponentVersion(COMP, VERS))
+		ocv2 := accessio.OnceCloser(cv2)
+		defer Close(ocv2)
+
+		dig := digest.FromString(CONTENT)
+		ra := Must(cv2.GetResourceByIndex(0))
+		acc := Must(ra.Access())
+		Expect(acc.GetKind()).To(Equal(azureblob.Type))
+		val := Must(ctx.AccessSpecForSpec(acc)).(*azureblob.AccessSpec)
+		Expect(val.BlobName).To(Equal("ocm/sha256/" + dig.Hex()))
+		Expect(val.MediaType).To(Equal(mime.MIME_TEXT))
+		Expect(blobs["/acme/artifacts/ocm/sha256/"+dig.Hex()]).To(Equal(CONTENT))
+
+		m := Must(ra.AccessMethod())
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("rejects incomplete config", func() {
+		err := registration.RegisterBlobHandlerByName(env.OCMContext(), "ocm/azureBlob", []byte(`{"account":"acme"}`))
+		Expect(err).To(MatchError(ContainSubstring("container required")))
+	})
+})
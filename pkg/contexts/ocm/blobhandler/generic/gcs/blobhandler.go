@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"path"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader/gcs"
+	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/gcs"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/gcs/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// Config describes the target bucket of the blob handler.
+type Config struct {
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// Prefix is an optional path prefix for the names of the stored objects.
+	Prefix string `json:"prefix,omitempty"`
+	// Endpoint is an optional storage API endpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// blobHandler stores blobs in a Google Cloud Storage bucket.
+// The objects are named by the blob digest.
+type blobHandler struct {
+	spec *Config
+}
+
+func NewBlobHandler(spec *Config) cpi.BlobHandler {
+	return &blobHandler{spec}
+}
+
+func (b *blobHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, global cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil || b.spec.Bucket == "" {
+		return nil, nil
+	}
+
+	dig := blob.Digest()
+	if dig == "" {
+		var err error
+		dig, err = accessio.Digest(blob)
+		if err != nil {
+			return nil, err
+		}
+	}
+	loc := gcs.Location{
+		Endpoint: b.spec.Endpoint,
+		Bucket:   b.spec.Bucket,
+		Object:   path.Join(b.spec.Prefix, dig.Algorithm().String(), dig.Hex()),
+	}
+
+	values := []interface{}{
+		"arttype", artType,
+		"mediatype", blob.MimeType(),
+		"target", loc.URL(),
+	}
+	cpi.BlobHandlerLogger(ctx.GetContext()).Debug("gcs blob handler", values...)
+
+	creds, err := identity.GetCredentials(ctx.GetContext(), b.spec.Endpoint, b.spec.Bucket)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get credentials for bucket %s", b.spec.Bucket)
+	}
+
+	r, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	generation, err := gcs.Upload(loc, creds, r, blob.MimeType())
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot upload blob to %s", loc.URL())
+	}
+	return access.New(loc.Bucket, loc.Object, generation, loc.Endpoint, blob.MimeType(), nil), nil
+}
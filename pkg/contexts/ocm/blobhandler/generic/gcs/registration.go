@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const BLOBHANDLER_NAME = "ocm/gcs"
+
+func init() {
+	cpi.RegisterBlobHandlerRegistrationHandler(BLOBHANDLER_NAME, &RegistrationHandler{})
+}
+
+type RegistrationHandler struct{}
+
+var _ cpi.BlobHandlerRegistrationHandler = (*RegistrationHandler)(nil)
+
+func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, config cpi.BlobHandlerConfig, olist ...cpi.BlobHandlerOption) (bool, error) {
+	if handler != "" {
+		return true, fmt.Errorf("invalid gcs handler %q", handler)
+	}
+	if config == nil {
+		return true, fmt.Errorf("gcs target specification required")
+	}
+
+	var cfg *Config
+	switch a := config.(type) {
+	case *Config:
+		cfg = a
+	case json.RawMessage:
+		cfg = &Config{}
+		err := runtime.DefaultYAMLEncoding.Unmarshal(a, cfg)
+		if err != nil {
+			return true, errors.Wrapf(err, "cannot unmarshal blob handler target configuration")
+		}
+	case []byte:
+		cfg = &Config{}
+		err := runtime.DefaultYAMLEncoding.Unmarshal(a, cfg)
+		if err != nil {
+			return true, errors.Wrapf(err, "cannot unmarshal blob handler target configuration")
+		}
+	default:
+		return true, fmt.Errorf("unexpected type %T for gcs handler target", a)
+	}
+	if cfg.Bucket == "" {
+		return true, fmt.Errorf("bucket required for gcs handler target")
+	}
+
+	// without artifact or mime type the handler is used for all blobs
+	ctx.BlobHandlers().Register(NewBlobHandler(cfg), cpi.NewBlobHandlerOptions(olist...))
+	return true, nil
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helm
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	ocihelm "github.com/open-component-model/ocm/pkg/contexts/oci/ociutils/helm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/helm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/helm/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+// Config describes the target chart repository of the blob handler.
+type Config struct {
+	// RepoURL is the URL of the ChartMuseum style chart repository.
+	RepoURL string `json:"repoUrl"`
+	// UploadURL is the URL used to upload charts. If not given,
+	// it is derived from the repository URL following the
+	// ChartMuseum convention.
+	UploadURL string `json:"uploadUrl,omitempty"`
+}
+
+// APIURL provides the ChartMuseum upload URL for the repository.
+// If no explicit upload URL is configured, the repository path is
+// inserted after the api prefix, as used by a multi-tenant server.
+func (c *Config) APIURL() (string, error) {
+	if c.UploadURL != "" {
+		if _, err := url.Parse(c.UploadURL); err != nil {
+			return "", errors.Wrapf(err, "invalid upload URL %q", c.UploadURL)
+		}
+		return c.UploadURL, nil
+	}
+	u, err := url.Parse(strings.TrimSuffix(c.RepoURL, "/"))
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid repository URL %q", c.RepoURL)
+	}
+	u.Path = "/api" + u.Path + "/charts"
+	return u.String(), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// chartHandler uploads helm charts to a ChartMuseum style chart repository.
+type chartHandler struct {
+	spec *Config
+}
+
+func NewChartHandler(repospec *Config) cpi.BlobHandler {
+	return &chartHandler{repospec}
+}
+
+func (b *chartHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, global cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil || b.spec.RepoURL == "" {
+		return nil, nil
+	}
+
+	if !ocihelm.IsChartMediaType(blob.MimeType()) {
+		return nil, nil
+	}
+
+	fs := memoryfs.New()
+	chart, chartpath, provpath, err := ocihelm.ExtractChart(blob, "/", fs)
+	if err != nil {
+		return nil, err
+	}
+
+	cpi.BlobHandlerLogger(ctx.GetContext()).Debug("helm chart repository handler",
+		"arttype", artType,
+		"mediatype", blob.MimeType(),
+		"chart", chart.Metadata.Name,
+		"version", chart.Metadata.Version,
+		"target", b.spec.RepoURL,
+	)
+
+	u, err := b.spec.APIURL()
+	if err != nil {
+		return nil, err
+	}
+	creds, err := hostpath.GetCredentials(ctx.GetContext(), identity.CONSUMER_TYPE, b.spec.RepoURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get credentials for %s", b.spec.RepoURL)
+	}
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	err = addFile(w, "chart", chartpath, fs)
+	if err == nil && provpath != "" {
+		err = addFile(w, "prov", provpath, fs)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot prepare chart upload")
+	}
+
+	// the upload URL is explicitly configured for the repository,
+	// therefore the repository credentials are used for it.
+	req, err := httputils.NewRequest(http.MethodPost, u, body, creds)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot prepare chart upload")
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot upload helm chart %s:%s", chart.Metadata.Name, chart.Metadata.Version)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errors.Wrapf(httputils.ResponseError(u, resp), "cannot upload helm chart %s:%s", chart.Metadata.Name, chart.Metadata.Version)
+	}
+	return helm.New(b.spec.RepoURL, chart.Metadata.Name+":"+chart.Metadata.Version), nil
+}
+
+func addFile(w *multipart.Writer, field, path string, fs vfs.FileSystem) error {
+	f, err := fs.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	part, err := w.CreateFormFile(field, vfs.Base(fs, path))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, f)
+	return err
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const BLOBHANDLER_NAME = "ocm/helmChartRepository"
+
+func init() {
+	cpi.RegisterBlobHandlerRegistrationHandler(BLOBHANDLER_NAME, &RegistrationHandler{})
+}
+
+type RegistrationHandler struct{}
+
+var _ cpi.BlobHandlerRegistrationHandler = (*RegistrationHandler)(nil)
+
+func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, config cpi.BlobHandlerConfig, olist ...cpi.BlobHandlerOption) (bool, error) {
+	if handler != "" {
+		return true, fmt.Errorf("invalid helmChartRepository handler %q", handler)
+	}
+	if config == nil {
+		return true, fmt.Errorf("helm chart repository target specification required")
+	}
+
+	var cfg *Config
+	switch a := config.(type) {
+	case *Config:
+		cfg = a
+	case json.RawMessage:
+		cfg = &Config{}
+		err := runtime.DefaultYAMLEncoding.Unmarshal(a, cfg)
+		if err != nil {
+			return true, errors.Wrapf(err, "cannot unmarshal blob handler target configuration")
+		}
+	case []byte:
+		cfg = &Config{}
+		err := runtime.DefaultYAMLEncoding.Unmarshal(a, cfg)
+		if err != nil {
+			return true, errors.Wrapf(err, "cannot unmarshal blob handler target configuration")
+		}
+	default:
+		return true, fmt.Errorf("unexpected type %T for helm chart repository blob handler target", a)
+	}
+	if cfg.RepoURL == "" {
+		return true, fmt.Errorf("repository URL required for helm chart repository blob handler target")
+	}
+
+	opts := cpi.NewBlobHandlerOptions(olist...)
+	if opts.ArtifactType == "" {
+		opts.ArtifactType = resourcetypes.HELM_CHART
+	}
+	ctx.BlobHandlers().Register(NewChartHandler(cfg), opts)
+	return true, nil
+}
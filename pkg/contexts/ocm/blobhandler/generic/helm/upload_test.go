@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helm_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/helm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localblob"
+	v1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/registration"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+	ctfocm "github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+	tenv "github.com/open-component-model/ocm/pkg/env"
+)
+
+const COMP = "github.com/compa"
+const VERS = "1.0.0"
+const CA = "ca"
+const CTF = "ctf"
+const COPY = "ctf.copy"
+
+// chartMuseum is a minimal multi-tenant ChartMuseum fake serving
+// the tenant directories found in its root directory.
+type chartMuseum struct {
+	dir string
+}
+
+func (c *chartMuseum) index(tenant string) {
+	index := Must(repo.IndexDirectory(filepath.Join(c.dir, tenant), ""))
+	MustBeSuccessful(index.WriteFile(filepath.Join(c.dir, tenant, "index.yaml"), 0o644))
+}
+
+func (c *chartMuseum) store(tenant string, name string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Join(c.dir, tenant), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.dir, tenant, name), data, 0o644)
+}
+
+func (c *chartMuseum) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.FileServer(http.Dir(c.dir)).ServeHTTP(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/api/") || !strings.HasSuffix(r.URL.Path, "/charts") {
+		http.NotFound(w, r)
+		return
+	}
+	tenant := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/"), "/charts")
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, field := range []string{"chart", "prov"} {
+		f, h, err := r.FormFile(field)
+		if err != nil {
+			continue
+		}
+		err = c.store(tenant, h.Filename, f)
+		f.Close()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	c.index(tenant)
+	w.WriteHeader(http.StatusCreated)
+}
+
+var _ = Describe("upload", func() {
+	var env *Builder
+	var museum *chartMuseum
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		env = NewBuilder(tenv.NewEnvironment())
+
+		museum = &chartMuseum{Must(os.MkdirTemp("", "chartmuseum-"))}
+		// the chart museum is additionally served under a context path
+		mux := http.NewServeMux()
+		mux.Handle("/museum/", http.StripPrefix("/museum", museum))
+		mux.Handle("/", museum)
+		server = httptest.NewServer(mux)
+
+		src := filepath.Join(museum.dir, "source")
+		MustBeSuccessful(os.MkdirAll(src, 0o755))
+		Must(chartutil.Save(&chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: chart.APIVersionV2,
+				Name:       "testchart",
+				Version:    "0.1.0",
+			},
+			Templates: []*chart.File{
+				{Name: "templates/configmap.yaml", Data: []byte("kind: ConfigMap\n")},
+			},
+		}, src))
+		MustBeSuccessful(os.WriteFile(filepath.Join(src, "testchart-0.1.0.tgz.prov"), []byte("provenance"), 0o644))
+		museum.index("source")
+
+		env.ComponentArchive(CA, accessio.FormatDirectory, COMP, VERS, func() {
+			env.Provider("mandelsoft")
+			env.Resource("chart", "", resourcetypes.HELM_CHART, v1.LocalRelation, func() {
+				env.Access(helm.New(server.URL+"/source", "testchart:0.1.0"))
+			})
+		})
+
+		ca := Must(comparch.Open(env.OCMContext(), accessobj.ACC_READONLY, CA, 0, env))
+		oca := accessio.OnceCloser(ca)
+		defer Close(oca)
+
+		ctf := Must(ctfocm.Create(env.OCMContext(), accessobj.ACC_CREATE, CTF, 0o700, env))
+		octf := accessio.OnceCloser(ctf)
+		defer Close(octf)
+
+		handler := Must(standard.New(standard.ResourcesByValue()))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, ca, ctf, handler))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(museum.dir)
+		env.Cleanup()
+	})
+
+	It("transfers helm chart with named handler", func() {
+		ctx := env.OCMContext()
+
+		ctf := Must(ctfocm.Open(ctx, accessobj.ACC_READONLY, CTF, 0o700, env))
+		defer Close(ctf, "ctf")
+
+		cv := Must(ctf.LookupComponentVersion(COMP, VERS))
+		ocv := accessio.OnceCloser(cv)
+		defer Close(ocv)
+		ra := Must(cv.GetResourceByIndex(0))
+		acc := Must(ra.Access())
+		Expect(acc.GetKind()).To(Equal(localblob.Type))
+
+		copy := Must(ctfocm.Create(ctx, accessobj.ACC_CREATE, COPY, 0o700, env))
+		ocopy := accessio.OnceCloser(copy)
+		defer Close(ocopy)
+
+		MustBeSuccessful(registration.RegisterBlobHandlerByName(ctx, "ocm/helmChartRepository", []byte(`{"repoUrl":"`+server.URL+`/target"}`)))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, copy, nil))
+
+		cv2 := Must(copy.LookupComponentVersion(COMP, VERS))
+		ocv2 := accessio.OnceCloser(cv2)
+		defer Close(ocv2)
+		ra = Must(cv2.GetResourceByIndex(0))
+		acc = Must(ra.Access())
+		Expect(acc.GetKind()).To(Equal(helm.Type))
+		val := Must(ctx.AccessSpecForSpec(acc))
+		Expect(val.(*helm.AccessSpec).HelmRepository).To(Equal(server.URL + "/target"))
+		Expect(val.(*helm.AccessSpec).HelmChart).To(Equal("testchart:0.1.0"))
+
+		Expect(filepath.Join(museum.dir, "target", "testchart-0.1.0.tgz")).To(BeARegularFile())
+		Expect(os.ReadFile(filepath.Join(museum.dir, "target", "testchart-0.1.0.tgz.prov"))).To(Equal([]byte("provenance")))
+
+		m := Must(ra.AccessMethod())
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(helm.MimeType))
+		Expect(len(Must(m.Get())) > 0).To(BeTrue())
+	})
+
+	It("transfers helm chart with explicit upload URL", func() {
+		ctx := env.OCMContext()
+
+		ctf := Must(ctfocm.Open(ctx, accessobj.ACC_READONLY, CTF, 0o700, env))
+		defer Close(ctf, "ctf")
+
+		cv := Must(ctf.LookupComponentVersion(COMP, VERS))
+		ocv := accessio.OnceCloser(cv)
+		defer Close(ocv)
+
+		copy := Must(ctfocm.Create(ctx, accessobj.ACC_CREATE, COPY, 0o700, env))
+		ocopy := accessio.OnceCloser(copy)
+		defer Close(ocopy)
+
+		repo := server.URL + "/museum/target"
+		MustBeSuccessful(registration.RegisterBlobHandlerByName(ctx, "ocm/helmChartRepository", []byte(`{"repoUrl":"`+repo+`","uploadUrl":"`+server.URL+`/museum/api/target/charts"}`)))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, copy, nil))
+
+		cv2 := Must(copy.LookupComponentVersion(COMP, VERS))
+		ocv2 := accessio.OnceCloser(cv2)
+		defer Close(ocv2)
+		ra := Must(cv2.GetResourceByIndex(0))
+		acc := Must(ra.Access())
+		Expect(acc.GetKind()).To(Equal(helm.Type))
+		val := Must(ctx.AccessSpecForSpec(acc))
+		Expect(val.(*helm.AccessSpec).HelmRepository).To(Equal(repo))
+
+		Expect(filepath.Join(museum.dir, "target", "testchart-0.1.0.tgz")).To(BeARegularFile())
+
+		m := Must(ra.AccessMethod())
+		defer Close(m)
+		Expect(len(Must(m.Get())) > 0).To(BeTrue())
+	})
+})
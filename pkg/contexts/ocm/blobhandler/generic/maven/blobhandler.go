@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// Config describes the target Maven repository of the blob handler.
+type Config struct {
+	// RepoURL is the base URL of the target Maven repository.
+	// <code>file://</code> URLs describe a repository in the
+	// file system of the OCM context.
+	RepoURL string `json:"repoUrl"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// artifactHandler stores Maven artifact files in a Maven repository.
+type artifactHandler struct {
+	spec *Config
+}
+
+func NewArtifactHandler(repospec *Config) cpi.BlobHandler {
+	return &artifactHandler{repospec}
+}
+
+func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, global cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil || b.spec.RepoURL == "" {
+		return nil, nil
+	}
+
+	var coords *maven.Coordinates
+	if g, ok := global.(*maven.AccessSpec); ok {
+		c := g.Coordinates
+		coords = &c
+	} else {
+		c, err := maven.ParseCoordinates(hint)
+		if err != nil {
+			return nil, nil
+		}
+		coords = c
+	}
+
+	values := []interface{}{
+		"arttype", artType,
+		"mediatype", blob.MimeType(),
+		"coordinates", coords.String(),
+		"target", b.spec.RepoURL,
+	}
+	cpi.BlobHandlerLogger(ctx.GetContext()).Debug("maven artifact handler", values...)
+
+	creds, err := hostpath.GetCredentials(ctx.GetContext(), identity.CONSUMER_TYPE, b.spec.RepoURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get credentials for %s", b.spec.RepoURL)
+	}
+	repo := maven.NewRepository(b.spec.RepoURL, vfsattr.Get(ctx.GetContext()), creds)
+
+	r, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	err = repo.Upload(coords, r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot upload %s to %s", coords, b.spec.RepoURL)
+	}
+
+	spec := maven.NewForCoordinates(b.spec.RepoURL, coords)
+	if blob.MimeType() != coords.MimeType() {
+		spec.MediaType = blob.MimeType()
+	}
+	return spec, nil
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven"
+	v1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/registration"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+	ctfocm "github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+	tenv "github.com/open-component-model/ocm/pkg/env"
+)
+
+const COMP = "github.com/compa"
+const VERS = "1.0.0"
+const CA = "ca"
+const CTF = "ctf"
+const COPY = "ctf.copy"
+const TARGET = "/tmp/maven"
+
+const CONTENT = "jar content"
+const COORDS = "com.acme:lib:1.0.0"
+const JAR_PATH = "com/acme/lib/1.0.0/lib-1.0.0.jar"
+
+var _ = Describe("upload", func() {
+	var env *Builder
+
+	BeforeEach(func() {
+		env = NewBuilder(tenv.NewEnvironment())
+
+		env.ComponentArchive(CA, accessio.FormatDirectory, COMP, VERS, func() {
+			env.Provider("mandelsoft")
+			env.Resource("lib", "", resourcetypes.MAVEN_ARTIFACT, v1.LocalRelation, func() {
+				env.BlobStringData(maven.MIME_JAR, CONTENT)
+				env.Hint(COORDS)
+			})
+			env.Resource("other", "", resourcetypes.PLAIN_TEXT, v1.LocalRelation, func() {
+				env.BlobStringData(maven.MIME_JAR, CONTENT)
+				env.Hint(COORDS)
+			})
+		})
+
+		ca := Must(comparch.Open(env.OCMContext(), accessobj.ACC_READONLY, CA, 0, env))
+		oca := accessio.OnceCloser(ca)
+		defer Close(oca)
+
+		ctf := Must(ctfocm.Create(env.OCMContext(), accessobj.ACC_CREATE, CTF, 0o700, env))
+		octf := accessio.OnceCloser(ctf)
+		defer Close(octf)
+
+		handler := Must(standard.New(standard.ResourcesByValue()))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, ca, ctf, handler))
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("transfers maven artifact with named handler", func() {
+		ctx := env.OCMContext()
+
+		ctf := Must(ctfocm.Open(ctx, accessobj.ACC_READONLY, CTF, 0o700, env))
+		defer Close(ctf, "ctf")
+
+		cv := Must(ctf.LookupComponentVersion(COMP, VERS))
+		ocv := accessio.OnceCloser(cv)
+		defer Close(ocv)
+
+		copy := Must(ctfocm.Create(ctx, accessobj.ACC_CREATE, COPY, 0o700, env))
+		ocopy := accessio.OnceCloser(copy)
+		defer Close(ocopy)
+
+		MustBeSuccessful(registration.RegisterBlobHandlerByName(ctx, "ocm/mavenRepository", []byte(`{"repoUrl":"file://`+TARGET+`"}`)))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, copy, nil))
+
+		cv2 := Must(copy.LookupComponentVersion(COMP, VERS))
+		ocv2 := accessio.OnceCloser(cv2)
+		defer Close(ocv2)
+
+		ra := Must(cv2.GetResourceByIndex(0))
+		acc := Must(ra.Access())
+		Expect(acc.GetKind()).To(Equal(maven.Type))
+		val := Must(ctx.AccessSpecForSpec(acc))
+		Expect(val.(*maven.AccessSpec).RepoURL).To(Equal("file://" + TARGET))
+		Expect(val.(*maven.AccessSpec).Coordinates.String()).To(Equal(COORDS))
+		Expect(vfs.ReadFile(env.FileSystem(), TARGET+"/"+JAR_PATH)).To(Equal([]byte(CONTENT)))
+		Expect(vfs.FileExists(env.FileSystem(), TARGET+"/"+JAR_PATH+".sha1")).To(BeTrue())
+		Expect(vfs.FileExists(env.FileSystem(), TARGET+"/"+JAR_PATH+".sha256")).To(BeTrue())
+
+		m := Must(ra.AccessMethod())
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+
+		// other resource types are not uploaded
+		ra = Must(cv2.GetResourceByIndex(1))
+		acc = Must(ra.Access())
+		Expect(acc.GetKind()).To(Equal(localblob.Type))
+	})
+})
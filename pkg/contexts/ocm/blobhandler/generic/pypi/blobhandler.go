@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pypi
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/pypi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/pypi/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+// Config describes the target package index of the blob handler.
+type Config struct {
+	// URL is the upload URL of the package index (legacy upload API),
+	// for example https://upload.pypi.org/legacy/.
+	URL string `json:"url"`
+	// Registry is the base URL of the simple repository API of the
+	// package index used for the resulting access specifications.
+	Registry string `json:"registry"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// packageHandler uploads Python package files to a package index.
+type packageHandler struct {
+	spec *Config
+}
+
+func NewPackageHandler(repospec *Config) cpi.BlobHandler {
+	return &packageHandler{repospec}
+}
+
+// packageInfo describes the package file to upload.
+type packageInfo struct {
+	name     string
+	version  string
+	filename string
+}
+
+// getPackageInfo determines the package file from the global access
+// specification or the reference hint <package>:<version>[:<filename>].
+func getPackageInfo(hint string, global cpi.AccessSpec) *packageInfo {
+	if g, ok := global.(*pypi.AccessSpec); ok {
+		return &packageInfo{g.Package, g.Version, g.Filename}
+	}
+	parts := strings.Split(hint, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return nil
+	}
+	info := &packageInfo{name: parts[0], version: parts[1]}
+	if len(parts) > 2 {
+		info.filename = parts[2]
+	}
+	return info
+}
+
+func (b *packageHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, global cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil || b.spec.URL == "" {
+		return nil, nil
+	}
+	info := getPackageInfo(hint, global)
+	if info == nil {
+		return nil, nil
+	}
+	if info.filename == "" {
+		info.filename = pypi.SdistFilename(info.name, info.version)
+	}
+	_, _, filetype, err := pypi.ParseFilename(info.filename)
+	if err != nil {
+		return nil, err
+	}
+	pyversion := "source"
+	if filetype == pypi.FILETYPE_WHEEL {
+		pyversion = pypi.WheelPythonTag(info.filename)
+	}
+
+	cpi.BlobHandlerLogger(ctx.GetContext()).Debug("python package index handler",
+		"arttype", artType,
+		"mediatype", blob.MimeType(),
+		"package", info.name,
+		"version", info.version,
+		"filename", info.filename,
+		"target", b.spec.URL,
+	)
+
+	data, err := blob.Get()
+	if err != nil {
+		return nil, err
+	}
+	sha := sha256.Sum256(data)
+	md := md5.Sum(data) //nolint: gosec // md5 digest is part of the upload API
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	fields := [][2]string{
+		{":action", "file_upload"},
+		{"protocol_version", "1"},
+		{"metadata_version", "2.1"},
+		{"name", info.name},
+		{"version", info.version},
+		{"filetype", filetype},
+		{"pyversion", pyversion},
+		{"sha256_digest", hex.EncodeToString(sha[:])},
+		{"md5_digest", hex.EncodeToString(md[:])},
+	}
+	for _, f := range fields {
+		err = w.WriteField(f[0], f[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	part, err := w.CreateFormFile("content", info.filename)
+	if err == nil {
+		_, err = io.Copy(part, bytes.NewReader(data))
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot prepare package upload")
+	}
+
+	creds, err := hostpath.GetCredentials(ctx.GetContext(), identity.CONSUMER_TYPE, b.spec.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get credentials for %s", b.spec.URL)
+	}
+	index := pypi.NewIndex(b.spec.URL, vfsattr.Get(ctx.GetContext()), creds)
+	resp, err := index.Request(http.MethodPost, b.spec.URL, w.FormDataContentType(), body, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot upload python package %s", info.filename)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errors.Wrapf(httputils.ResponseError(b.spec.URL, resp), "cannot upload python package %s", info.filename)
+	}
+	return pypi.New(b.spec.Registry, info.name, info.version, info.filename), nil
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pypi_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/pypi"
+	v1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/registration"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+	ctfocm "github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+	tenv "github.com/open-component-model/ocm/pkg/env"
+)
+
+const COMP = "github.com/compa"
+const VERS = "1.0.0"
+const CA = "ca"
+const CTF = "ctf"
+const COPY = "ctf.copy"
+
+const WHEEL = "my_pkg-1.0.0-py3-none-any.whl"
+const CONTENT = "wheel content"
+
+// packageIndex is a minimal package index fake supporting the legacy
+// upload API and the JSON variant of the simple repository API.
+type packageIndex struct {
+	lock   sync.Mutex
+	files  map[string]map[string]string
+	fields map[string]string
+}
+
+func (p *packageIndex) add(project, filename, content string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	project = pypi.NormalizeName(project)
+	if p.files[project] == nil {
+		p.files[project] = map[string]string{}
+	}
+	p.files[project][filename] = content
+}
+
+func (p *packageIndex) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/legacy/":
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f, h, err := r.FormFile("content")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		data := Must(io.ReadAll(f))
+		p.lock.Lock()
+		for k, v := range r.MultipartForm.Value {
+			p.fields[k] = v[0]
+		}
+		p.lock.Unlock()
+		p.add(r.FormValue("name"), h.Filename, string(data))
+	case strings.HasPrefix(r.URL.Path, "/simple/"):
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		files, ok := p.files[strings.Trim(strings.TrimPrefix(r.URL.Path, "/simple/"), "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		var list []pypi.File
+		for n := range files {
+			list = append(list, pypi.File{Filename: n, URL: "/files/" + n})
+		}
+		w.Header().Set("Content-Type", pypi.MIME_SIMPLE_JSON)
+		json.NewEncoder(w).Encode(map[string]interface{}{"files": list})
+	case strings.HasPrefix(r.URL.Path, "/files/"):
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		name := strings.TrimPrefix(r.URL.Path, "/files/")
+		for _, files := range p.files {
+			if data, ok := files[name]; ok {
+				w.Write([]byte(data))
+				return
+			}
+		}
+		http.NotFound(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+var _ = Describe("upload", func() {
+	var env *Builder
+	var source *packageIndex
+	var target *packageIndex
+	var sourceServer *httptest.Server
+	var targetServer *httptest.Server
+
+	BeforeEach(func() {
+		env = NewBuilder(tenv.NewEnvironment())
+
+		source = &packageIndex{files: map[string]map[string]string{}, fields: map[string]string{}}
+		source.add("my-pkg", WHEEL, CONTENT)
+		sourceServer = httptest.NewServer(source)
+		target = &packageIndex{files: map[string]map[string]string{}, fields: map[string]string{}}
+		targetServer = httptest.NewServer(target)
+
+		env.ComponentArchive(CA, accessio.FormatDirectory, COMP, VERS, func() {
+			env.Provider("mandelsoft")
+			env.Resource("package", "", resourcetypes.PYTHON_PACKAGE, v1.LocalRelation, func() {
+				env.Access(pypi.New(sourceServer.URL+"/simple", "my-pkg", "1.0.0", WHEEL))
+			})
+		})
+
+		ca := Must(comparch.Open(env.OCMContext(), accessobj.ACC_READONLY, CA, 0, env))
+		oca := accessio.OnceCloser(ca)
+		defer Close(oca)
+
+		ctf := Must(ctfocm.Create(env.OCMContext(), accessobj.ACC_CREATE, CTF, 0o700, env))
+		octf := accessio.OnceCloser(ctf)
+		defer Close(octf)
+
+		handler := Must(standard.New(standard.ResourcesByValue()))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, ca, ctf, handler))
+	})
+
+	AfterEach(func() {
+		sourceServer.Close()
+		targetServer.Close()
+		env.Cleanup()
+	})
+
+	It("transfers python package with named handler", func() {
+		ctx := env.OCMContext()
+
+		ctf := Must(ctfocm.Open(ctx, accessobj.ACC_READONLY, CTF, 0o700, env))
+		defer Close(ctf, "ctf")
+
+		cv := Must(ctf.LookupComponentVersion(COMP, VERS))
+		ocv := accessio.OnceCloser(cv)
+		defer Close(ocv)
+		ra := Must(cv.GetResourceByIndex(0))
+		acc := Must(ra.Access())
+		Expect(acc.GetKind()).To(Equal(localblob.Type))
+
+		copy := Must(ctfocm.Create(ctx, accessobj.ACC_CREATE, COPY, 0o700, env))
+		ocopy := accessio.OnceCloser(copy)
+		defer Close(ocopy)
+
+		config := `{"url":"` + targetServer.URL + `/legacy/","registry":"` + targetServer.URL + `/simple"}`
+		MustBeSuccessful(registration.RegisterBlobHandlerByName(ctx, "ocm/pypiRepository", []byte(config)))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, copy, nil))
+
+		cv2 := Must(copy.LookupComponentVersion(COMP, VERS))
+		ocv2 := accessio.OnceCloser(cv2)
+		defer Close(ocv2)
+		ra = Must(cv2.GetResourceByIndex(0))
+		acc = Must(ra.Access())
+		Expect(acc.GetKind()).To(Equal(pypi.Type))
+		val := Must(ctx.AccessSpecForSpec(acc))
+		Expect(val).To(Equal(pypi.New(targetServer.URL+"/simple", "my-pkg", "1.0.0", WHEEL)))
+
+		Expect(target.files["my-pkg"]).To(Equal(map[string]string{WHEEL: CONTENT}))
+		Expect(target.fields).To(HaveKeyWithValue("filetype", pypi.FILETYPE_WHEEL))
+		Expect(target.fields).To(HaveKeyWithValue("pyversion", "py3"))
+		Expect(target.fields).To(HaveKeyWithValue(":action", "file_upload"))
+
+		m := Must(ra.AccessMethod())
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+})
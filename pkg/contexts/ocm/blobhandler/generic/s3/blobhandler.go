@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package s3
+
+import (
+	"path"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader/s3"
+	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/s3"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/s3/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// Config describes the target bucket of the blob handler.
+type Config struct {
+	// Region is the region of the bucket. If not set, it is determined
+	// from the bucket.
+	Region string `json:"region,omitempty"`
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// Prefix is an optional key prefix for the stored objects.
+	Prefix string `json:"prefix,omitempty"`
+	// Endpoint is the URL of an S3 compatible service like MinIO.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// blobHandler stores blobs in an S3 bucket.
+// The objects are keyed by the blob digest.
+type blobHandler struct {
+	spec *Config
+}
+
+func NewBlobHandler(spec *Config) cpi.BlobHandler {
+	return &blobHandler{spec}
+}
+
+func (b *blobHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, global cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil || b.spec.Bucket == "" {
+		return nil, nil
+	}
+
+	dig := blob.Digest()
+	if dig == "" {
+		var err error
+		dig, err = accessio.Digest(blob)
+		if err != nil {
+			return nil, err
+		}
+	}
+	key := path.Join(b.spec.Prefix, dig.Algorithm().String(), dig.Hex())
+
+	values := []interface{}{
+		"arttype", artType,
+		"mediatype", blob.MimeType(),
+		"bucket", b.spec.Bucket,
+		"key", key,
+	}
+	cpi.BlobHandlerLogger(ctx.GetContext()).Debug("s3 blob handler", values...)
+
+	creds, err := identity.GetCredentials(ctx.GetContext(), b.spec.Endpoint, b.spec.Bucket, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get credentials for bucket %s", b.spec.Bucket)
+	}
+
+	r, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	version, err := s3.NewUploader(b.spec.Endpoint, b.spec.Region, b.spec.Bucket, creds).Upload(key, blob.MimeType(), r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot upload blob to bucket %s", b.spec.Bucket)
+	}
+	spec := access.New(b.spec.Region, b.spec.Bucket, key, version, blob.MimeType(), nil)
+	spec.Endpoint = b.spec.Endpoint
+	return spec, nil
+}
@@ -0,0 +1,321 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package s3_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/s3"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/s3/identity"
+	v1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/registration"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+	ctfocm "github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+	tenv "github.com/open-component-model/ocm/pkg/env"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const COMP = "github.com/compa"
+const VERS = "1.0.0"
+const CA = "ca"
+const CTF = "ctf"
+const COPY = "ctf.copy"
+
+const CONTENT = "some text content"
+
+// fakeS3 implements the object and multipart upload operations
+// of the S3 API required by the uploader and downloader.
+type fakeS3 struct {
+	lock      sync.Mutex
+	objects   map[string][]byte
+	types     map[string]string
+	uploads   map[string]map[int][]byte
+	multipart int
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		objects: map[string][]byte{},
+		types:   map[string]string{},
+		uploads: map[string]map[int][]byte{},
+	}
+}
+
+type completeUpload struct {
+	Parts []struct {
+		PartNumber int
+	} `xml:"Part"`
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !strings.Contains(r.Header.Get("Authorization"), "Credential=access/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	q := r.URL.Query()
+	data, _ := io.ReadAll(r.Body)
+	key := r.URL.Path
+	switch {
+	case r.Method == http.MethodPost && q.Has("uploads"):
+		id := fmt.Sprintf("upload-%d", len(s.uploads))
+		s.uploads[id] = map[int][]byte{}
+		s.types[key] = r.Header.Get("Content-Type")
+		fmt.Fprintf(w, `<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>`, id)
+	case r.Method == http.MethodPut && q.Has("uploadId"):
+		var n int
+		fmt.Sscanf(q.Get("partNumber"), "%d", &n)
+		s.uploads[q.Get("uploadId")][n] = data
+		w.Header().Set("ETag", fmt.Sprintf(`"%d"`, n))
+	case r.Method == http.MethodPost && q.Has("uploadId"):
+		var c completeUpload
+		if xml.Unmarshal(data, &c) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		buf := &bytes.Buffer{}
+		for _, p := range c.Parts {
+			buf.Write(s.uploads[q.Get("uploadId")][p.PartNumber])
+		}
+		s.objects[key] = buf.Bytes()
+		s.multipart++
+		w.Header().Set("x-amz-version-id", "v1")
+		fmt.Fprintf(w, `<CompleteMultipartUploadResult><Key>%s</Key></CompleteMultipartUploadResult>`, key)
+	case r.Method == http.MethodPut:
+		s.objects[key] = data
+		s.types[key] = r.Header.Get("Content-Type")
+		w.Header().Set("x-amz-version-id", "v1")
+	case r.Method == http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok || q.Get("versionId") != "v1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeContent(w, r, key, time.Time{}, bytes.NewReader(data))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+var _ = Describe("upload", func() {
+	var env *Builder
+	var fake *fakeS3
+	var server *httptest.Server
+	var large []byte
+
+	BeforeEach(func() {
+		fake = newFakeS3()
+		server = httptest.NewServer(fake)
+		large = bytes.Repeat([]byte("0123456789abcdef"), 12*1024*1024/16)
+
+		env = NewBuilder(tenv.NewEnvironment())
+
+		env.ComponentArchive(CA, accessio.FormatDirectory, COMP, VERS, func() {
+			env.Provider("mandelsoft")
+			env.Resource("text", "", resourcetypes.PLAIN_TEXT, v1.LocalRelation, func() {
+				env.BlobStringData(mime.MIME_TEXT, CONTENT)
+			})
+			env.Resource("data", "", resourcetypes.BLOB, v1.LocalRelation, func() {
+				env.BlobData(mime.MIME_OCTET, large)
+			})
+		})
+
+		ca := Must(comparch.Open(env.OCMContext(), accessobj.ACC_READONLY, CA, 0, env))
+		oca := accessio.OnceCloser(ca)
+		defer Close(oca)
+
+		ctf := Must(ctfocm.Create(env.OCMContext(), accessobj.ACC_CREATE, CTF, 0o700, env))
+		octf := accessio.OnceCloser(ctf)
+		defer Close(octf)
+
+		handler := Must(standard.New(standard.ResourcesByValue()))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, ca, ctf, handler))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		env.Cleanup()
+	})
+
+	transferTo := func(ctx ocm.Context, config string, check func(cv ocm.ComponentVersionAccess), opts ...registration.BlobHandlerOption) {
+		ctf := Must(ctfocm.Open(ctx, accessobj.ACC_READONLY, CTF, 0o700, env))
+		defer Close(ctf, "ctf")
+
+		cv := Must(ctf.LookupComponentVersion(COMP, VERS))
+		ocv := accessio.OnceCloser(cv)
+		defer Close(ocv)
+
+		copy := Must(ctfocm.Create(ctx, accessobj.ACC_CREATE, COPY, 0o700, env))
+		ocopy := accessio.OnceCloser(copy)
+		defer Close(ocopy)
+
+		MustBeSuccessful(registration.RegisterBlobHandlerByName(ctx, "ocm/s3", []byte(config), opts...))
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, copy, nil))
+
+		cv2 := Must(copy.LookupComponentVersion(COMP, VERS))
+		ocv2 := accessio.OnceCloser(cv2)
+		defer Close(ocv2)
+		check(cv2)
+	}
+
+	It("transfers blobs with named handler", func() {
+		ctx := env.OCMContext()
+		ctx.CredentialsContext().SetCredentialsForConsumer(credentials.ConsumerIdentity{credentials.ID_TYPE: s3.CONSUMER_TYPE}, credentials.DirectCredentials{
+			credentials.ATTR_AWS_ACCESS_KEY_ID:     "access",
+			credentials.ATTR_AWS_SECRET_ACCESS_KEY: "secret",
+		})
+
+		transferTo(ctx, `{"bucket":"ocm","prefix":"blobs","endpoint":"`+server.URL+`"}`, func(cv ocm.ComponentVersionAccess) {
+			dig := digest.FromString(CONTENT)
+			ra := Must(cv.GetResourceByIndex(0))
+			acc := Must(ra.Access())
+			Expect(acc.GetKind()).To(Equal(s3.Type))
+			val := Must(ctx.AccessSpecForSpec(acc)).(*s3.AccessSpec)
+			Expect(val.Bucket).To(Equal("ocm"))
+			Expect(val.Key).To(Equal("blobs/sha256/" + dig.Hex()))
+			Expect(val.Version).To(Equal("v1"))
+			Expect(val.Endpoint).To(Equal(server.URL))
+			Expect(val.MediaType).To(Equal(mime.MIME_TEXT))
+			Expect(fake.types["/ocm/blobs/sha256/"+dig.Hex()]).To(Equal(mime.MIME_TEXT))
+
+			m := Must(ra.AccessMethod())
+			defer Close(m)
+			Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+
+			// large blobs use multipart upload
+			Expect(fake.multipart).To(Equal(1))
+			ra = Must(cv.GetResourceByIndex(1))
+			m2 := Must(ra.AccessMethod())
+			defer Close(m2)
+			Expect(Must(m2.Get())).To(Equal(large))
+		})
+	})
+
+	It("transfers blobs for artifact type", func() {
+		ctx := env.OCMContext()
+		ctx.CredentialsContext().SetCredentialsForConsumer(credentials.ConsumerIdentity{credentials.ID_TYPE: s3.CONSUMER_TYPE}, credentials.DirectCredentials{
+			credentials.ATTR_AWS_ACCESS_KEY_ID:     "access",
+			credentials.ATTR_AWS_SECRET_ACCESS_KEY: "secret",
+		})
+
+		transferTo(ctx, `{"bucket":"ocm","endpoint":"`+server.URL+`"}`, func(cv ocm.ComponentVersionAccess) {
+			ra := Must(cv.GetResourceByIndex(0))
+			Expect(Must(ra.Access()).GetKind()).To(Equal(localblob.Type))
+			ra = Must(cv.GetResourceByIndex(1))
+			Expect(Must(ra.Access()).GetKind()).To(Equal(s3.Type))
+		}, registration.ForArtifactType(resourcetypes.BLOB))
+	})
+
+	It("selects credentials by endpoint and bucket", func() {
+		ctx := env.OCMContext()
+		creds := ctx.CredentialsContext()
+		u := Must(url.Parse(server.URL))
+		creds.SetCredentialsForConsumer(credentials.ConsumerIdentity{credentials.ID_TYPE: s3.CONSUMER_TYPE}, credentials.DirectCredentials{
+			credentials.ATTR_AWS_ACCESS_KEY_ID:     "other",
+			credentials.ATTR_AWS_SECRET_ACCESS_KEY: "secret",
+		})
+		creds.SetCredentialsForConsumer(credentials.ConsumerIdentity{
+			identity.ID_TYPE:       identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME:   u.Hostname(),
+			identity.ID_PORT:       u.Port(),
+			identity.ID_PATHPREFIX: "other",
+		}, credentials.DirectCredentials{
+			credentials.ATTR_AWS_ACCESS_KEY_ID:     "other",
+			credentials.ATTR_AWS_SECRET_ACCESS_KEY: "secret",
+		})
+		creds.SetCredentialsForConsumer(credentials.ConsumerIdentity{
+			identity.ID_TYPE:       identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME:   u.Hostname(),
+			identity.ID_PORT:       u.Port(),
+			identity.ID_PATHPREFIX: "ocm",
+		}, credentials.DirectCredentials{
+			credentials.ATTR_AWS_ACCESS_KEY_ID:     "access",
+			credentials.ATTR_AWS_SECRET_ACCESS_KEY: "secret",
+		})
+
+		transferTo(ctx, `{"bucket":"ocm","prefix":"blobs","endpoint":"`+server.URL+`"}`, func(cv ocm.ComponentVersionAccess) {
+			ra := Must(cv.GetResourceByIndex(0))
+			Expect(Must(ra.Access()).GetKind()).To(Equal(s3.Type))
+			m := Must(ra.AccessMethod())
+			defer Close(m)
+			Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		})
+	})
+
+	It("provides consumer identities", func() {
+		Expect(identity.GetConsumerId("", "bucket", "dir/key")).To(Equal(credentials.ConsumerIdentity{
+			identity.ID_TYPE:       identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME:   identity.AWS_HOSTNAME,
+			identity.ID_PATHPREFIX: "bucket/dir/key",
+		}))
+		Expect(identity.GetConsumerId("http://localhost:9000", "bucket", "key")).To(Equal(credentials.ConsumerIdentity{
+			identity.ID_TYPE:       identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME:   "localhost",
+			identity.ID_PORT:       "9000",
+			identity.ID_SCHEME:     "http",
+			identity.ID_PATHPREFIX: "bucket/key",
+		}))
+	})
+
+	It("rejects incomplete config", func() {
+		err := registration.RegisterBlobHandlerByName(env.OCMContext(), "ocm/s3", []byte(`{"prefix":"ocm"}`))
+		Expect(err).To(MatchError(ContainSubstring("bucket required")))
+	})
+
+	Context("minio", func() {
+		// set MINIO_ENDPOINT to the URL of a MinIO instance with an
+		// existing bucket "ocm" and MINIO_ACCESS_KEY and MINIO_SECRET_KEY
+		// to its credentials.
+		It("transfers blobs", func() {
+			endpoint := os.Getenv("MINIO_ENDPOINT")
+			if endpoint == "" {
+				Skip("no MinIO configured")
+			}
+			ctx := env.OCMContext()
+			ctx.CredentialsContext().SetCredentialsForConsumer(credentials.ConsumerIdentity{credentials.ID_TYPE: s3.CONSUMER_TYPE}, credentials.DirectCredentials{
+				credentials.ATTR_AWS_ACCESS_KEY_ID:     os.Getenv("MINIO_ACCESS_KEY"),
+				credentials.ATTR_AWS_SECRET_ACCESS_KEY: os.Getenv("MINIO_SECRET_KEY"),
+			})
+
+			transferTo(ctx, `{"bucket":"ocm","prefix":"test","endpoint":"`+endpoint+`"}`, func(cv ocm.ComponentVersionAccess) {
+				for i, data := range [][]byte{[]byte(CONTENT), large} {
+					ra := Must(cv.GetResourceByIndex(i))
+					Expect(Must(ra.Access()).GetKind()).To(Equal(s3.Type))
+					m := Must(ra.AccessMethod())
+					Expect(Must(m.Get())).To(Equal(data))
+					MustBeSuccessful(m.Close())
+				}
+			})
+		})
+	})
+})
@@ -5,7 +5,13 @@
 package blobhandler
 
 import (
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/generic/azureblob"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/generic/gcs"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/generic/helm"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/generic/maven"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/generic/ocirepo"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/generic/pypi"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/generic/s3"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/oci/ocirepo"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/ocm/comparch"
 )
@@ -6,9 +6,11 @@ package oci
 
 import (
 	"reflect"
+	"sync"
 
 	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
 
+	"github.com/open-component-model/ocm/pkg/common/accessio"
 	"github.com/open-component-model/ocm/pkg/contexts/oci"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
@@ -23,6 +25,10 @@ type StorageContext struct {
 	Repository cpi.Repository
 	Namespace  cpi.NamespaceAccess
 	Manifest   cpi.ManifestAccess
+	// LayerLock serializes the modification of the layer list of
+	// the manifest. It must be shared by all storage contexts used
+	// for the same manifest to support concurrent blob storage.
+	LayerLock sync.Locker
 }
 
 var _ ocmcpi.StorageContext = (*StorageContext)(nil)
@@ -40,6 +46,7 @@ func New(comprepo ocmcpi.Repository, vers ocmcpi.ComponentVersionAccess, impltyp
 		Repository: ocirepo,
 		Namespace:  namespace,
 		Manifest:   manifest,
+		LayerLock:  &sync.Mutex{},
 	}
 }
 
@@ -51,7 +58,26 @@ func (s *StorageContext) TargetComponentVersion() ocmcpi.ComponentVersionAccess
 	return s.ComponentVersion
 }
 
+// AssureBlob stores a blob in the namespace, if it is not yet present.
+// The existence is only checked for blobs with a known digest,
+// to avoid reading the blob just to determine its digest.
+// A skipped upload is reported to the blob (see accessio.StorageObserver).
+func (s *StorageContext) AssureBlob(blob cpi.BlobAccess) error {
+	if blob.DigestKnown() {
+		if c, ok := s.Namespace.(cpi.BlobChecker); ok {
+			if found, size, err := c.HasBlob(blob.Digest()); err == nil && found {
+				accessio.NotifySkipped(blob, size)
+				return nil
+			}
+		}
+	}
+	return s.Manifest.AddBlob(blob)
+}
+
 func (s *StorageContext) AssureLayer(blob cpi.BlobAccess) error {
+	s.LayerLock.Lock()
+	defer s.LayerLock.Unlock()
+
 	d := artdesc.DefaultBlobDescriptor(blob)
 	desc := s.Manifest.GetDescriptor()
 
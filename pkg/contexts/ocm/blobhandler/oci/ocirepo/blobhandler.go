@@ -17,6 +17,7 @@ import (
 	"github.com/open-component-model/ocm/pkg/common/accessobj"
 	"github.com/open-component-model/ocm/pkg/contexts/oci"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	ocicpi "github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocireg"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer"
@@ -89,7 +90,7 @@ func (b *blobHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, globa
 		cpi.BlobHandlerLogger(ctx.GetContext()).Debug("oci blob handler", values...)
 	}
 
-	err := ocictx.Manifest.AddBlob(blob)
+	err := ocictx.AssureBlob(blob)
 	if err != nil {
 		return nil, err
 	}
@@ -192,6 +193,7 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 
 	errhint += " namespace " + namespace.GetNamespace()
 
+	var src *artifactset.ArtifactSet
 	if art == nil {
 		log.Debug("using artifact set transfer mode")
 		set, err := artifactset.OpenFromBlob(accessobj.ACC_READONLY, blob)
@@ -199,6 +201,7 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 			return nil, wrap(err, errhint, "open blob")
 		}
 		defer set.Close()
+		src = set
 		digest = set.GetMain()
 		art, err = set.GetArtifact(digest.String())
 		if err != nil {
@@ -214,16 +217,37 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 		version = "@" + digest.String()
 	}
 
-	err = transfer.TransferArtifact(art, namespace, oci.AsTags(tag)...)
-	if err != nil {
-		return nil, wrap(err, errhint, "transfer artifact")
+	if src == nil && hasArtifact(namespace, digest) {
+		// the artifact is already present in the target namespace,
+		// so only the tag has to be set.
+		log.Debug("artifact already present in target", append(values, "digest", digest)...)
+		if tag != "" {
+			err = namespace.AddTags(digest, tag)
+			if err != nil {
+				return nil, wrap(err, errhint, "tag artifact")
+			}
+		}
+		if size, err := ocicpi.ArtifactSize(art); err == nil {
+			accessio.NotifySkipped(blob, size)
+		}
+	} else {
+		err = transfer.TransferArtifact(art, namespace, oci.AsTags(tag)...)
+		if err != nil {
+			return nil, wrap(err, errhint, "transfer artifact")
+		}
+	}
+	if src != nil {
+		err = transfer.TransferAttachedArtifacts(src, digest, namespace)
+		if err != nil {
+			return nil, wrap(err, errhint, "transfer attached artifacts")
+		}
 	}
 
 	ref := path.Join(base, namespace.GetNamespace()) + version
 	var acc cpi.AccessSpec = ociartifact.New(ref)
 
 	if keep {
-		err := ocictx.Manifest.AddBlob(blob)
+		err := ocictx.AssureBlob(blob)
 		if err != nil {
 			return nil, wrap(err, errhint, "store local blob")
 		}
@@ -236,6 +260,17 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 	return acc, nil
 }
 
+// hasArtifact checks whether the namespace already provides
+// an artifact with the given manifest digest.
+func hasArtifact(namespace oci.NamespaceAccess, digest digest.Digest) bool {
+	a, err := namespace.GetArtifact(digest.String())
+	if err != nil {
+		return false
+	}
+	a.Close()
+	return true
+}
+
 func wrap(err error, msg string, args ...interface{}) error {
 	for _, a := range args {
 		msg = fmt.Sprintf("%s: %s", msg, a)
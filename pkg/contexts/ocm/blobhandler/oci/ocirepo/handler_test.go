@@ -19,6 +19,7 @@ import (
 	"github.com/open-component-model/ocm/pkg/common/accessobj"
 	"github.com/open-component-model/ocm/pkg/contexts/oci"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	ocicpi "github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
 	ocictf "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
 	storagecontext "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/oci"
@@ -29,6 +30,7 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/genericocireg"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
 	"github.com/open-component-model/ocm/pkg/mime"
 )
@@ -122,4 +124,34 @@ var _ = Describe("oci artifact transfer", func() {
 		data = Must(blob.Get())
 		Expect(string(data)).To(Equal(OCILAYER))
 	})
+
+	It("it should skip artifacts already present in delta mode", func() {
+		env.OCMContext().BlobHandlers().Register(ocirepo.NewArtifactHandler(FakeOCIRegBaseFunction),
+			cpi.ForRepo(oci.CONTEXT_TYPE, ocictf.Type), cpi.ForMimeType(artdesc.ToContentMediaType(artdesc.MediaTypeImageManifest)))
+
+		srcrepo := Must(env.OCIContext().RepositoryForSpec(env.OCIContext().GetAlias(OCIHOST)))
+		defer Close(srcrepo)
+		srcart := Must(srcrepo.LookupArtifact(OCINAMESPACE, OCIVERSION))
+		defer Close(srcart)
+		artsize := Must(ocicpi.ArtifactSize(srcart))
+
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src)
+		cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(cv)
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt)
+
+		handler := Must(standard.New(standard.ResourcesByValue(), standard.Overwrite(), standard.Delta()))
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, tgt, handler))
+		Expect(transferhandler.GetSavedBytes(handler)).To(Equal(int64(0)))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, tgt, handler))
+		Expect(transferhandler.GetSavedBytes(handler)).To(Equal(int64(8) + artsize))
+
+		comp := Must(tgt.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(comp)
+		data := Must(json.Marshal(comp.GetDescriptor().Resources[1].Access))
+		Expect(string(data)).To(StringEqualWithContext("{\"imageReference\":\"baseurl.io/ocm/value:v2.0\",\"type\":\"ociArtifact\"}"))
+	})
 })
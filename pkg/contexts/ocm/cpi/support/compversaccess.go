@@ -7,6 +7,9 @@ package support
 import (
 	"fmt"
 	"strconv"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
 
 	"github.com/open-component-model/ocm/pkg/common/accessio"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
@@ -56,6 +59,9 @@ func (s *ComponentVersionAccess) EnablePersistence() {
 ////////////////////////////////////////////////////////////////////////////////
 
 type componentVersionAccessImpl struct {
+	// lock serializes modifications of the descriptor and the
+	// underlying container to support concurrent artifact transfers.
+	lock           sync.Mutex
 	refs           accessio.ReferencableCloser
 	lazy           bool
 	discardChanges bool
@@ -114,6 +120,15 @@ func (a *componentVersionAccessImpl) GetVersion() string {
 	return a.base.GetDescriptor().GetVersion()
 }
 
+// HasLocalBlob checks whether the underlying container already
+// provides a blob with the given digest.
+func (a *componentVersionAccessImpl) HasLocalBlob(digest digest.Digest) (bool, int64, error) {
+	if c, ok := a.base.(cpi.LocalBlobChecker); ok {
+		return c.HasLocalBlob(digest)
+	}
+	return false, accessio.BLOB_UNKNOWN_SIZE, nil
+}
+
 func (a *componentVersionAccessImpl) AddBlob(blob cpi.BlobAccess, artType, refName string, global cpi.AccessSpec) (cpi.AccessSpec, error) {
 	if blob == nil {
 		return nil, errors.New("a resource has to be defined")
@@ -129,6 +144,8 @@ func (a *componentVersionAccessImpl) AddBlob(blob cpi.BlobAccess, artType, refNa
 			return acc, nil
 		}
 	}
+	// the blob upload is not serialized here to support
+	// concurrent transfers, the container synchronizes its own state.
 	return a.base.AddBlobFor(storagectx, blob, refName, global)
 }
 
@@ -144,6 +161,14 @@ func (a *componentVersionAccessImpl) GetDescriptor() *compdesc.ComponentDescript
 	return a.base.GetDescriptor()
 }
 
+// ReadDescriptor calls the given function with the component descriptor
+// while concurrent modifications are blocked.
+func (a *componentVersionAccessImpl) ReadDescriptor(f func(cd *compdesc.ComponentDescriptor)) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	f(a.base.GetDescriptor())
+}
+
 func (a *componentVersionAccessImpl) GetResource(id metav1.Identity) (cpi.ResourceAccess, error) {
 	r, err := a.base.GetDescriptor().GetResourceByIdentity(id)
 	if err != nil {
@@ -265,6 +290,9 @@ func (c *componentVersionAccessImpl) AdjustResourceAccess(meta *cpi.ResourceMeta
 		}
 	}
 
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	cd := c.GetDescriptor()
 	if idx := cd.GetResourceIndex(meta); idx == -1 {
 		return errors.ErrUnknown(cpi.KIND_RESOURCE, meta.GetIdentity(cd.Resources).String())
@@ -272,7 +300,7 @@ func (c *componentVersionAccessImpl) AdjustResourceAccess(meta *cpi.ResourceMeta
 		cd.Resources[idx].Access = acc
 	}
 
-	return c.Update(false)
+	return c.update(false)
 }
 
 func (c *componentVersionAccessImpl) checkAccessSpec(acc compdesc.AccessSpec) error {
@@ -304,6 +332,9 @@ func (c *componentVersionAccessImpl) SetResource(meta *cpi.ResourceMeta, acc com
 		}
 	}
 
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	cd := c.GetDescriptor()
 	if idx := cd.GetResourceIndex(meta); idx == -1 {
 		cd.Resources = append(cd.Resources, *res)
@@ -314,7 +345,7 @@ func (c *componentVersionAccessImpl) SetResource(meta *cpi.ResourceMeta, acc com
 		}
 		cd.Resources[idx] = *res
 	}
-	return c.Update(false)
+	return c.update(false)
 }
 
 func (c *componentVersionAccessImpl) SetSource(meta *cpi.SourceMeta, acc compdesc.AccessSpec) error {
@@ -338,12 +369,15 @@ func (c *componentVersionAccessImpl) SetSource(meta *cpi.SourceMeta, acc compdes
 		res.Version = c.GetVersion()
 	}
 
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	if idx := c.GetDescriptor().GetSourceIndex(meta); idx == -1 {
 		c.GetDescriptor().Sources = append(c.GetDescriptor().Sources, *res)
 	} else {
 		c.GetDescriptor().Sources[idx] = *res
 	}
-	return c.Update(false)
+	return c.update(false)
 }
 
 // AddResource adds a blob resource to the current archive.
@@ -378,12 +412,15 @@ func (c *componentVersionAccessImpl) SetSourceBlob(meta *cpi.SourceMeta, blob cp
 ////////////////////////////////////////////////////////////////////////////////
 
 func (c *componentVersionAccessImpl) SetReference(ref *cpi.ComponentReference) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	if idx := c.GetDescriptor().GetComponentReferenceIndex(*ref); idx == -1 {
 		c.GetDescriptor().References = append(c.GetDescriptor().References, *ref)
 	} else {
 		c.GetDescriptor().References[idx] = *ref
 	}
-	return c.Update(false)
+	return c.update(false)
 }
 
 func (a *componentVersionAccessImpl) DiscardChanges() {
@@ -391,6 +428,12 @@ func (a *componentVersionAccessImpl) DiscardChanges() {
 }
 
 func (a *componentVersionAccessImpl) Update(final bool) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.update(final)
+}
+
+func (a *componentVersionAccessImpl) update(final bool) error {
 	if (final || !a.lazy) && !a.discardChanges {
 		return a.base.Update()
 	}
@@ -27,6 +27,8 @@ type BlobContainer interface {
 	// The resulting access information (global and local) is provided as
 	// an access method specification usable in a component descriptor.
 	// This is the direct technical storage, without caring about any handler.
+	// It may be called concurrently for different blobs, so modifications
+	// of internal state must be synchronized by the implementation.
 	AddBlobFor(storagectx cpi.StorageContext, blob cpi.BlobAccess, refName string, global cpi.AccessSpec) (cpi.AccessSpec, error)
 }
 
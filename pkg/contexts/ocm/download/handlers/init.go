@@ -8,4 +8,5 @@ import (
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/download/handlers/blob"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/download/handlers/executable"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/download/handlers/helm"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/download/handlers/sbom"
 )
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"io"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/compression"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/download"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/sbom"
+)
+
+const TYPE = resourcetypes.SBOM
+
+// Handler downloads a single SBOM resource. The document is
+// decompressed and validated before it is written.
+type Handler struct{}
+
+func init() {
+	download.RegisterForArtifactType(TYPE, &Handler{})
+}
+
+func wrapErr(err error, racc cpi.ResourceAccess) error {
+	if err == nil {
+		return nil
+	}
+	m := racc.Meta()
+	return errors.Wrapf(err, "resource %s/%s%s", m.GetName(), m.GetVersion(), m.ExtraIdentity.String())
+}
+
+// Read reads and validates the SBOM document of a resource.
+func Read(racc cpi.ResourceAccess) (*sbom.Document, error) {
+	rd, err := cpi.ResourceReader(racc)
+	if err != nil {
+		return nil, wrapErr(err, racc)
+	}
+	defer rd.Close()
+
+	r, _, err := compression.AutoDecompress(rd)
+	if err != nil {
+		return nil, wrapErr(err, racc)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, wrapErr(err, racc)
+	}
+	doc, err := sbom.Parse(data)
+	return doc, wrapErr(err, racc)
+}
+
+func (_ Handler) Download(p common.Printer, racc cpi.ResourceAccess, path string, fs vfs.FileSystem) (bool, string, error) {
+	doc, err := Read(racc)
+	if err != nil {
+		return true, "", err
+	}
+	return true, path, wrapErr(write(p, doc, path, fs), racc)
+}
+
+func write(p common.Printer, doc *sbom.Document, path string, fs vfs.FileSystem) error {
+	data, err := doc.Data()
+	if err != nil {
+		return err
+	}
+	err = vfs.WriteFile(fs, path, data, 0o660)
+	if err != nil {
+		return errors.Wrapf(err, "creating target file %q", path)
+	}
+	p.Printf("%s: %s SBOM with %d byte(s) written\n", path, doc.Format, len(data))
+	return nil
+}
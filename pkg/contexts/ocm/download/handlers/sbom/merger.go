@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"sync"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/download"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/sbom"
+)
+
+// Merger is a download handler collecting the SBOM documents of
+// all downloaded resources instead of writing them. The collected
+// documents can finally be written as single merged document.
+type Merger struct {
+	lock sync.Mutex
+	info sbom.Info
+	docs []*sbom.Document
+}
+
+var _ download.Handler = (*Merger)(nil)
+
+// NewMerger creates a merger for the SBOM of the given product.
+func NewMerger(info sbom.Info) *Merger {
+	return &Merger{info: info}
+}
+
+func (m *Merger) Download(p common.Printer, racc cpi.ResourceAccess, path string, fs vfs.FileSystem) (bool, string, error) {
+	if racc.Meta().GetType() != TYPE {
+		return false, "", nil
+	}
+	err := m.Add(racc)
+	if err != nil {
+		return true, "", err
+	}
+	p.Printf("%s: SBOM collected\n", racc.Meta().GetName())
+	return true, path, nil
+}
+
+// Add adds the SBOM document of a resource.
+func (m *Merger) Add(racc cpi.ResourceAccess) error {
+	doc, err := Read(racc)
+	if err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.docs = append(m.docs, doc)
+	return nil
+}
+
+// AddClosure adds the SBOM resources of a component version and all
+// component versions referenced by it. The references are resolved
+// by the given resolver or the repository of the component version.
+func (m *Merger) AddClosure(cv cpi.ComponentVersionAccess, resolver cpi.ComponentVersionResolver) error {
+	if resolver == nil {
+		resolver = cv.Repository()
+	}
+	return m.addClosure(cv, resolver, common.History{})
+}
+
+func (m *Merger) addClosure(cv cpi.ComponentVersionAccess, resolver cpi.ComponentVersionResolver, hist common.History) error {
+	nv := common.VersionedElementKey(cv)
+	if hist.Contains(nv) {
+		return nil
+	}
+	hist = hist.Append(nv)
+	for _, r := range cv.GetResources() {
+		if r.Meta().GetType() != TYPE {
+			continue
+		}
+		if err := m.Add(r); err != nil {
+			return errors.Wrapf(err, "%s", hist)
+		}
+	}
+	for _, ref := range cv.GetDescriptor().References {
+		ncv, err := resolver.LookupComponentVersion(ref.ComponentName, ref.Version)
+		if err != nil {
+			return errors.Wrapf(err, "%s: cannot resolve reference %s", hist, ref.Name)
+		}
+		if ncv == nil {
+			return errors.Wrapf(errors.ErrNotFound(cpi.KIND_COMPONENTVERSION, common.NewNameVersion(ref.ComponentName, ref.Version).String()), "%s", hist)
+		}
+		err = m.addClosure(ncv, resolver, hist)
+		ncv.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Len returns the number of collected documents.
+func (m *Merger) Len() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return len(m.docs)
+}
+
+// Merge merges the collected documents.
+func (m *Merger) Merge() (*sbom.Document, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return sbom.Merge(m.info, m.docs...)
+}
+
+// Write writes the merged document to the given file.
+func (m *Merger) Write(p common.Printer, path string, fs vfs.FileSystem) error {
+	doc, err := m.Merge()
+	if err != nil {
+		return err
+	}
+	return write(p, doc, path, fs)
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	sbomhdlr "github.com/open-component-model/ocm/pkg/contexts/ocm/download/handlers/sbom"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/sbom"
+)
+
+const (
+	ARCH     = "/tmp/ctf"
+	PROVIDER = "acme.org"
+	VERSION  = "v1"
+	COMP     = "acme.org/app"
+	COMP2    = "acme.org/product"
+	OUT      = "/tmp/sbom.json"
+)
+
+func bom(name string) string {
+	return `{"spdxVersion": "SPDX-2.3", "dataLicense": "CC0-1.0", "SPDXID": "SPDXRef-DOCUMENT", "name": "` + name + `",
+  "documentNamespace": "https://acme.org/` + name + `", "creationInfo": {"created": "2023-01-01T00:00:00Z"},
+  "packages": [{"SPDXID": "SPDXRef-Package", "name": "` + name + `"}]}`
+}
+
+var _ = Describe("sbom merger", func() {
+	var env *Builder
+
+	BeforeEach(func() {
+		env = NewBuilder(nil)
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMP, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					env.Resource("sbom", "", resourcetypes.SBOM, metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_SPDX_JSON, bom("app"))
+					})
+				})
+			})
+			env.Component(COMP2, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					env.Resource("sbom", "", resourcetypes.SBOM, metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_SPDX_JSON, bom("product"))
+					})
+					env.Resource("text", "", resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, "text")
+					})
+					env.Reference("app", COMP, VERSION)
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("merges SBOMs of closure", func() {
+		repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo)
+		cv := Must(repo.LookupComponentVersion(COMP2, VERSION))
+		defer Close(cv)
+
+		merger := sbomhdlr.NewMerger(sbom.Info{Name: COMP2, Version: VERSION})
+		MustBeSuccessful(merger.AddClosure(cv, nil))
+		Expect(merger.Len()).To(Equal(2))
+
+		MustBeSuccessful(merger.Write(common.NewPrinter(nil), OUT, env))
+		doc := Must(sbom.Parse(Must(env.ReadFile(OUT))))
+		Expect(doc.Content["name"]).To(Equal(COMP2 + "-" + VERSION))
+
+		var names []interface{}
+		for _, p := range doc.Content["packages"].([]interface{}) {
+			names = append(names, p.(map[string]interface{})["name"])
+		}
+		Expect(names).To(Equal([]interface{}{"product", "app"}))
+	})
+})
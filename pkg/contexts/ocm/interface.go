@@ -35,6 +35,8 @@ type (
 	ComponentLister                  = internal.ComponentLister
 	ComponentAccess                  = internal.ComponentAccess
 	ComponentVersionAccess           = internal.ComponentVersionAccess
+	LocalBlobChecker                 = internal.LocalBlobChecker
+	DescriptorReader                 = internal.DescriptorReader
 	AccessSpec                       = internal.AccessSpec
 	HintProvider                     = internal.HintProvider
 	AccessMethod                     = internal.AccessMethod
@@ -7,6 +7,8 @@ package internal
 import (
 	"io"
 
+	"github.com/opencontainers/go-digest"
+
 	"github.com/open-component-model/ocm/pkg/common"
 	"github.com/open-component-model/ocm/pkg/common/accessio"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
@@ -122,6 +124,23 @@ type ComponentVersionAccess interface {
 	io.Closer
 }
 
+// LocalBlobChecker is an optional interface of a ComponentVersionAccess
+// able to check whether a blob with a given digest is already
+// stored together with the component version.
+type LocalBlobChecker interface {
+	// HasLocalBlob checks whether a blob with the given digest is
+	// available for the component version and returns its size.
+	HasLocalBlob(digest digest.Digest) (bool, int64, error)
+}
+
+// DescriptorReader is an optional interface of a ComponentVersionAccess
+// supporting concurrent modifications of its component descriptor.
+type DescriptorReader interface {
+	// ReadDescriptor calls the given function with the component
+	// descriptor, while modifications are blocked.
+	ReadDescriptor(func(cd *compdesc.ComponentDescriptor))
+}
+
 // ComponentLister provides the optional repository list functionality of
 // a repository.
 type ComponentLister interface {
@@ -82,6 +82,19 @@ func (p *pluginImpl) GetActionDescriptor(name string) *descriptor.ActionDescript
 	return nil
 }
 
+func (p *pluginImpl) GetCredentialProviderDescriptor(name string) *descriptor.CredentialProviderDescriptor {
+	if !p.IsValid() {
+		return nil
+	}
+
+	for _, c := range p.descriptor.CredentialProviders {
+		if c.Name == name {
+			return &c
+		}
+	}
+	return nil
+}
+
 func (p *pluginImpl) GetAccessMethodDescriptor(name, version string) *descriptor.AccessMethodDescriptor {
 	if !p.IsValid() {
 		return nil
@@ -35,6 +35,9 @@ func DescribePluginDescriptor(d *descriptor.Descriptor, out common.Printer) {
 	if len(d.Actions) > 0 {
 		caps = append(caps, "Actions")
 	}
+	if len(d.CredentialProviders) > 0 {
+		caps = append(caps, "Credential Providers")
+	}
 	if len(caps) == 0 {
 		out.Printf("Capabilities:     none\n")
 	} else {
@@ -65,6 +68,23 @@ func DescribePluginDescriptor(d *descriptor.Descriptor, out common.Printer) {
 		out.Printf("Actions:\n")
 		DescribeActions(d, out)
 	}
+	if len(d.CredentialProviders) > 0 {
+		out.Printf("\n")
+		out.Printf("Credential Providers:\n")
+		DescribeCredentialProviders(d, out)
+	}
+}
+
+func DescribeCredentialProviders(d *descriptor.Descriptor, out common.Printer) {
+	for _, c := range d.CredentialProviders {
+		out.Printf("- Name: %s\n", c.Name)
+		if c.Description != "" {
+			out.Printf("%s\n", utils2.IndentLines(c.Description, "    "))
+		}
+		if len(c.ConsumerTypes) > 0 {
+			out.Printf("  Consumer Types: %s\n", strings.Join(c.ConsumerTypes, ", "))
+		}
+	}
 }
 
 type MethodInfo struct {
@@ -16,6 +16,8 @@ const (
 	KIND_UPLOADER     = "uploader"
 	KIND_ACCESSMETHOD = errors.KIND_ACCESSMETHOD
 	KIND_ACTION       = action.KIND_ACTION
+
+	KIND_CREDENTIALPROVIDER = "credential provider"
 )
 
 var REALM = ocmlog.DefineSubRealm("OCM plugin handling", "plugins")
@@ -17,6 +17,8 @@ type Descriptor struct {
 	AccessMethods []AccessMethodDescriptor   `json:"accessMethods,omitempty"`
 	Uploaders     List[UploaderDescriptor]   `json:"uploaders,omitempty"`
 	Downloaders   List[DownloaderDescriptor] `json:"downloaders,omitempty"`
+
+	CredentialProviders []CredentialProviderDescriptor `json:"credentialProviders,omitempty"`
 }
 
 type DownloaderKey = ArtifactContext
@@ -80,6 +82,12 @@ type ActionDescriptor struct {
 	DefaultSelectors []string `json:"defaultSelectors,omitempty"`
 }
 
+type CredentialProviderDescriptor struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description,omitempty"`
+	ConsumerTypes []string `json:"consumerTypes,omitempty"`
+}
+
 type CLIOption struct {
 	Name        string `json:"name"`
 	Type        string `json:"type,omitempty"`
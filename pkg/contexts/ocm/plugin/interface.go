@@ -14,19 +14,22 @@ const (
 	KIND_UPLOADER     = descriptor.KIND_UPLOADER
 	KIND_ACCESSMETHOD = descriptor.KIND_ACCESSMETHOD
 	KIND_ACTION       = descriptor.KIND_ACTION
+
+	KIND_CREDENTIALPROVIDER = descriptor.KIND_CREDENTIALPROVIDER
 )
 
 var TAG = descriptor.REALM
 
 type (
-	Descriptor             = descriptor.Descriptor
-	ActionDescriptor       = descriptor.ActionDescriptor
-	AccessMethodDescriptor = descriptor.AccessMethodDescriptor
-	DownloaderDescriptor   = descriptor.DownloaderDescriptor
-	DownloaderKey          = descriptor.DownloaderKey
-	UploaderDescriptor     = descriptor.UploaderDescriptor
-	UploaderKey            = descriptor.UploaderKey
-	UploaderKeySet         = descriptor.UploaderKeySet
+	Descriptor                   = descriptor.Descriptor
+	ActionDescriptor             = descriptor.ActionDescriptor
+	CredentialProviderDescriptor = descriptor.CredentialProviderDescriptor
+	AccessMethodDescriptor       = descriptor.AccessMethodDescriptor
+	DownloaderDescriptor         = descriptor.DownloaderDescriptor
+	DownloaderKey                = descriptor.DownloaderKey
+	UploaderDescriptor           = descriptor.UploaderDescriptor
+	UploaderKey                  = descriptor.UploaderKey
+	UploaderKeySet               = descriptor.UploaderKeySet
 
 	AccessSpecInfo       = internal.AccessSpecInfo
 	UploadTargetSpecInfo = internal.UploadTargetSpecInfo
@@ -5,6 +5,7 @@
 package plugin
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
 	"github.com/open-component-model/ocm/pkg/contexts/credentials"
 	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/credhelper"
 	action2 "github.com/open-component-model/ocm/pkg/contexts/datacontext/action"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/identity"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
@@ -25,6 +27,8 @@ import (
 	accval "github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/accessmethod/validate"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/action"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/action/execute"
+	credcmd "github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/credentials"
+	credget "github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/credentials/get"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/download"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/upload"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/upload/put"
@@ -111,6 +115,42 @@ func (p *pluginImpl) Action(spec ppi.ActionSpec, creds json.RawMessage) (ppi.Act
 	return info, nil
 }
 
+// GetCredentials requests credentials from the credential provider
+// with the given name.
+func (p *pluginImpl) GetCredentials(name string, req *ppi.CredentialsRequest) (*ppi.CredentialsResult, error) {
+	if p.GetCredentialProviderDescriptor(name) == nil {
+		return nil, errors.ErrNotSupported(KIND_CREDENTIALPROVIDER, name, KIND_PLUGIN, p.Name())
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := p.Exec(bytes.NewReader(data), nil, credcmd.Name, credget.Name, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s", p.Name())
+	}
+	r, err := credhelper.ParseResult(result)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s", p.Name())
+	}
+	return r, nil
+}
+
+// CredentialHelper provides a credential helper for the credential provider
+// with the given name.
+func (p *pluginImpl) CredentialHelper(name string) credhelper.Helper {
+	return &credentialHelper{p, name}
+}
+
+type credentialHelper struct {
+	plugin Plugin
+	name   string
+}
+
+func (h *credentialHelper) GetCredentials(req *credhelper.Request) (*credhelper.Result, error) {
+	return h.plugin.GetCredentials(h.name, req)
+}
+
 func (p *pluginImpl) ValidateAccessMethod(spec []byte) (*ppi.AccessSpecInfo, error) {
 	result, err := p.Exec(nil, nil, accessmethod.Name, accval.Name, string(spec))
 	if err != nil {
@@ -10,8 +10,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/open-component-model/ocm/pkg/common"
 	"github.com/open-component-model/ocm/pkg/contexts/credentials"
 	"github.com/open-component-model/ocm/pkg/contexts/datacontext/action/types/oci-repository-prepare"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/identity"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/plugin"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/plugincacheattr"
@@ -19,6 +21,7 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/cache"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/plugins"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/registration"
 	testutils "github.com/open-component-model/ocm/pkg/testutils"
 )
@@ -49,6 +52,33 @@ var _ = Describe("setup plugin cache", func() {
 		Expect(r).To(Equal(oci_repository_prepare.Result("all good")))
 	})
 
+	It("provides credentials", func() {
+		p := registry.Get("credentials")
+		Expect(p).NotTo(BeNil())
+		Expect(p.GetCredentialProviderDescriptor("test")).NotTo(BeNil())
+
+		req := &ppi.CredentialsRequest{ConsumerId: credentials.ConsumerIdentity{
+			identity.ID_TYPE:     identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME: "ghcr.io",
+		}}
+		r := testutils.Must(p.GetCredentials("test", req))
+		Expect(r.Properties).To(Equal(common.Properties{"username": "ocm", "password": "secret"}))
+
+		req.ConsumerId[identity.ID_HOSTNAME] = "other.io"
+		Expect(p.GetCredentials("test", req)).To(BeNil())
+	})
+
+	It("registers credential providers", func() {
+		Expect(registration.RegisterExtensions(registry.GetContext())).To(Succeed())
+
+		id := credentials.ConsumerIdentity{
+			identity.ID_TYPE:     identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME: "ghcr.io",
+		}
+		creds := testutils.Must(credentials.CredentialsForConsumer(ctx, id, identity.IdentityMatcher))
+		Expect(creds.Properties()).To(Equal(common.Properties{"username": "ocm", "password": "secret"}))
+	})
+
 	It("scans only once", func() {
 		ctx = ocm.New()
 		plugindirattr.Set(ctx, "testdata")
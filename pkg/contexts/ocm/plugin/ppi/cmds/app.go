@@ -16,6 +16,7 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/accessmethod"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/action"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/credentials"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/describe"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/download"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/info"
@@ -63,6 +64,7 @@ func NewPluginCommand(p ppi.Plugin) *PluginCommand {
 	cmd.AddCommand(accessmethod.New(p))
 	cmd.AddCommand(upload.New(p))
 	cmd.AddCommand(download.New(p))
+	cmd.AddCommand(credentials.New(p))
 
 	cmd.InitDefaultHelpCmd()
 	var help *cobra.Command
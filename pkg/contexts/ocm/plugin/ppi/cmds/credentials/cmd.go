@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/credentials/get"
+)
+
+const Name = "credentials"
+
+func New(p ppi.Plugin) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   Name,
+		Short: "credential provider operations",
+		Long:  `This command group provides all commands used to implement a credential provider.`,
+	}
+
+	cmd.AddCommand(get.New(p))
+	return cmd
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/descriptor"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const Name = "get"
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " <name>",
+		Short: "get credentials",
+		Long: `
+This command requests credentials from the credential provider with the given
+name. The request is provided as JSON document on *stdin*. It has the
+following fields:
+
+- **<code>consumerId</code>** *map[string]string*
+
+  The consumer identity the credentials are requested for.
+
+- **<code>name</code>** *string*
+
+  The name of a credential set, if credentials are requested by name.
+
+The command has to provide the result as JSON document on *stdout*.
+It has the following fields:
+
+- **<code>properties</code>** *map[string]string*
+
+  The credential properties. If no properties are returned, the provider
+  is not responsible for the request.
+
+- **<code>consumerId</code>** *map[string]string* (optional)
+
+  The consumer identity the credentials are provided for. It is used to
+  compare the result with credentials provided by other sources. By default,
+  the requested identity is used.
+
+- **<code>ttl</code>** *int* (optional)
+
+  The caching period in seconds for the result.
+`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	return cmd
+}
+
+type Options struct {
+	Name string
+}
+
+func (o *Options) Complete(args []string) error {
+	o.Name = args[0]
+	return nil
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	c := p.GetCredentialProvider(opts.Name)
+	if c == nil {
+		return errors.ErrNotFound(descriptor.KIND_CREDENTIALPROVIDER, opts.Name)
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return errors.Wrapf(err, "cannot read credentials request")
+	}
+	var req ppi.CredentialsRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return errors.Wrapf(err, "invalid credentials request")
+	}
+	result, err := c.Credentials(p, &req)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		result = &ppi.CredentialsResult{}
+	}
+	data, err = json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("%s\n", string(data))
+	return nil
+}
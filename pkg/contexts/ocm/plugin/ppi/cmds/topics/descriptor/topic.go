@@ -101,6 +101,12 @@ following fields:
   require dedicated environment specific actions.
   For example, the creation of OCI repositories before an artifact upload.
 
+- **<code>credentialProviders</code>** *[]CredentialProviderDescriptor*
+
+  The list of supported credential providers. Credential providers are
+  registered at the credential context of the plugin host and are asked
+  for credentials for requested consumer identities.
+
 #### Access Method Descriptor
 
 An access method descriptor describes a dedicated supported access method.
@@ -246,6 +252,24 @@ The descriptor for an action has the following fields:
   consumer type used to lookup the credentials. The consumer attributes are
   derived from the the action specification and cannot be influenced by the
   plugin.
+
+#### Credential Provider Descriptor
+
+The descriptor for a credential provider has the following fields:
+
+- **<code>name</code>** *string*
+
+  The name of the credential provider.
+
+- **<code>description</code>** *string* (optional)
+
+  A short description of the provided credentials.
+
+- **<code>consumerTypes</code>** *[]string*
+
+  The list of consumer types the provider is asked for. The type <code>*</code>
+  asks the provider for all requested consumer identities. Providers without
+  consumer types are not registered.
 . 
 
 `,
@@ -9,6 +9,7 @@ import (
 	"io"
 
 	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/credhelper"
 	"github.com/open-component-model/ocm/pkg/contexts/datacontext/action"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/descriptor"
@@ -25,6 +26,10 @@ type (
 	AccessMethodDescriptor = descriptor.AccessMethodDescriptor
 	CLIOption              = descriptor.CLIOption
 
+	CredentialProviderDescriptor = descriptor.CredentialProviderDescriptor
+	CredentialsRequest           = credhelper.Request
+	CredentialsResult            = credhelper.Result
+
 	ActionSpecInfo       = internal.ActionSpecInfo
 	AccessSpecInfo       = internal.AccessSpecInfo
 	UploadTargetSpecInfo = internal.UploadTargetSpecInfo
@@ -60,6 +65,9 @@ type Plugin interface {
 	DecodeAction(data []byte) (ActionSpec, error)
 	GetAction(name string) Action
 
+	RegisterCredentialProvider(c CredentialProvider) error
+	GetCredentialProvider(name string) CredentialProvider
+
 	GetOptions() *Options
 	GetConfig() (interface{}, error)
 }
@@ -121,3 +129,19 @@ type Action interface {
 
 	Execute(p Plugin, spec ActionSpec, creds credentials.DirectCredentials) (result ActionResult, err error)
 }
+
+// CredentialProvider provides credentials for consumer identities.
+// It is used by the credential context of the plugin host to resolve
+// credentials for the given consumer types.
+type CredentialProvider interface {
+	Name() string
+	Description() string
+	// ConsumerTypes are the consumer types the provider is asked for.
+	// The type "*" asks the provider for all consumer identities.
+	ConsumerTypes() []string
+
+	// Credentials provides the credentials for the consumer identity or the
+	// credential name described by the request. A nil result indicates that
+	// no credentials are available.
+	Credentials(p Plugin, req *CredentialsRequest) (*CredentialsResult, error)
+}
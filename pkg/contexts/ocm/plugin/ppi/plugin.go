@@ -35,6 +35,8 @@ type plugin struct {
 
 	actions map[string]Action
 
+	credproviders map[string]CredentialProvider
+
 	configParser func(message json.RawMessage) (interface{}, error)
 }
 
@@ -56,6 +58,8 @@ func NewPlugin(name string, version string) Plugin {
 
 		actions: map[string]Action{},
 
+		credproviders: map[string]CredentialProvider{},
+
 		descriptor: descriptor.Descriptor{
 			Version:       descriptor.VERSION,
 			PluginName:    name,
@@ -327,6 +331,25 @@ func (p *plugin) GetAction(name string) Action {
 	return p.actions[name]
 }
 
+func (p *plugin) RegisterCredentialProvider(c CredentialProvider) error {
+	if p.GetCredentialProvider(c.Name()) != nil {
+		return errors.ErrAlreadyExists(descriptor.KIND_CREDENTIALPROVIDER, c.Name())
+	}
+
+	desc := descriptor.CredentialProviderDescriptor{
+		Name:          c.Name(),
+		Description:   c.Description(),
+		ConsumerTypes: c.ConsumerTypes(),
+	}
+	p.descriptor.CredentialProviders = append(p.descriptor.CredentialProviders, desc)
+	p.credproviders[c.Name()] = c
+	return nil
+}
+
+func (p *plugin) GetCredentialProvider(name string) CredentialProvider {
+	return p.credproviders[name]
+}
+
 func (p *plugin) GetConfig() (interface{}, error) {
 	if len(p.options.Config) == 0 {
 		return nil, nil
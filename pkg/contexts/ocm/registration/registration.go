@@ -5,6 +5,8 @@
 package registration
 
 import (
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/credhelper"
 	"github.com/open-component-model/ocm/pkg/contexts/datacontext/action"
 	"github.com/open-component-model/ocm/pkg/contexts/datacontext/action/handlers"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
@@ -66,6 +68,21 @@ func RegisterExtensions(ctx ocm.Context) error {
 				}
 			}
 		}
+
+		for _, c := range p.GetDescriptor().CredentialProviders {
+			if len(c.ConsumerTypes) == 0 {
+				logger.Warn("ignoring credential provider without consumer types",
+					"plugin", p.Name(),
+					"provider", c.Name)
+				continue
+			}
+			logger.Info("registering credential provider",
+				"plugin", p.Name(),
+				"provider", c.Name)
+			credctx := ctx.CredentialsContext()
+			id := credentials.ProviderIdentity(credhelper.PROVIDER + "/plugin/" + p.Name() + "/" + c.Name)
+			credctx.RegisterConsumerProvider(id, credhelper.NewConsumerProvider(credctx, p.CredentialHelper(c.Name), credhelper.DEFAULT_TTL, c.ConsumerTypes...))
+		}
 	}
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"path"
 	"strings"
+	"sync"
 
 	"github.com/opencontainers/go-digest"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/oci"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localblob"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localociblob"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
@@ -60,9 +62,16 @@ type ComponentVersionContainer struct {
 	access   oci.ArtifactAccess
 	manifest oci.ManifestAccess
 	state    accessobj.State
+
+	// layerLock serializes the layer list modifications of the manifest
+	// for concurrently stored blobs.
+	layerLock sync.Mutex
 }
 
-var _ support.ComponentVersionContainer = (*ComponentVersionContainer)(nil)
+var (
+	_ support.ComponentVersionContainer = (*ComponentVersionContainer)(nil)
+	_ cpi.LocalBlobChecker              = (*ComponentVersionContainer)(nil)
+)
 
 func newComponentVersionContainer(mode accessobj.AccessMode, comp *componentAccessImpl, version string, access oci.ArtifactAccess) (*ComponentVersionContainer, error) {
 	m := access.ManifestAccess()
@@ -162,6 +171,8 @@ func (c *ComponentVersionContainer) Update() error {
 
 	if c.state.HasChanged() {
 		logger.Debug("update component version")
+		c.layerLock.Lock()
+		defer c.layerLock.Unlock()
 		desc := c.GetDescriptor()
 		layers := generics.Set[int]{}
 		for i := range c.manifest.GetDescriptor().Layers {
@@ -257,7 +268,18 @@ func (c *ComponentVersionContainer) GetBlobData(name string) (cpi.DataAccess, er
 }
 
 func (c *ComponentVersionContainer) GetStorageContext(cv cpi.ComponentVersionAccess) cpi.StorageContext {
-	return ocihdlr.New(c.comp.repo, cv, c.comp.repo.ocirepo.GetSpecification().GetKind(), c.comp.repo.ocirepo, c.comp.namespace, c.manifest)
+	ctx := ocihdlr.New(c.comp.repo, cv, c.comp.repo.ocirepo.GetSpecification().GetKind(), c.comp.repo.ocirepo, c.comp.namespace, c.manifest)
+	ctx.LayerLock = &c.layerLock
+	return ctx
+}
+
+// HasLocalBlob checks whether the OCI namespace of the component
+// already provides a blob with the given digest.
+func (c *ComponentVersionContainer) HasLocalBlob(digest digest.Digest) (bool, int64, error) {
+	if b, ok := c.comp.namespace.(oci.BlobChecker); ok {
+		return b.HasBlob(digest)
+	}
+	return false, accessio.BLOB_UNKNOWN_SIZE, nil
 }
 
 func (c *ComponentVersionContainer) AddBlobFor(storagectx cpi.StorageContext, blob cpi.BlobAccess, refName string, global cpi.AccessSpec) (cpi.AccessSpec, error) {
@@ -265,7 +287,7 @@ func (c *ComponentVersionContainer) AddBlobFor(storagectx cpi.StorageContext, bl
 		return nil, errors.New("a resource has to be defined")
 	}
 
-	err := c.manifest.AddBlob(blob)
+	err := storagectx.(*ocihdlr.StorageContext).AssureBlob(blob)
 	if err != nil {
 		return nil, err
 	}
@@ -316,6 +338,10 @@ func (c *ComponentVersionContainer) AssureGlobalRef(d digest.Digest, url, name s
 	if err != nil {
 		return nil, err
 	}
+	err = transfer.TransferAttachedArtifacts(set, digest, namespace)
+	if err != nil {
+		return nil, err
+	}
 
 	ref := path.Join(url+namespace.GetNamespace()) + ":" + version
 
@@ -24,6 +24,16 @@ const (
 	PLAIN_TEXT = "plainText"
 	// OCM_PLUGIN describes an OS executable OCM plugin.
 	OCM_PLUGIN = "ocmPlugin"
+	// SBOM describes a software bill of materials, either in SPDX
+	// (application/spdx+json) or CycloneDX (application/vnd.cyclonedx+json)
+	// format.
+	SBOM = "sbom"
+	// MAVEN_ARTIFACT describes a single artifact file of a Maven artifact,
+	// typically stored in a Maven repository.
+	MAVEN_ARTIFACT = "mavenArtifact"
+	// PYTHON_PACKAGE describes a Python package file, either a wheel
+	// or a source distribution (sdist).
+	PYTHON_PACKAGE = "pythonPackage"
 
 	// OCM_FILE describes a generic file or unspecified byte stream.
 	OCM_FILE = "file"
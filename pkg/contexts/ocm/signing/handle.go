@@ -88,6 +88,14 @@ func _apply(printer common.Printer, state WalkingState, nv common.NameVersion, c
 	octx := cv.GetContext()
 	printer.Printf("%sapplying to version %q...\n", prefix, nv)
 
+	opts, rule, err := opts.ForComponent(nv.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if rule != nil {
+		printer.Printf("  using verification policy rule %q\n", rule.Component)
+	}
+
 	signatureNames := opts.SignatureNames
 	if len(signatureNames) == 0 {
 		for _, s := range cd.Signatures {
@@ -173,6 +181,11 @@ func _apply(printer common.Printer, state WalkingState, nv common.NameVersion, c
 		if err := doVerify(printer, cd, state, signatureNames, opts); err != nil {
 			return nil, err
 		}
+		if opts.DoVerifyOCISignatures() {
+			if err := verifyOCISignatures(printer, cv, signatureNames, opts); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	found := cd.GetSignatureIndex(opts.SignatureName())
@@ -278,6 +291,14 @@ func doVerify(printer common.Printer, cd *compdesc.ComponentDescriptor, state Wa
 		if err != nil {
 			return errors.ErrInvalidWrap(err, compdesc.KIND_SIGNATURE, sig.Signature.Algorithm)
 		}
+		if r := opts.policyRule; r != nil {
+			if err := r.CheckIssuer(n, sig.Signature.Issuer); err != nil {
+				return err
+			}
+			if err := r.CheckSubject(n, pub); err != nil {
+				return err
+			}
+		}
 		found = append(found, n)
 	}
 	if len(found) == 0 {
@@ -295,7 +316,7 @@ func calculateReferenceDigests(printer common.Printer, cd *compdesc.ComponentDes
 		if reference.Digest == nil && !opts.DoUpdate() {
 			printer.Printf("  no digest given for reference %s", reference)
 		}
-		if reference.Digest == nil || opts.Recursively || opts.Verify {
+		if reference.Digest == nil || opts.Recursively || opts.Verify || opts.RequiresPolicyCheck(reference.GetComponentName()) {
 			nested, err := opts.Resolver.LookupComponentVersion(reference.GetComponentName(), reference.GetVersion())
 			if err != nil {
 				return errors.Wrapf(err, refMsg(reference, "failed resolving component reference"))
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cosign"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/none"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/finalizer"
+)
+
+// verifyOCISignatures verifies the cosign signatures attached to the OCI
+// image resources of a component version using the public keys
+// configured for the given signature names.
+func verifyOCISignatures(printer common.Printer, cv ocm.ComponentVersionAccess, signatureNames []string, opts *Options) error {
+	var keys []interface{}
+	for _, n := range signatureNames {
+		if pub := opts.PublicKey(n); pub != nil {
+			keys = append(keys, pub)
+		}
+	}
+
+	for i, res := range cv.GetResources() {
+		if res.Meta().GetType() != resourcetypes.OCI_IMAGE {
+			continue
+		}
+		raw := &cv.GetDescriptor().Resources[i]
+		if len(keys) == 0 {
+			return errors.ErrNotFound(compdesc.KIND_PUBLIC_KEY, resMsg(raw, "", "for OCI signature of resource"))
+		}
+		acc, err := res.Access()
+		if err != nil {
+			return errors.Wrapf(err, resMsg(raw, "", "failed getting access for resource"))
+		}
+		if none.IsNone(acc.GetKind()) {
+			continue
+		}
+		err = verifyOCISignature(cv, acc, keys)
+		if err != nil {
+			return errors.Wrapf(err, resMsg(raw, acc.Describe(cv.GetContext()), "failed verifying OCI signature for resource"))
+		}
+		printer.Printf("  resource %d:  %s: OCI signature verified\n", i, res.Meta().GetIdentity(cv.GetDescriptor().Resources))
+	}
+	return nil
+}
+
+func verifyOCISignature(cv ocm.ComponentVersionAccess, acc ocm.AccessSpec, keys []interface{}) error {
+	meth, err := acc.AccessMethod(cv)
+	if err != nil {
+		return err
+	}
+	defer meth.Close()
+
+	if o, ok := meth.(ociartifact.AccessMethod); ok {
+		// verify directly against the repository to avoid
+		// copying the complete artifact.
+		return verifyOCIArtifactSignature(o, keys)
+	}
+
+	mime := meth.MimeType()
+	if !artdesc.IsOCIMediaType(mime) {
+		return errors.ErrInvalid("OCI artifact media type", mime)
+	}
+	set, err := artifactset.OpenFromBlob(accessobj.ACC_READONLY, accessio.BlobAccessForDataAccess(accessio.BLOB_UNKNOWN_DIGEST, accessio.BLOB_UNKNOWN_SIZE, mime, meth))
+	if err != nil {
+		return err
+	}
+	defer set.Close()
+	return cosign.Verify(set, set.GetMain(), keys...)
+}
+
+// verifyOCIArtifactSignature looks up the signatures of an artifact
+// in its OCI repository, using the cosign signature tag and the referrers
+// of the artifact. Only the manifest of the artifact and the signature
+// artifacts are fetched.
+func verifyOCIArtifactSignature(meth ociartifact.AccessMethod, keys []interface{}) error {
+	var finalize finalizer.Finalizer
+	defer finalize.Finalize()
+
+	ns, ref, err := meth.GetNamespace(&finalize)
+	if err != nil {
+		return err
+	}
+	subject := ref.Digest
+	if subject == nil {
+		art, err := ns.GetArtifact(ref.Version())
+		if err != nil {
+			return err
+		}
+		finalize.Close(art)
+		d := art.Digest()
+		subject = &d
+	}
+	return cosign.Verify(ns, *subject, keys...)
+}
@@ -65,6 +65,38 @@ func (o *verify) ApplySigningOption(opts *Options) {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+type ocisigs struct {
+	flag bool
+}
+
+// VerifyOCISignatures enables the verification of cosign signatures
+// attached to the OCI image resources of verified component versions.
+func VerifyOCISignatures(flags ...bool) Option {
+	return &ocisigs{utils.GetOptionFlag(flags...)}
+}
+
+func (o *ocisigs) ApplySigningOption(opts *Options) {
+	opts.VerifyOCISignatures = o.flag
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type policy struct {
+	policy *VerificationPolicy
+}
+
+// Policy sets a verification policy. It enforces the verification of
+// signatures and provides the verification requirements per component.
+func Policy(p *VerificationPolicy) Option {
+	return &policy{p}
+}
+
+func (o *policy) ApplySigningOption(opts *Options) {
+	opts.Policy = o.policy
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type signer struct {
 	signer signing.Signer
 	name   string
@@ -241,6 +273,11 @@ type Options struct {
 	SkipAccessTypes   map[string]bool
 	SignatureNames    []string
 	NormalizationAlgo string
+
+	VerifyOCISignatures bool
+
+	Policy     *VerificationPolicy
+	policyRule *PolicyRule
 }
 
 var _ Option = (*Options)(nil)
@@ -292,6 +329,12 @@ func (o *Options) ApplySigningOption(opts *Options) {
 	if o.NormalizationAlgo != "" {
 		opts.NormalizationAlgo = o.NormalizationAlgo
 	}
+	if o.VerifyOCISignatures {
+		opts.VerifyOCISignatures = o.VerifyOCISignatures
+	}
+	if o.Policy != nil {
+		opts.Policy = o.Policy
+	}
 }
 
 func (o *Options) Complete(registry signing.Registry) error {
@@ -304,6 +347,14 @@ func (o *Options) Complete(registry signing.Registry) error {
 	if o.SkipAccessTypes == nil {
 		o.SkipAccessTypes = map[string]bool{}
 	}
+	if o.Policy != nil {
+		if err := o.Policy.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid verification policy")
+		}
+		if o.Signer == nil {
+			o.VerifySignature = true
+		}
+	}
 	if o.Signer != nil {
 		if len(o.SignatureNames) == 0 {
 			return errors.Newf("signature name required for signing")
@@ -371,6 +422,10 @@ func (o *Options) DoVerify() bool {
 	return o.VerifySignature
 }
 
+func (o *Options) DoVerifyOCISignatures() bool {
+	return o.VerifySignature && o.VerifyOCISignatures
+}
+
 func (o *Options) SignatureName() string {
 	if len(o.SignatureNames) > 0 {
 		return o.SignatureNames[0]
@@ -407,6 +462,58 @@ func (o *Options) PrivateKey() interface{} {
 	return o.Registry.GetPrivateKey(o.SignatureName())
 }
 
+// ForComponent provides the options to use for a component version
+// according to the verification policy.
+// Signatures of referenced component versions are not verified by default,
+// but if a rule matches, it is enforced for those versions, also.
+func (o *Options) ForComponent(name string) (*Options, *PolicyRule, error) {
+	rule := o.Policy.RuleFor(name)
+	if rule == nil {
+		return o, nil, nil
+	}
+	opts := *o
+	opts.policyRule = rule
+	if !opts.VerifySignature && opts.Signer == nil {
+		opts.VerifySignature = true
+		opts.SignatureNames = nil
+	}
+	if rule.VerifyDigests {
+		opts.Verify = true
+	}
+	if len(rule.SkipAccessTypes) > 0 {
+		opts.SkipAccessTypes = map[string]bool{}
+		for k, v := range o.SkipAccessTypes {
+			opts.SkipAccessTypes[k] = v
+		}
+		for _, t := range rule.SkipAccessTypes {
+			opts.SkipAccessTypes[t] = true
+		}
+	}
+	if opts.DoVerify() {
+		names := append([]string{}, opts.SignatureNames...)
+		for _, n := range rule.Signatures {
+			if opts.SignatureConfigured(n) {
+				continue
+			}
+			if pub := opts.PublicKey(n); pub != nil {
+				if err := opts.checkCert(pub, n); err != nil {
+					return nil, nil, err
+				}
+			}
+			names = append(names, n)
+		}
+		opts.SignatureNames = names
+	}
+	return &opts, rule, nil
+}
+
+// RequiresPolicyCheck checks whether there is a verification policy rule
+// for a referenced component version. Such a version must be processed
+// even if its digest is already known.
+func (o *Options) RequiresPolicyCheck(name string) bool {
+	return o.Signer == nil && o.Policy.RuleFor(name) != nil
+}
+
 func (o *Options) For(digest *metav1.DigestSpec) (*Options, error) {
 	opts := *o
 	opts.VerifySignature = false // TODO: may be we want a mode to verify signature if present
@@ -5,6 +5,8 @@
 package signing_test
 
 import (
+	"crypto"
+	"crypto/elliptic"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"time"
@@ -13,7 +15,11 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/open-component-model/ocm/pkg/contexts/ocm/signing"
 
+	"github.com/opencontainers/go-digest"
+
 	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/ecdsa"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/ed25519"
 	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
 )
 
@@ -85,3 +91,49 @@ var _ = Describe("options", func() {
 		Expect(opts.Complete(signing.DefaultRegistry())).To(HaveOccurred())
 	})
 })
+
+var _ = Describe("options for other key types", func() {
+	DescribeTable("verifies certificate chains", func(h signing.SignatureHandler) {
+		kh := h.(signing.KeyPairHandler)
+		capriv, capub, err := kh.CreateKeyPair()
+		Expect(err).To(Succeed())
+		caData, err := signing.CreateCertificate(pkix.Name{CommonName: "ca-authority"}, nil, 10*time.Hour, capub, nil, capriv, true)
+		Expect(err).To(Succeed())
+		ca, err := x509.ParseCertificate(caData)
+		Expect(err).To(Succeed())
+
+		priv, pub, err := kh.CreateKeyPair()
+		Expect(err).To(Succeed())
+		certData, err := signing.CreateCertificate(pkix.Name{CommonName: "mandelsoft"}, nil, 10*time.Hour, pub, ca, capriv, false)
+		Expect(err).To(Succeed())
+		cert, err := x509.ParseCertificate(certData)
+		Expect(err).To(Succeed())
+
+		pool := x509.NewCertPool()
+		pool.AddCert(ca)
+
+		opts := NewOptions(
+			RootCertificates(pool),
+			Sign(h, NAME),
+			PrivateKey(NAME, priv),
+			PublicKey(NAME, cert),
+		)
+		Expect(opts.Complete(signing.DefaultRegistry())).To(Succeed())
+
+		sig, err := h.Sign(nil, digest.FromString("test").Hex(), crypto.SHA256, "ca-authority", opts.PrivateKey())
+		Expect(err).To(Succeed())
+		Expect(h.Verify(digest.FromString("test").Hex(), crypto.SHA256, sig, opts.PublicKey(NAME))).To(Succeed())
+		sig.Issuer = "other"
+		Expect(h.Verify(digest.FromString("test").Hex(), crypto.SHA256, sig, opts.PublicKey(NAME))).To(HaveOccurred())
+
+		opts = NewOptions(
+			VerifySignature(NAME),
+			PublicKey(NAME, cert),
+		)
+		Expect(opts.Complete(signing.DefaultRegistry())).To(HaveOccurred())
+	},
+		Entry("ECDSA P-256", ecdsa.Handler{}),
+		Entry("ECDSA P-384", ecdsa.NewHandler(elliptic.P384())),
+		Entry("Ed25519", ed25519.Handler{}),
+	)
+})
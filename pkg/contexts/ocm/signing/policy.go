@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/signing"
+)
+
+// VerificationPolicy describes the requirements for the verification
+// of component versions by rules for component name patterns.
+// For a component version the first matching rule is used.
+type VerificationPolicy struct {
+	Rules []PolicyRule `json:"rules,omitempty"`
+}
+
+// PolicyRule describes the verification requirements for the component
+// versions of components matching a name pattern.
+type PolicyRule struct {
+	// Component is the name pattern for the component names the rule
+	// applies to. A * matches any sequence of characters (including /).
+	Component string `json:"component"`
+	// Signatures are the names of the signatures required to be present
+	// and successfully verified.
+	Signatures []string `json:"signatures,omitempty"`
+	// Issuers are the allowed issuers of verified signatures.
+	Issuers []string `json:"issuers,omitempty"`
+	// Subjects are the allowed subjects (common name or distinguished name)
+	// of the certificates used to verify signatures.
+	Subjects []string `json:"subjects,omitempty"`
+	// VerifyDigests enforces the verification of resource and reference digests.
+	VerifyDigests bool `json:"verifyDigests,omitempty"`
+	// SkipAccessTypes are the access types of resources excluded from
+	// the digest calculation.
+	SkipAccessTypes []string `json:"skipAccessTypes,omitempty"`
+}
+
+// AddRules adds rules to the policy. Rules are evaluated in the
+// order they are added.
+func (p *VerificationPolicy) AddRules(rules ...PolicyRule) {
+	p.Rules = append(p.Rules, rules...)
+}
+
+// Validate checks the rules of the policy.
+func (p *VerificationPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for i, r := range p.Rules {
+		if r.Component == "" {
+			return errors.Newf("component pattern missing for policy rule %d", i)
+		}
+		for _, n := range r.Signatures {
+			if strings.TrimSpace(n) == "" {
+				return errors.Newf("empty signature name in policy rule %d (%s)", i, r.Component)
+			}
+		}
+	}
+	return nil
+}
+
+// RuleFor provides the first rule matching the given component name.
+// If there is no such rule (or no policy), nil is returned.
+func (p *VerificationPolicy) RuleFor(name string) *PolicyRule {
+	if p == nil {
+		return nil
+	}
+	for i := range p.Rules {
+		if p.Rules[i].Matches(name) {
+			return &p.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Matches checks whether the rule applies to the given component name.
+func (r *PolicyRule) Matches(name string) bool {
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(r.Component), `\*`, ".*") + "$"
+	ok, _ := regexp.MatchString(expr, name)
+	return ok
+}
+
+// CheckIssuer checks whether the issuer of a signature is allowed by the rule.
+func (r *PolicyRule) CheckIssuer(name string, issuer string) error {
+	if len(r.Issuers) == 0 {
+		return nil
+	}
+	for _, i := range r.Issuers {
+		if i == issuer {
+			return nil
+		}
+	}
+	return errors.Newf("issuer %q of signature %q not allowed by policy for %q", issuer, name, r.Component)
+}
+
+// CheckSubject checks whether the public key used to verify a signature is
+// a certificate with a subject allowed by the rule.
+func (r *PolicyRule) CheckSubject(name string, pub interface{}) error {
+	if len(r.Subjects) == 0 {
+		return nil
+	}
+	cert, err := signing.GetCertificate(pub)
+	if err != nil {
+		return errors.Newf("public key for signature %q is no certificate, but required by policy for %q", name, r.Component)
+	}
+	for _, s := range r.Subjects {
+		if s == cert.Subject.CommonName || s == cert.Subject.String() {
+			return nil
+		}
+	}
+	return errors.Newf("certificate subject %q for signature %q not allowed by policy for %q", cert.Subject.String(), name, r.Component)
+}
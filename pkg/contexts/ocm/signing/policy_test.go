@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signing_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/open-component-model/ocm/pkg/contexts/ocm/signing"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
+)
+
+var _ = Describe("verification policy", func() {
+	It("selects first matching rule", func() {
+		policy := &VerificationPolicy{}
+		policy.AddRules(
+			PolicyRule{Component: "acme.org/special"},
+			PolicyRule{Component: "acme.org/*"},
+			PolicyRule{Component: "*"},
+		)
+		Expect(policy.RuleFor("acme.org/special")).To(BeIdenticalTo(&policy.Rules[0]))
+		Expect(policy.RuleFor("acme.org/special/sub")).To(BeIdenticalTo(&policy.Rules[1]))
+		Expect(policy.RuleFor("acme.org")).To(BeIdenticalTo(&policy.Rules[2]))
+		Expect((*VerificationPolicy)(nil).RuleFor("acme.org")).To(BeNil())
+	})
+
+	It("rejects rules without component pattern", func() {
+		policy := &VerificationPolicy{Rules: []PolicyRule{{Signatures: []string{SIGNATURE}}}}
+		Expect(policy.Validate()).To(MatchError("component pattern missing for policy rule 0"))
+	})
+
+	Context("verification", func() {
+		var env *Builder
+		var priv, pub interface{}
+
+		BeforeEach(func() {
+			env = NewBuilder(nil)
+
+			var err error
+			priv, pub, err = rsa.Handler{}.CreateKeyPair()
+			Expect(err).To(Succeed())
+
+			env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+				env.Component(COMPONENTA, func() {
+					env.Version(VERSION, func() {
+						env.Provider(PROVIDER)
+						env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+							env.BlobStringData(mime.MIME_TEXT, "testdata")
+						})
+					})
+				})
+			})
+
+			session := datacontext.NewSession()
+			defer session.Close()
+
+			src, err := ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env)
+			Expect(err).To(Succeed())
+			session.AddCloser(src)
+			cv, err := src.LookupComponentVersion(COMPONENTA, VERSION)
+			Expect(err).To(Succeed())
+			session.AddCloser(cv)
+
+			opts := NewOptions(
+				Sign(signing.DefaultHandlerRegistry().GetSigner(SIGN_ALGO), SIGNATURE),
+				Issuer("acme"),
+				PrivateKey(SIGNATURE, priv),
+				Update(), VerifyDigests(),
+			)
+			MustBeSuccessful(opts.Complete(signing.DefaultRegistry()))
+			_, err = Apply(nil, nil, cv, opts)
+			Expect(err).To(Succeed())
+		})
+
+		AfterEach(func() {
+			env.Cleanup()
+		})
+
+		verify := func(rules ...PolicyRule) error {
+			session := datacontext.NewSession()
+			defer session.Close()
+
+			src, err := ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env)
+			Expect(err).To(Succeed())
+			session.AddCloser(src)
+			cv, err := src.LookupComponentVersion(COMPONENTA, VERSION)
+			Expect(err).To(Succeed())
+			session.AddCloser(cv)
+
+			opts := NewOptions(
+				Resolver(ocm.NewCompoundResolver(src)),
+				PublicKey(SIGNATURE, pub),
+				Policy(&VerificationPolicy{Rules: rules}),
+			)
+			MustBeSuccessful(opts.Complete(signing.DefaultRegistry()))
+			_, err = Apply(nil, nil, cv, opts)
+			return err
+		}
+
+		It("enforces signature verification without matching rule", func() {
+			Expect(verify(PolicyRule{Component: "other.org/*", Signatures: []string{"other"}})).To(Succeed())
+		})
+
+		It("verifies required signatures", func() {
+			Expect(verify(PolicyRule{
+				Component:     "github.com/mandelsoft/*",
+				Signatures:    []string{SIGNATURE},
+				Issuers:       []string{"acme"},
+				VerifyDigests: true,
+			})).To(Succeed())
+		})
+
+		It("fails for missing signature", func() {
+			Expect(verify(PolicyRule{
+				Component:  "github.com/mandelsoft/*",
+				Signatures: []string{SIGNATURE, "other"},
+			})).To(MatchError(COMPONENTA + ":" + VERSION + ": signature \"other\" not found"))
+		})
+
+		It("fails for wrong issuer", func() {
+			Expect(verify(PolicyRule{
+				Component: "github.com/mandelsoft/*",
+				Issuers:   []string{"mandelsoft"},
+			})).To(MatchError(COMPONENTA + ":" + VERSION + ": issuer \"acme\" of signature \"test\" not allowed by policy for \"github.com/mandelsoft/*\""))
+		})
+
+		It("fails for required certificate subject", func() {
+			Expect(verify(PolicyRule{
+				Component: "github.com/mandelsoft/*",
+				Subjects:  []string{"acme"},
+			})).To(MatchError(COMPONENTA + ":" + VERSION + ": public key for signature \"test\" is no certificate, but required by policy for \"github.com/mandelsoft/*\""))
+		})
+	})
+
+	Context("nested verification", func() {
+		const WRAPPER = "acme.org/wrapper"
+
+		var env *Builder
+		var priv, pub interface{}
+
+		BeforeEach(func() {
+			env = NewBuilder(nil)
+
+			var err error
+			priv, pub, err = rsa.Handler{}.CreateKeyPair()
+			Expect(err).To(Succeed())
+
+			env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+				env.Component(COMPONENTA, func() {
+					env.Version(VERSION, func() {
+						env.Provider(PROVIDER)
+						env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+							env.BlobStringData(mime.MIME_TEXT, "testdata")
+						})
+					})
+				})
+				env.Component(WRAPPER, func() {
+					env.Version(VERSION, func() {
+						env.Provider(PROVIDER)
+						env.Reference("ref", COMPONENTA, VERSION)
+					})
+				})
+			})
+		})
+
+		AfterEach(func() {
+			env.Cleanup()
+		})
+
+		apply := func(opts *Options) error {
+			session := datacontext.NewSession()
+			defer session.Close()
+
+			src, err := ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env)
+			Expect(err).To(Succeed())
+			session.AddCloser(src)
+			cv, err := src.LookupComponentVersion(WRAPPER, VERSION)
+			Expect(err).To(Succeed())
+			session.AddCloser(cv)
+
+			opts.Eval(Resolver(ocm.NewCompoundResolver(src)))
+			MustBeSuccessful(opts.Complete(signing.DefaultRegistry()))
+			_, err = Apply(nil, nil, cv, opts)
+			return err
+		}
+
+		sign := func(recursive bool) {
+			Expect(apply(NewOptions(
+				Sign(signing.DefaultHandlerRegistry().GetSigner(SIGN_ALGO), SIGNATURE),
+				Issuer("acme"),
+				PrivateKey(SIGNATURE, priv),
+				Update(), Recursive(recursive),
+			))).To(Succeed())
+		}
+
+		verify := func(rules ...PolicyRule) error {
+			return apply(NewOptions(
+				PublicKey(SIGNATURE, pub),
+				Policy(&VerificationPolicy{Rules: rules}),
+			))
+		}
+
+		It("enforces rule for unsigned referenced version", func() {
+			sign(false)
+			Expect(verify(PolicyRule{Component: "other.org/*"})).To(Succeed())
+			Expect(verify(PolicyRule{
+				Component:  COMPONENTA,
+				Signatures: []string{SIGNATURE},
+			})).To(MatchError(ContainSubstring(COMPONENTA + ":" + VERSION + ": signature \"test\" not found")))
+		})
+
+		It("enforces issuer for referenced version", func() {
+			sign(true)
+			Expect(verify(PolicyRule{
+				Component:  COMPONENTA,
+				Signatures: []string{SIGNATURE},
+				Issuers:    []string{"acme"},
+			})).To(Succeed())
+			Expect(verify(PolicyRule{
+				Component: COMPONENTA,
+				Issuers:   []string{"mandelsoft"},
+			})).To(MatchError(ContainSubstring(COMPONENTA + ":" + VERSION + ": issuer \"acme\" of signature \"test\" not allowed by policy for \"" + COMPONENTA + "\"")))
+		})
+	})
+})
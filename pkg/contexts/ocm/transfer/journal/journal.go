@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const (
+	KIND_RESOURCE = "resource"
+	KIND_SOURCE   = "source"
+)
+
+// Artifact describes the blob of a resource or source already
+// transferred to the target repository.
+type Artifact struct {
+	// Access is the (source) access specification the blob has been taken from.
+	Access    json.RawMessage `json:"access"`
+	Digest    digest.Digest   `json:"digest"`
+	Size      int64           `json:"size"`
+	MediaType string          `json:"mediaType,omitempty"`
+}
+
+// MatchAccess checks whether the recorded blob has been taken from
+// the given (serialized) access specification.
+func (a *Artifact) MatchAccess(access []byte) bool {
+	var r, g interface{}
+	if json.Unmarshal(a.Access, &r) != nil || json.Unmarshal(access, &g) != nil {
+		return false
+	}
+	return reflect.DeepEqual(r, g)
+}
+
+// Version describes the transfer state of a component version.
+type Version struct {
+	Finished  bool                 `json:"finished,omitempty"`
+	Artifacts map[string]*Artifact `json:"artifacts,omitempty"`
+}
+
+// journalFile is the serialization format of a journal.
+type journalFile struct {
+	// Target is the specification of the target repository
+	// the journal has been recorded for.
+	Target   json.RawMessage     `json:"target,omitempty"`
+	Versions map[string]*Version `json:"versions,omitempty"`
+}
+
+// Closure is the transport closure of a journal keeping
+// the state for every touched component version.
+type Closure = common.NameVersionInfo[*Version]
+
+// Journal records the progress of a transfer in a file.
+// It is used to resume an interrupted transfer: finished component versions
+// are skipped and for already transferred blobs the recorded digest is passed to
+// the target repository, which can then check for the blob without copying it
+// again.
+// A journal is bound to the target repository it has been recorded for.
+type Journal struct {
+	lock    sync.Mutex
+	fs      vfs.FileSystem
+	path    string
+	target  json.RawMessage
+	closure Closure
+}
+
+// Open opens a journal file. If the file does not exist,
+// an empty journal is created, which is written on the first update.
+func Open(path string, fss ...vfs.FileSystem) (*Journal, error) {
+	fs := accessio.FileSystem(fss...)
+	j := &Journal{
+		fs:      fs,
+		path:    path,
+		closure: Closure{},
+	}
+	ok, err := vfs.FileExists(fs, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "transfer journal %q", path)
+	}
+	if ok {
+		data, err := vfs.ReadFile(fs, path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read transfer journal %q", path)
+		}
+		var file journalFile
+		err = json.Unmarshal(data, &file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid transfer journal %q", path)
+		}
+		j.target = file.Target
+		for k, v := range file.Versions {
+			nv, err := common.ParseNameVersion(k)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid transfer journal %q", path)
+			}
+			j.closure[nv] = v
+		}
+	}
+	return j, nil
+}
+
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// AssureTarget binds the journal to the given (serialized) target repository
+// specification. If the journal has already been recorded for a different
+// target, an error is returned, because the recorded transfer state
+// is not valid for another target.
+func (j *Journal) AssureTarget(spec []byte) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if j.target == nil {
+		j.target = spec
+		return j.save()
+	}
+	var r, g interface{}
+	if json.Unmarshal(j.target, &r) != nil || json.Unmarshal(spec, &g) != nil || !reflect.DeepEqual(r, g) {
+		return errors.Newf("transfer journal %q has been recorded for a different target repository %s", j.path, string(j.target))
+	}
+	return nil
+}
+
+// IsFinished reports whether the transfer of a component version
+// has been completed.
+func (j *Journal) IsFinished(nv common.NameVersion) bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	v := j.closure[nv]
+	return v != nil && v.Finished
+}
+
+// Finish marks the transfer of a component version as completed.
+// The artifact information of this version is not required anymore.
+func (j *Journal) Finish(nv common.NameVersion) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.closure[nv] = &Version{Finished: true}
+	return j.save()
+}
+
+// Reset forgets the state of a component version.
+func (j *Journal) Reset(nv common.NameVersion) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if _, ok := j.closure[nv]; !ok {
+		return nil
+	}
+	delete(j.closure, nv)
+	return j.save()
+}
+
+// GetArtifact returns the recorded blob information for a resource or source
+// of a component version, if it has already been transferred.
+func (j *Journal) GetArtifact(nv common.NameVersion, kind string, id metav1.Identity) *Artifact {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	v := j.closure[nv]
+	if v == nil {
+		return nil
+	}
+	return v.Artifacts[key(kind, id)]
+}
+
+// AddArtifact records the blob information for a transferred resource or source.
+func (j *Journal) AddArtifact(nv common.NameVersion, kind string, id metav1.Identity, a *Artifact) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	v := j.closure[nv]
+	if v == nil {
+		v = &Version{}
+		j.closure[nv] = v
+	}
+	if v.Artifacts == nil {
+		v.Artifacts = map[string]*Artifact{}
+	}
+	v.Artifacts[key(kind, id)] = a
+	return j.save()
+}
+
+// Versions returns the component versions known by the journal.
+func (j *Journal) Versions() []common.NameVersion {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	result := []common.NameVersion{}
+	for nv := range j.closure {
+		result = append(result, nv)
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].Compare(result[b]) < 0 })
+	return result
+}
+
+func (j *Journal) save() error {
+	versions := map[string]*Version{}
+	for nv, v := range j.closure {
+		versions[nv.String()] = v
+	}
+	data, err := json.Marshal(&journalFile{Target: j.target, Versions: versions})
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal transfer journal")
+	}
+	tmp := j.path + ".tmp"
+	err = vfs.WriteFile(j.fs, tmp, data, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "cannot write transfer journal %q", j.path)
+	}
+	err = j.fs.Rename(tmp, j.path)
+	if err != nil {
+		// not all filesystems support replacing files by a rename.
+		j.fs.Remove(j.path)
+		err = j.fs.Rename(tmp, j.path)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot write transfer journal %q", j.path)
+	}
+	return nil
+}
+
+func key(kind string, id metav1.Identity) string {
+	return kind + ":" + id.String()
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package journal_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
+)
+
+const JOURNAL = "/tmp/journal"
+
+var _ = Describe("transfer journal", func() {
+	var fs vfs.FileSystem
+
+	nv := common.NewNameVersion("acme.org/test", "v1")
+	id := metav1.NewIdentity("data")
+	artifact := &journal.Artifact{
+		Access:    []byte(`{"type":"localBlob"}`),
+		Digest:    digest.FromString("data"),
+		Size:      4,
+		MediaType: "text/plain",
+	}
+
+	BeforeEach(func() {
+		fs = memoryfs.New()
+		Expect(fs.MkdirAll("/tmp", 0o700)).To(Succeed())
+	})
+
+	It("starts empty without file", func() {
+		j, err := journal.Open(JOURNAL, fs)
+		Expect(err).To(Succeed())
+		Expect(j.Versions()).To(BeEmpty())
+		Expect(vfs.FileExists(fs, JOURNAL)).To(BeFalse())
+	})
+
+	It("persists artifacts", func() {
+		j, err := journal.Open(JOURNAL, fs)
+		Expect(err).To(Succeed())
+		Expect(j.AddArtifact(nv, journal.KIND_RESOURCE, id, artifact)).To(Succeed())
+		Expect(j.IsFinished(nv)).To(BeFalse())
+
+		j, err = journal.Open(JOURNAL, fs)
+		Expect(err).To(Succeed())
+		Expect(j.Versions()).To(Equal([]common.NameVersion{nv}))
+		Expect(j.GetArtifact(nv, journal.KIND_RESOURCE, id)).To(Equal(artifact))
+		Expect(j.GetArtifact(nv, journal.KIND_SOURCE, id)).To(BeNil())
+	})
+
+	It("finishes versions", func() {
+		j, err := journal.Open(JOURNAL, fs)
+		Expect(err).To(Succeed())
+		Expect(j.AddArtifact(nv, journal.KIND_RESOURCE, id, artifact)).To(Succeed())
+		Expect(j.Finish(nv)).To(Succeed())
+
+		j, err = journal.Open(JOURNAL, fs)
+		Expect(err).To(Succeed())
+		Expect(j.IsFinished(nv)).To(BeTrue())
+		Expect(j.GetArtifact(nv, journal.KIND_RESOURCE, id)).To(BeNil())
+
+		Expect(j.Reset(nv)).To(Succeed())
+		Expect(j.IsFinished(nv)).To(BeFalse())
+	})
+
+	It("is bound to a target", func() {
+		j, err := journal.Open(JOURNAL, fs)
+		Expect(err).To(Succeed())
+		Expect(j.AssureTarget([]byte(`{"type":"CommonTransportFormat","filePath":"/tmp/ctf"}`))).To(Succeed())
+
+		j, err = journal.Open(JOURNAL, fs)
+		Expect(err).To(Succeed())
+		Expect(j.AssureTarget([]byte(`{"filePath":"/tmp/ctf","type":"CommonTransportFormat"}`))).To(Succeed())
+		Expect(j.AssureTarget([]byte(`{"type":"CommonTransportFormat","filePath":"/tmp/other"}`))).To(MatchError(`transfer journal "/tmp/journal" has been recorded for a different target repository {"type":"CommonTransportFormat","filePath":"/tmp/ctf"}`))
+	})
+
+	It("rejects invalid files", func() {
+		Expect(vfs.WriteFile(fs, JOURNAL, []byte("{ invalid"), 0o600)).To(Succeed())
+		_, err := journal.Open(JOURNAL, fs)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"bytes"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// transferJob describes the transfer of a single artifact.
+// All output must be done using the given printer.
+type transferJob func(printer common.Printer) error
+
+type jobResult struct {
+	done   chan struct{}
+	output *bytes.Buffer
+	err    error
+}
+
+// runJobs executes the given jobs using a pool of n workers.
+// The output of every job is buffered and passed to the printer
+// in the order of the job list, as soon as all preceding jobs
+// are finished. This keeps the output deterministic, regardless of
+// the execution order. Errors are added to the error list in the
+// same order.
+func runJobs(printer common.Printer, n int, list *errors.ErrorList, jobs []transferJob) {
+	results := make([]*jobResult, len(jobs))
+	for i := range results {
+		results[i] = &jobResult{done: make(chan struct{})}
+	}
+
+	queue := make(chan int)
+	for w := 0; w < n && w < len(jobs); w++ {
+		go func() {
+			for i := range queue {
+				p, buf := common.NewBufferedPrinter()
+				results[i].output = buf
+				results[i].err = jobs[i](p)
+				close(results[i].done)
+			}
+		}()
+	}
+	go func() {
+		for i := range jobs {
+			queue <- i
+		}
+		close(queue)
+	}()
+
+	for _, r := range results {
+		<-r.done
+		if r.output.Len() > 0 {
+			printer.Printf("%s", r.output.String())
+		}
+		list.Add(r.err)
+	}
+}
@@ -0,0 +1,332 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"github.com/mandelsoft/logging"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	ocicpi "github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/none"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	ocmcpi "github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/finalizer"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Actions planned for a component version.
+const (
+	// ACTION_TRANSFER is used for versions not yet present in the target.
+	ACTION_TRANSFER = "transfer"
+	// ACTION_OVERWRITE is used for versions replacing an existing one.
+	ACTION_OVERWRITE = "overwrite"
+	// ACTION_SKIP is used for versions left untouched.
+	ACTION_SKIP = "skip"
+)
+
+// Transport modes planned for a resource or source.
+const (
+	// MODE_LOCAL is used for artifacts stored as local blob, which
+	// are always transported by value.
+	MODE_LOCAL = "local"
+	// MODE_VALUE is used for external artifacts transported by value.
+	MODE_VALUE = "value"
+	// MODE_REFERENCE is used for external artifacts kept by reference.
+	MODE_REFERENCE = "reference"
+	// MODE_NONE is used for artifacts without access.
+	MODE_NONE = "none"
+)
+
+// Plan describes the actions a transfer of a component version
+// graph would execute, without executing any of them.
+type Plan struct {
+	Target   *runtime.UnstructuredTypedObject `json:"target,omitempty"`
+	Versions []*VersionPlan                   `json:"versions"`
+}
+
+// Size returns the number of bytes planned to be transported.
+// Artifacts with unknown size are ignored.
+func (p *Plan) Size() int64 {
+	var size int64
+	for _, v := range p.Versions {
+		size += v.Size()
+	}
+	return size
+}
+
+// UnknownSizes returns the number of artifacts planned to be
+// transported, whose size is unknown.
+func (p *Plan) UnknownSizes() int {
+	cnt := 0
+	for _, v := range p.Versions {
+		cnt += v.UnknownSizes()
+	}
+	return cnt
+}
+
+// VersionPlan describes the planned handling of a single component version.
+type VersionPlan struct {
+	Component  string           `json:"component"`
+	Version    string           `json:"version"`
+	Action     string           `json:"action"`
+	Reason     string           `json:"reason,omitempty"`
+	Resources  []*ArtifactPlan  `json:"resources,omitempty"`
+	Sources    []*ArtifactPlan  `json:"sources,omitempty"`
+	References []*ReferencePlan `json:"references,omitempty"`
+}
+
+func (v *VersionPlan) Size() int64 {
+	var size int64
+	for _, a := range v.Resources {
+		if a.Size > 0 {
+			size += a.Size
+		}
+	}
+	for _, a := range v.Sources {
+		if a.Size > 0 {
+			size += a.Size
+		}
+	}
+	return size
+}
+
+// UnknownSizes returns the number of artifacts planned to be
+// transported, whose size is unknown.
+func (v *VersionPlan) UnknownSizes() int {
+	cnt := 0
+	for _, list := range [][]*ArtifactPlan{v.Resources, v.Sources} {
+		for _, a := range list {
+			if a.Size < 0 {
+				cnt++
+			}
+		}
+	}
+	return cnt
+}
+
+// ArtifactPlan describes the planned handling of a resource or source.
+// Size is the number of bytes to transport, -1 if it cannot be
+// determined, and 0 if the artifact is not transported by value.
+type ArtifactPlan struct {
+	Index      int    `json:"index"`
+	Name       string `json:"name"`
+	Version    string `json:"version,omitempty"`
+	Type       string `json:"type"`
+	AccessType string `json:"accessType"`
+	Mode       string `json:"mode"`
+	Size       int64  `json:"size"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ReferencePlan describes the planned handling of a component reference.
+// Repository is the repository the referenced version is taken from,
+// which may be changed by the transfer handler.
+type ReferencePlan struct {
+	Name       string                           `json:"name"`
+	Component  string                           `json:"component"`
+	Version    string                           `json:"version"`
+	Transfer   bool                             `json:"transfer"`
+	Repository *runtime.UnstructuredTypedObject `json:"repository,omitempty"`
+}
+
+// PlanVersion evaluates the decisions of the given transfer handler
+// for transferring a component version graph to the given target repository
+// like TransferVersion, but does not modify the target.
+func PlanVersion(closure TransportClosure, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler transferhandler.TransferHandler) (*Plan, error) {
+	if closure == nil {
+		closure = TransportClosure{}
+	}
+	plan := &Plan{}
+	if !ocm.IsIntermediate(tgt.GetSpecification()) {
+		plan.Target, _ = runtime.ToUnstructuredTypedObject(tgt.GetSpecification())
+	}
+	state := WalkingState{Closure: closure}
+	err := planVersion(plan, Logger(src), state, src, tgt, handler)
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func planVersion(plan *Plan, log logging.Logger, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler transferhandler.TransferHandler) error {
+	nv := common.VersionedElementKey(src)
+	log = log.WithValues("history", state.History.String(), "version", nv)
+	if ok, err := state.Add(ocm.KIND_COMPONENTVERSION, nv); !ok {
+		return err
+	}
+	log.Info("planning version")
+	if handler == nil {
+		var err error
+		handler, err = standard.New(standard.Overwrite())
+		if err != nil {
+			return err
+		}
+	}
+
+	v := &VersionPlan{
+		Component: src.GetName(),
+		Version:   src.GetVersion(),
+		Action:    ACTION_TRANSFER,
+	}
+	plan.Versions = append(plan.Versions, v)
+
+	j := transferhandler.GetJournal(handler)
+	if j != nil && j.IsFinished(nv) {
+		if ok, err := tgt.ExistsComponentVersion(src.GetName(), src.GetVersion()); ok && err == nil {
+			v.Action = ACTION_SKIP
+			v.Reason = "already transferred according to journal"
+			return nil
+		}
+	}
+
+	t, err := tgt.LookupComponentVersion(src.GetName(), src.GetVersion())
+	if err != nil {
+		if !errors.IsErrNotFound(err) {
+			return errors.Wrapf(err, "%s: lookup target version", state.History)
+		}
+	} else {
+		ok, err := handler.OverwriteVersion(src, t)
+		t.Close()
+		if err != nil {
+			return errors.Wrapf(err, "%s: checking target version", state.History)
+		}
+		if !ok {
+			v.Action = ACTION_SKIP
+			v.Reason = "already present"
+			return nil
+		}
+		v.Action = ACTION_OVERWRITE
+	}
+
+	for i, r := range src.GetResources() {
+		p, err := planArtifact(src, i, &r.Meta().ElementMeta, r.Meta().GetType(), r, func(a ocm.AccessSpec) (bool, error) { return handler.TransferResource(src, a, r) })
+		if err != nil {
+			return errors.Wrapf(err, "%s: planning resource %d", state.History, i)
+		}
+		v.Resources = append(v.Resources, p)
+	}
+	for i, r := range src.GetSources() {
+		p, err := planArtifact(src, i, &r.Meta().ElementMeta, r.Meta().GetType(), r, func(a ocm.AccessSpec) (bool, error) { return handler.TransferSource(src, a, r) })
+		if err != nil {
+			return errors.Wrapf(err, "%s: planning source %d", state.History, i)
+		}
+		v.Sources = append(v.Sources, p)
+	}
+
+	list := errors.ErrListf("component references for %s", nv)
+	for _, r := range src.GetDescriptor().References {
+		cv, shdlr, err := handler.TransferVersion(src.Repository(), src, &r, tgt)
+		if err != nil {
+			return errors.Wrapf(err, "%s: nested component %s[%s:%s]", state.History, r.GetName(), r.ComponentName, r.GetVersion())
+		}
+		ref := &ReferencePlan{
+			Name:      r.GetName(),
+			Component: r.ComponentName,
+			Version:   r.GetVersion(),
+			Transfer:  cv != nil,
+		}
+		v.References = append(v.References, ref)
+		if cv != nil {
+			ref.Repository, _ = runtime.ToUnstructuredTypedObject(cv.Repository().GetSpecification())
+			list.Add(planVersion(plan, log.WithValues("ref", r.Name), state, cv, tgt, shdlr))
+			cv.Close()
+		}
+	}
+	return list.Result()
+}
+
+type artifactAccess interface {
+	Access() (ocm.AccessSpec, error)
+	AccessMethod() (ocm.AccessMethod, error)
+}
+
+func planArtifact(src ocm.ComponentVersionAccess, i int, meta *compdesc.ElementMeta, typ string, r artifactAccess, byValue func(a ocm.AccessSpec) (bool, error)) (*ArtifactPlan, error) {
+	p := &ArtifactPlan{
+		Index:   i,
+		Name:    meta.GetName(),
+		Version: meta.GetVersion(),
+		Type:    typ,
+		Mode:    MODE_REFERENCE,
+	}
+	a, err := r.Access()
+	if err == nil {
+		p.AccessType = a.GetType()
+		ok := a.IsLocal(src.GetContext())
+		if ok {
+			p.Mode = MODE_LOCAL
+		} else {
+			if a.GetKind() == none.Type {
+				p.Mode = MODE_NONE
+			} else {
+				ok, err = byValue(a)
+				if ok {
+					p.Mode = MODE_VALUE
+				}
+			}
+		}
+		if err == nil && ok {
+			var m ocm.AccessMethod
+			m, err = r.AccessMethod()
+			if err == nil {
+				p.Size, err = blobSize(src, m)
+				m.Close()
+				if err != nil {
+					p.Size = -1
+					p.Error = err.Error()
+					err = nil
+				}
+			}
+		}
+	}
+	if err != nil {
+		if !errors.IsErrUnknownKind(err, errors.KIND_ACCESSMETHOD) {
+			return nil, err
+		}
+		p.Mode = MODE_REFERENCE
+		p.Size = 0
+		p.Error = err.Error() + " (enforce transport by reference)"
+	}
+	return p, nil
+}
+
+// blobSize determines the number of bytes transported for an access method.
+// OCI artifacts are measured by their manifests and blobs. For other blobs
+// the size is reported as unknown, if it is not provided by the access method,
+// because a plan must not download the blob content.
+func blobSize(src ocm.ComponentVersionAccess, m ocm.AccessMethod) (int64, error) {
+	if s, ok := m.(interface{ Size() int64 }); ok && s.Size() != accessio.BLOB_UNKNOWN_SIZE {
+		return s.Size(), nil
+	}
+	if l, ok := m.AccessSpec().(*localblob.AccessSpec); ok {
+		// the storage backend knows the size of local blobs.
+		if c, ok := src.(ocm.LocalBlobChecker); ok {
+			if d, err := digest.Parse(l.LocalReference); err == nil {
+				if found, s, err := c.HasLocalBlob(d); err == nil && found {
+					return s, nil
+				}
+			}
+		}
+	}
+	if o, ok := m.(ociartifact.AccessMethod); ok {
+		var finalize finalizer.Finalizer
+		defer finalize.Finalize()
+
+		art, _, err := o.GetArtifact(&finalize)
+		if err != nil {
+			return -1, err
+		}
+		finalize.Close(art)
+		return ocicpi.ArtifactSize(art)
+	}
+	return accessio.BLOB_UNKNOWN_SIZE, nil
+}
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
+	. "github.com/open-component-model/ocm/pkg/env"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	ocictf "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	ARCH       = "/tmp/ctf"
+	OUT        = "/tmp/res"
+	COMPARCH   = "/tmp/ca"
+	OCIPATH    = "/tmp/oci"
+	OCIHOST    = "alias"
+	PROVIDER   = "mandelsoft"
+	VERSION    = "v1"
+	COMPONENT  = "github.com/mandelsoft/test"
+	COMPONENT2 = "github.com/mandelsoft/test2"
+)
+
+var _ = Describe("Transfer plan", func() {
+	var env *Builder
+	var artsize int64
+
+	BeforeEach(func() {
+		env = NewBuilder(NewEnvironment())
+
+		env.OCICommonTransport(OCIPATH, accessio.FormatDirectory, func() {
+			OCIManifest1(env)
+		})
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMPONENT, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, "testdata")
+					})
+					env.Resource("artifact", "", resourcetypes.OCI_IMAGE, metav1.LocalRelation, func() {
+						env.Access(
+							ociartifact.New(oci.StandardOCIRef(OCIHOST+".alias", OCINAMESPACE, OCIVERSION)),
+						)
+					})
+				})
+			})
+			env.Component(COMPONENT2, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					env.Reference("ref", COMPONENT, VERSION)
+				})
+			})
+		})
+
+		FakeOCIRepo(env, OCIPATH, OCIHOST)
+
+		repo := Must(env.OCIContext().RepositoryForSpec(env.OCIContext().GetAlias(OCIHOST)))
+		defer Close(repo)
+		art := Must(repo.LookupArtifact(OCINAMESPACE, OCIVERSION))
+		defer Close(art)
+		blob := Must(art.Blob())
+		defer Close(blob)
+		m := Must(art.Manifest())
+		artsize = blob.Size() + m.Config.Size
+		for _, l := range m.Layers {
+			artsize += l.Size
+		}
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("plans a recursive transfer by value without writing", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src)
+		cv := Must(src.LookupComponentVersion(COMPONENT2, VERSION))
+		defer Close(cv)
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt)
+
+		handler := Must(standard.New(standard.Recursive(), standard.ResourcesByValue()))
+		plan := Must(transfer.PlanVersion(nil, cv, tgt, handler))
+
+		Expect(len(plan.Versions)).To(Equal(2))
+		Expect(plan.Versions[0].Action).To(Equal(transfer.ACTION_TRANSFER))
+		Expect(plan.Versions[0].References).To(Equal([]*transfer.ReferencePlan{
+			{
+				Name:       "ref",
+				Component:  COMPONENT,
+				Version:    VERSION,
+				Transfer:   true,
+				Repository: plan.Versions[0].References[0].Repository,
+			},
+		}))
+		Expect(plan.Versions[0].References[0].Repository.GetType()).To(Equal(ocictf.Type))
+
+		v := plan.Versions[1]
+		Expect(v.Component).To(Equal(COMPONENT))
+		Expect(v.Action).To(Equal(transfer.ACTION_TRANSFER))
+		Expect(v.Resources).To(Equal([]*transfer.ArtifactPlan{
+			{Index: 0, Name: "testdata", Version: VERSION, Type: "PlainText", AccessType: "localBlob", Mode: transfer.MODE_LOCAL, Size: 8},
+			{Index: 1, Name: "artifact", Version: VERSION, Type: resourcetypes.OCI_IMAGE, AccessType: ociartifact.Type, Mode: transfer.MODE_VALUE, Size: artsize},
+		}))
+		Expect(plan.Size()).To(Equal(8 + artsize))
+
+		Expect(Must(tgt.ComponentLister().GetComponents("", true))).To(BeEmpty())
+	})
+
+	It("plans transfer by reference", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src)
+		cv := Must(src.LookupComponentVersion(COMPONENT2, VERSION))
+		defer Close(cv)
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt)
+
+		plan := Must(transfer.PlanVersion(nil, cv, tgt, Must(standard.New())))
+
+		Expect(len(plan.Versions)).To(Equal(1))
+		Expect(plan.Versions[0].References[0].Transfer).To(BeFalse())
+		Expect(plan.Versions[0].References[0].Repository).To(BeNil())
+	})
+
+	It("reports unknown sizes without reading blobs", func() {
+		env.ComponentArchive(COMPARCH, accessio.FormatDirectory, COMPONENT, VERSION, func() {
+			env.Provider(PROVIDER)
+			env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+				env.BlobStringData(mime.MIME_TEXT, "testdata")
+			})
+		})
+		cv := Must(comparch.Open(env.OCMContext(), accessobj.ACC_READONLY, COMPARCH, 0, env))
+		defer Close(cv)
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt)
+
+		plan := Must(transfer.PlanVersion(nil, cv, tgt, Must(standard.New())))
+		Expect(plan.Versions[0].Resources[0].Size).To(Equal(int64(accessio.BLOB_UNKNOWN_SIZE)))
+		Expect(plan.Versions[0].Resources[0].Error).To(BeEmpty())
+		Expect(plan.UnknownSizes()).To(Equal(1))
+		Expect(plan.Size()).To(Equal(int64(0)))
+	})
+
+	It("plans skip and overwrite for existing versions", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src)
+		cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(cv)
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt)
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, tgt, Must(standard.New())))
+
+		plan := Must(transfer.PlanVersion(nil, cv, tgt, Must(standard.New())))
+		Expect(plan.Versions[0].Action).To(Equal(transfer.ACTION_SKIP))
+		Expect(plan.Versions[0].Resources).To(BeNil())
+		Expect(plan.Size()).To(Equal(int64(0)))
+
+		plan = Must(transfer.PlanVersion(nil, cv, tgt, Must(standard.New(standard.Overwrite()))))
+		Expect(plan.Versions[0].Action).To(Equal(transfer.ACTION_OVERWRITE))
+		Expect(plan.Versions[0].Resources[1].Mode).To(Equal(transfer.MODE_REFERENCE))
+		Expect(plan.Size()).To(Equal(int64(8)))
+	})
+})
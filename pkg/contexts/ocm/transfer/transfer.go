@@ -5,12 +5,14 @@
 package transfer
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/mandelsoft/logging"
 
 	"github.com/open-component-model/ocm/pkg/common"
 	"github.com/open-component-model/ocm/pkg/common/accessio"
+	ocicpi "github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/none"
 	ocmcpi "github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
@@ -31,10 +33,39 @@ func TransferVersion(printer common.Printer, closure TransportClosure, src ocmcp
 	if printer == nil {
 		printer = common.NewPrinter(nil)
 	}
+	if j := transferhandler.GetJournal(handler); j != nil {
+		data, err := targetIdentity(tgt)
+		if err != nil {
+			return errors.Wrapf(err, "cannot marshal target repository specification")
+		}
+		err = j.AssureTarget(data)
+		if err != nil {
+			return err
+		}
+	}
 	state := WalkingState{Closure: closure}
 	return transferVersion(printer, Logger(src), state, src, tgt, handler)
 }
 
+// targetIdentity provides a serialized identity of the target repository,
+// which is independent of access options like the access mode.
+func targetIdentity(tgt ocmcpi.Repository) ([]byte, error) {
+	spec := tgt.GetSpecification()
+	u := spec.AsUniformSpec(tgt.GetContext())
+	u.CreateIfMissing = false
+	u.TypeHint = ""
+	if o, ok := spec.(interface {
+		UniformRepositorySpec() *ocicpi.UniformRepositorySpec
+	}); ok {
+		// OCI based repositories provide the location with the OCI specification.
+		if ou := o.UniformRepositorySpec(); ou != nil {
+			u.Host = ou.Host
+			u.Info = ou.Info
+		}
+	}
+	return json.Marshal(&u)
+}
+
 func transferVersion(printer common.Printer, log logging.Logger, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler transferhandler.TransferHandler) error {
 	nv := common.VersionedElementKey(src)
 	log = log.WithValues("history", state.History.String(), "version", nv)
@@ -51,6 +82,15 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 		}
 	}
 
+	j := transferhandler.GetJournal(handler)
+	if j != nil && j.IsFinished(nv) {
+		if ok, err := tgt.ExistsComponentVersion(src.GetName(), src.GetVersion()); ok && err == nil {
+			printer.Printf("  version %q already transferred according to journal -> skip transport\n", nv)
+			return nil
+		}
+		log.Info("  version recorded as finished in journal, but not found in target -> transfer again")
+	}
+
 	d := src.GetDescriptor()
 
 	comp, err := tgt.LookupComponent(src.GetName())
@@ -110,72 +150,111 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 	cd.Signatures = src.GetDescriptor().Signatures.Copy()
 	printer.Printf("...adding component version...\n")
 	log.Info("  adding component version")
-	return list.Add(comp.AddVersion(t)).Result()
+	err = comp.AddVersion(t)
+	if err == nil && j != nil && list.Result() == nil {
+		err = j.Finish(nv)
+	}
+	return list.Add(err).Result()
 }
 
 func CopyVersion(printer common.Printer, log logging.Logger, hist common.History, src ocm.ComponentVersionAccess, t ocm.ComponentVersionAccess, handler transferhandler.TransferHandler) error {
 	if handler == nil {
 		handler = standard.NewDefaultHandler(nil)
 	}
+	if printer == nil {
+		printer = common.NewPrinter(nil)
+	}
 
 	*t.GetDescriptor() = *src.GetDescriptor().Copy()
-	log.Info("  transferring resources")
+
+	var jobs []transferJob
 	for i, r := range src.GetResources() {
-		var m ocm.AccessMethod
-		a, err := r.Access()
+		i, r := i, r
+		jobs = append(jobs, func(printer common.Printer) error {
+			return copyResource(printer, log, hist, src, i, r, t, handler)
+		})
+	}
+	for i, r := range src.GetSources() {
+		i, r := i, r
+		jobs = append(jobs, func(printer common.Printer) error {
+			return copySource(printer, log, hist, src, i, r, t, handler)
+		})
+	}
+
+	if n := transferhandler.GetConcurrency(handler); n > 1 {
+		log.Info("  transferring artifacts", "concurrency", n)
+		list := errors.ErrListf("transferring artifacts of %s", common.VersionedElementKey(src))
+		runJobs(printer, n, list, jobs)
+		return list.Result()
+	}
+
+	log.Info("  transferring resources")
+	for i, job := range jobs {
+		if i == len(src.GetResources()) {
+			log.Info("  transferring sources")
+		}
+		if err := job(printer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyResource(printer common.Printer, log logging.Logger, hist common.History, src ocm.ComponentVersionAccess, i int, r ocm.ResourceAccess, t ocm.ComponentVersionAccess, handler transferhandler.TransferHandler) error {
+	var m ocm.AccessMethod
+	a, err := r.Access()
+	if err == nil {
+		m, err = r.AccessMethod()
 		if err == nil {
-			m, err = r.AccessMethod()
-			if err == nil {
-				defer m.Close()
-				ok := a.IsLocal(src.GetContext())
-				if !ok {
-					if a.GetKind() != none.Type {
-						ok, err = handler.TransferResource(src, a, r)
-					}
-				}
-				if ok {
-					hint := ocmcpi.ArtifactNameHint(a, src)
-					printArtifactInfo(printer, log, "resource", i, hint)
-					err = handler.HandleTransferResource(r, m, hint, t)
+			defer m.Close()
+			ok := a.IsLocal(src.GetContext())
+			if !ok {
+				if a.GetKind() != none.Type {
+					ok, err = handler.TransferResource(src, a, r)
 				}
 			}
-		}
-		if err != nil {
-			if !errors.IsErrUnknownKind(err, errors.KIND_ACCESSMETHOD) {
-				return errors.Wrapf(err, "%s: transferring resource %d", hist, i)
+			if ok {
+				hint := ocmcpi.ArtifactNameHint(a, src)
+				printArtifactInfo(printer, log, "resource", i, hint)
+				err = handler.HandleTransferResource(r, m, hint, t)
 			}
-			printer.Printf("WARN: %s: transferring resource %d: %s (enforce transport by reference)\n", hist, i, err)
 		}
 	}
+	if err != nil {
+		if !errors.IsErrUnknownKind(err, errors.KIND_ACCESSMETHOD) {
+			return errors.Wrapf(err, "%s: transferring resource %d", hist, i)
+		}
+		printer.Printf("WARN: %s: transferring resource %d: %s (enforce transport by reference)\n", hist, i, err)
+	}
+	return nil
+}
 
-	log.Info("  transferring sources")
-	for i, r := range src.GetSources() {
-		var m ocm.AccessMethod
-		a, err := r.Access()
+func copySource(printer common.Printer, log logging.Logger, hist common.History, src ocm.ComponentVersionAccess, i int, r ocm.SourceAccess, t ocm.ComponentVersionAccess, handler transferhandler.TransferHandler) error {
+	var m ocm.AccessMethod
+	a, err := r.Access()
+	if err == nil {
+		m, err = r.AccessMethod()
 		if err == nil {
-			m, err = r.AccessMethod()
-			if err == nil {
-				defer m.Close()
-				ok := a.IsLocal(src.GetContext())
-				if !ok {
-					if a.GetKind() != none.Type {
-						ok, err = handler.TransferSource(src, a, r)
-					}
-				}
-				if ok {
-					hint := ocmcpi.ArtifactNameHint(a, src)
-					printArtifactInfo(printer, log, "source", i, hint)
-					err = handler.HandleTransferSource(r, m, hint, t)
+			defer m.Close()
+			ok := a.IsLocal(src.GetContext())
+			if !ok {
+				if a.GetKind() != none.Type {
+					ok, err = handler.TransferSource(src, a, r)
 				}
 			}
-		}
-		if err != nil {
-			if !errors.IsErrUnknownKind(err, errors.KIND_ACCESSMETHOD) {
-				return errors.Wrapf(err, "%s: transferring source %d", hist, i)
+			if ok {
+				hint := ocmcpi.ArtifactNameHint(a, src)
+				printArtifactInfo(printer, log, "source", i, hint)
+				err = handler.HandleTransferSource(r, m, hint, t)
 			}
-			printer.Printf("WARN: %s: transferring source %d: %s (enforce transport by reference)\n", hist, i, err)
 		}
 	}
+	if err != nil {
+		if !errors.IsErrUnknownKind(err, errors.KIND_ACCESSMETHOD) {
+			return errors.Wrapf(err, "%s: transferring source %d", hist, i)
+		}
+		printer.Printf("WARN: %s: transferring source %d: %s (enforce transport by reference)\n", hist, i, err)
+	}
 	return nil
 }
 
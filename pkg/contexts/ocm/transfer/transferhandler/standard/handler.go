@@ -5,22 +5,32 @@
 package standard
 
 import (
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common"
 	"github.com/open-component-model/ocm/pkg/common/accessio"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localblob"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
 	"github.com/open-component-model/ocm/pkg/errors"
 )
 
 type Handler struct {
-	opts *Options
+	opts  *Options
+	saved *int64
 }
 
 func NewDefaultHandler(opts *Options) *Handler {
 	if opts == nil {
 		opts = &Options{}
 	}
-	return &Handler{opts: opts}
+	return &Handler{opts: opts, saved: new(int64)}
 }
 
 func New(opts ...transferhandler.TransferOption) (transferhandler.TransferHandler, error) {
@@ -32,6 +42,20 @@ func New(opts ...transferhandler.TransferOption) (transferhandler.TransferHandle
 	return NewDefaultHandler(defaultOpts), nil
 }
 
+func (h *Handler) GetConcurrency() int {
+	return h.opts.GetConcurrency()
+}
+
+func (h *Handler) GetJournal() *journal.Journal {
+	return h.opts.GetJournal()
+}
+
+// GetSavedBytes returns the number of bytes not transferred,
+// because the blobs were already present in the target.
+func (h *Handler) GetSavedBytes() int64 {
+	return atomic.LoadInt64(h.saved)
+}
+
 func (h *Handler) OverwriteVersion(src ocm.ComponentVersionAccess, tgt ocm.ComponentVersionAccess) (bool, error) {
 	return h.opts.IsOverwrite(), nil
 }
@@ -59,16 +83,127 @@ func (h *Handler) TransferSource(src ocm.ComponentVersionAccess, a ocm.AccessSpe
 }
 
 func (h *Handler) HandleTransferResource(r ocm.ResourceAccess, m ocm.AccessMethod, hint string, t ocm.ComponentVersionAccess) error {
-	blob := accessio.BlobAccessForDataAccess("", -1, m.MimeType(), m)
+	var id metav1.Identity
+	readDescriptor(t, func(cd *compdesc.ComponentDescriptor) { id = r.Meta().GetIdentity(cd.Resources) })
+	blob := h.BlobAccess(t, journal.KIND_RESOURCE, id, m)
 	defer blob.Close()
-	return t.SetResourceBlob(r.Meta(), blob, hint, h.GlobalAccess(t.GetContext(), m))
+	err := t.SetResourceBlob(r.Meta(), blob, hint, h.GlobalAccess(t.GetContext(), m))
+	if err != nil {
+		return err
+	}
+	return h.RecordBlob(t, journal.KIND_RESOURCE, id, m, blob)
 }
 
 func (h *Handler) HandleTransferSource(r ocm.SourceAccess, m ocm.AccessMethod, hint string, t ocm.ComponentVersionAccess) error {
-	blob := accessio.BlobAccessForDataAccess("", -1, m.MimeType(), m)
+	var id metav1.Identity
+	readDescriptor(t, func(cd *compdesc.ComponentDescriptor) { id = r.Meta().GetIdentity(cd.Sources) })
+	blob := h.BlobAccess(t, journal.KIND_SOURCE, id, m)
 	defer blob.Close()
 
-	return t.SetSourceBlob(r.Meta(), blob, hint, h.GlobalAccess(t.GetContext(), m))
+	err := t.SetSourceBlob(r.Meta(), blob, hint, h.GlobalAccess(t.GetContext(), m))
+	if err != nil {
+		return err
+	}
+	return h.RecordBlob(t, journal.KIND_SOURCE, id, m, blob)
+}
+
+// readDescriptor provides synchronized read access to the descriptor
+// of a component version possibly modified by concurrent transfers.
+func readDescriptor(cv ocm.ComponentVersionAccess, f func(cd *compdesc.ComponentDescriptor)) {
+	if r, ok := cv.(ocm.DescriptorReader); ok {
+		r.ReadDescriptor(f)
+	} else {
+		f(cv.GetDescriptor())
+	}
+}
+
+// BlobAccess provides the blob access for the transfer of an artifact.
+// If a transfer journal is configured and the blob has already been
+// transferred by a previous run, the recorded digest and size are used,
+// to avoid reading the blob again just to determine its digest.
+// The recorded digest is only used, if the target confirms the existence
+// of the blob. Otherwise, the blob might be stored under a digest not
+// matching its actual content.
+// In delta mode, the digest of a local blob is taken from its access
+// specification. If the target already provides a blob with this digest,
+// the digest and size are passed along, so that the storage layer can skip
+// the upload without reading the blob.
+// Bytes are counted as saved only, if the storage layer confirms
+// a skipped upload (see accessio.StorageObserver).
+func (h *Handler) BlobAccess(t ocm.ComponentVersionAccess, kind string, id metav1.Identity, m ocm.AccessMethod) accessio.AnnotatedBlobAccess[ocm.AccessMethod] {
+	dig, size := digest.Digest(""), int64(accessio.BLOB_UNKNOWN_SIZE)
+	if j := h.opts.GetJournal(); j != nil {
+		if a := j.GetArtifact(common.VersionedElementKey(t), kind, id); a != nil && a.MediaType == m.MimeType() {
+			if data, err := json.Marshal(m.AccessSpec()); err == nil && a.MatchAccess(data) {
+				if c, ok := t.(ocm.LocalBlobChecker); ok {
+					if found, _, err := c.HasLocalBlob(a.Digest); err == nil && found {
+						dig, size = a.Digest, a.Size
+					}
+				}
+			}
+		}
+	}
+	if h.opts.IsDelta() {
+		if c, ok := t.(ocm.LocalBlobChecker); ok {
+			d := dig
+			if d == "" {
+				d = localDigest(m.AccessSpec())
+			}
+			if d != "" {
+				if found, s, err := c.HasLocalBlob(d); err == nil && found && s >= 0 {
+					dig, size = d, s
+				}
+			}
+		}
+	}
+	return &observedBlob{accessio.BlobAccessForDataAccess(dig, size, m.MimeType(), m), h.saved}
+}
+
+// observedBlob counts the bytes of blobs, whose upload has been
+// skipped by the storage layer, because the target already
+// provides the content.
+type observedBlob struct {
+	accessio.AnnotatedBlobAccess[ocm.AccessMethod]
+	saved *int64
+}
+
+var _ accessio.StorageObserver = (*observedBlob)(nil)
+
+func (b *observedBlob) BlobSkipped(size int64) {
+	if size > 0 {
+		atomic.AddInt64(b.saved, size)
+	}
+}
+
+// localDigest determines the digest of a local blob from its access
+// specification, if the local reference is a digest.
+func localDigest(spec ocm.AccessSpec) digest.Digest {
+	if l, ok := spec.(*localblob.AccessSpec); ok {
+		if d, err := digest.Parse(l.LocalReference); err == nil {
+			return d
+		}
+	}
+	return ""
+}
+
+// RecordBlob records the digest of a transferred blob in a configured transfer journal.
+// Blobs with an unknown digest are not recorded, because determining the
+// digest would require to read the blob again.
+func (h *Handler) RecordBlob(t ocm.ComponentVersionAccess, kind string, id metav1.Identity, m ocm.AccessMethod, blob accessio.BlobAccess) error {
+	j := h.opts.GetJournal()
+	if j == nil || !blob.DigestKnown() {
+		return nil
+	}
+	data, err := json.Marshal(m.AccessSpec())
+	if err != nil {
+		return err
+	}
+	return j.AddArtifact(common.VersionedElementKey(t), kind, id, &journal.Artifact{
+		Access:    data,
+		Digest:    blob.Digest(),
+		Size:      blob.Size(),
+		MediaType: blob.MimeType(),
+	})
 }
 
 func (h *Handler) GlobalAccess(ctx ocm.Context, m ocm.AccessMethod) ocm.AccessSpec {
@@ -146,6 +146,9 @@ var _ = Describe("Transfer handler", func() {
 		Entry("with preserve global",
 			"{\"globalAccess\":{\"imageReference\":\"alias.alias/ocm/value:v2.0\",\"type\":\"ociArtifact\"},\"localReference\":\"%s\",\"mediaType\":\"application/vnd.oci.image.manifest.v1+tar+gzip\",\"referenceName\":\"ocm/value:v2.0\",\"type\":\"localBlob\"}",
 			standard.KeepGlobalAccess()),
+		Entry("with concurrency",
+			"{\"localReference\":\"%s\",\"mediaType\":\"application/vnd.oci.image.manifest.v1+tar+gzip\",\"referenceName\":\""+OCINAMESPACE+":"+OCIVERSION+"\",\"type\":\"localBlob\"}",
+			standard.Concurrency(2)),
 	)
 
 	It("it should use additional resolver to resolve component ref", func() {
@@ -216,4 +219,27 @@ var _ = Describe("Transfer handler", func() {
 		Expect(err).To(Succeed())
 		Expect(dig.Value).To(Equal(digest))
 	})
+
+	It("it should skip unchanged local blobs in delta mode", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src)
+		cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(cv)
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0700, accessio.FormatDirectory, env))
+		defer Close(tgt)
+
+		handler := Must(standard.New(standard.Overwrite(), standard.Delta()))
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, tgt, handler))
+		Expect(transferhandler.GetSavedBytes(handler)).To(Equal(int64(0)))
+
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, tgt, handler))
+		Expect(transferhandler.GetSavedBytes(handler)).To(Equal(int64(8)))
+
+		comp := Must(tgt.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(comp)
+		r := Must(comp.GetResourceByIndex(0))
+		meth := Must(r.AccessMethod())
+		defer Close(meth)
+		Expect(string(Must(meth.Get()))).To(Equal("testdata"))
+	})
 })
@@ -6,6 +6,7 @@ package standard
 
 import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
 	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/utils"
@@ -18,6 +19,9 @@ type Options struct {
 	keepGlobalAccess bool
 	stopOnExisting   bool
 	overwrite        bool
+	delta            bool
+	concurrency      int
+	journal          *journal.Journal
 	resolver         ocm.ComponentVersionResolver
 }
 
@@ -27,6 +31,9 @@ var (
 	_ RecursiveOption        = (*Options)(nil)
 	_ ResolverOption         = (*Options)(nil)
 	_ KeepGlobalAccessOption = (*Options)(nil)
+	_ ConcurrencyOption      = (*Options)(nil)
+	_ JournalOption          = (*Options)(nil)
+	_ DeltaOption            = (*Options)(nil)
 )
 
 func (o *Options) SetOverwrite(overwrite bool) {
@@ -77,6 +84,30 @@ func (o *Options) GetResolver() ocm.ComponentVersionResolver {
 	return o.resolver
 }
 
+func (o *Options) SetConcurrency(concurrency int) {
+	o.concurrency = concurrency
+}
+
+func (o *Options) GetConcurrency() int {
+	return o.concurrency
+}
+
+func (o *Options) SetDelta(delta bool) {
+	o.delta = delta
+}
+
+func (o *Options) IsDelta() bool {
+	return o.delta
+}
+
+func (o *Options) SetJournal(j *journal.Journal) {
+	o.journal = j
+}
+
+func (o *Options) GetJournal() *journal.Journal {
+	return o.journal
+}
+
 func (o *Options) SetStopOnExistingVersion(stopOnExistingVersion bool) {
 	o.stopOnExisting = stopOnExistingVersion
 }
@@ -266,3 +297,88 @@ func StopOnExistingVersion(args ...bool) transferhandler.TransferOption {
 		flag: utils.GetOptionFlag(args...),
 	}
 }
+
+///////////////////////////////////////////////////////////////////////////////
+
+type ConcurrencyOption interface {
+	SetConcurrency(int)
+	GetConcurrency() int
+}
+
+type concurrencyOption struct {
+	concurrency int
+}
+
+func (o *concurrencyOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(ConcurrencyOption); ok {
+		eff.SetConcurrency(o.concurrency)
+		return nil
+	} else {
+		return errors.ErrNotSupported("concurrency")
+	}
+}
+
+// Concurrency sets the number of resources and sources of a component
+// version, which are transferred in parallel. A value less or equal to one
+// means sequential transfer.
+func Concurrency(n int) transferhandler.TransferOption {
+	return &concurrencyOption{
+		concurrency: n,
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type JournalOption interface {
+	SetJournal(*journal.Journal)
+	GetJournal() *journal.Journal
+}
+
+type journalOption struct {
+	journal *journal.Journal
+}
+
+func (o *journalOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(JournalOption); ok {
+		eff.SetJournal(o.journal)
+		return nil
+	} else {
+		return errors.ErrNotSupported("journal")
+	}
+}
+
+// Journal sets a transfer journal used to record the transfer
+// progress and to resume a previously interrupted transfer.
+func Journal(j *journal.Journal) transferhandler.TransferOption {
+	return &journalOption{
+		journal: j,
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type DeltaOption interface {
+	SetDelta(bool)
+	IsDelta() bool
+}
+
+type deltaOption struct {
+	flag bool
+}
+
+func (o *deltaOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(DeltaOption); ok {
+		eff.SetDelta(o.flag)
+		return nil
+	} else {
+		return errors.ErrNotSupported("delta")
+	}
+}
+
+// Delta enables the incremental transfer of local blobs. Blobs with
+// a known digest already present in the target are not copied again.
+func Delta(args ...bool) transferhandler.TransferOption {
+	return &deltaOption{
+		flag: utils.GetOptionFlag(args...),
+	}
+}
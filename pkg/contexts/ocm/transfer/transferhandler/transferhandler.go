@@ -7,6 +7,7 @@ package transferhandler
 import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
 	"github.com/open-component-model/ocm/pkg/errors"
 )
 
@@ -27,6 +28,56 @@ type TransferHandler interface {
 	HandleTransferSource(r ocm.SourceAccess, m ocm.AccessMethod, hint string, t ocm.ComponentVersionAccess) error
 }
 
+// ConcurrentTransferHandler is an optional interface of a TransferHandler
+// providing the number of artifacts of a component version, which
+// may be transferred in parallel.
+type ConcurrentTransferHandler interface {
+	TransferHandler
+	GetConcurrency() int
+}
+
+// GetConcurrency returns the artifact concurrency level of a TransferHandler.
+// Handlers not implementing ConcurrentTransferHandler transfer
+// sequentially (level 1).
+func GetConcurrency(h TransferHandler) int {
+	if c, ok := h.(ConcurrentTransferHandler); ok && c.GetConcurrency() > 1 {
+		return c.GetConcurrency()
+	}
+	return 1
+}
+
+// JournalingTransferHandler is an optional interface of a TransferHandler
+// providing a journal used to record and resume the transfer progress.
+type JournalingTransferHandler interface {
+	TransferHandler
+	GetJournal() *journal.Journal
+}
+
+// GetJournal returns the transfer journal of a TransferHandler, if configured.
+func GetJournal(h TransferHandler) *journal.Journal {
+	if j, ok := h.(JournalingTransferHandler); ok {
+		return j.GetJournal()
+	}
+	return nil
+}
+
+// DeltaTransferHandler is an optional interface of a TransferHandler
+// providing the number of bytes saved by skipping blobs already
+// present in the target.
+type DeltaTransferHandler interface {
+	TransferHandler
+	GetSavedBytes() int64
+}
+
+// GetSavedBytes returns the number of bytes saved by a TransferHandler.
+// Handlers not implementing DeltaTransferHandler always report 0.
+func GetSavedBytes(h TransferHandler) int64 {
+	if d, ok := h.(DeltaTransferHandler); ok {
+		return d.GetSavedBytes()
+	}
+	return 0
+}
+
 func ApplyOptions(set TransferOptions, opts ...TransferOption) error {
 	list := errors.ErrListf("transfer options")
 	for _, o := range opts {
@@ -16,6 +16,7 @@ import (
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/log"
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 )
@@ -24,6 +25,98 @@ type dockerFetcher struct {
 	*dockerBase
 }
 
+// Stat checks the existence of a blob by a HEAD request on the blobs endpoint.
+func (r dockerFetcher) Stat(ctx context.Context, dgst digest.Digest) (int64, error) {
+	ctx = log.WithLogger(ctx, log.G(ctx).WithField("digest", dgst))
+
+	hosts := r.filterHosts(HostCapabilityPull)
+	if len(hosts) == 0 {
+		return -1, errors.Wrap(errdefs.ErrNotFound, "no pull hosts")
+	}
+
+	ctx, err := ContextWithRepositoryScope(ctx, r.refspec, false)
+	if err != nil {
+		return -1, err
+	}
+
+	var firstErr error
+	for _, host := range hosts {
+		req := r.request(host, http.MethodHead, "blobs", dgst.String())
+		if err := req.addNamespace(r.refspec.Hostname()); err != nil {
+			return -1, err
+		}
+		resp, err := req.doWithRetries(ctx, nil)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue // try another host
+		}
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if resp.StatusCode > 299 {
+			if firstErr == nil {
+				firstErr = errors.Errorf("checking blob %s at host %s failed with status code %v", dgst, host.Host, resp.Status)
+			}
+			continue
+		}
+		return resp.ContentLength, nil
+	}
+	if firstErr == nil {
+		firstErr = errors.Wrapf(errdefs.ErrNotFound, "blob %s", dgst)
+	}
+	return -1, firstErr
+}
+
+// FetchReferrers fetches the referrers index of a manifest.
+// Registries not supporting the referrers API respond with 404.
+func (r dockerFetcher) FetchReferrers(ctx context.Context, dgst digest.Digest) (io.ReadCloser, error) {
+	ctx = log.WithLogger(ctx, log.G(ctx).WithField("digest", dgst))
+
+	hosts := r.filterHosts(HostCapabilityPull)
+	if len(hosts) == 0 {
+		return nil, errors.Wrap(errdefs.ErrNotFound, "no pull hosts")
+	}
+
+	ctx, err := ContextWithRepositoryScope(ctx, r.refspec, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	for _, host := range hosts {
+		req := r.request(host, http.MethodGet, "referrers", dgst.String())
+		if err := req.addNamespace(r.refspec.Hostname()); err != nil {
+			return nil, err
+		}
+		req.header.Set("Accept", ocispec.MediaTypeImageIndex)
+		resp, err := req.doWithRetries(ctx, nil)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue // try another host
+		}
+		if resp.StatusCode > 299 {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusNotFound {
+				if firstErr == nil {
+					firstErr = errors.Wrapf(errdefs.ErrNotImplemented, "referrers API at host %s", host.Host)
+				}
+				continue
+			}
+			if firstErr == nil {
+				firstErr = errors.Errorf("fetching referrers for %s at host %s failed with status code %v", dgst, host.Host, resp.Status)
+			}
+			continue
+		}
+		return resp.Body, nil
+	}
+	return nil, firstErr
+}
+
 func (r dockerFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
 	ctx = log.WithLogger(ctx, log.G(ctx).WithField("digest", desc.Digest))
 
@@ -53,6 +53,24 @@ type Fetcher interface {
 	Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error)
 }
 
+// BlobStater is an optional interface of a Fetcher able to check
+// the existence of a blob without fetching its content.
+type BlobStater interface {
+	// Stat returns the size of an existing blob, or an
+	// errdefs.ErrNotFound error, if the blob does not exist.
+	Stat(ctx context.Context, dgst digest.Digest) (int64, error)
+}
+
+// ReferrersFetcher is an optional interface of a Fetcher able to fetch
+// the referrers index of a manifest using the referrers API of the
+// OCI distribution spec.
+type ReferrersFetcher interface {
+	// FetchReferrers fetches the referrers index for the given digest.
+	// If the registry does not support the referrers API, an
+	// errdefs.ErrNotImplemented error is returned.
+	FetchReferrers(ctx context.Context, dgst digest.Digest) (io.ReadCloser, error)
+}
+
 // Pusher pushes content
 // don't use write interface of containerd remotes.Pusher.
 type Pusher interface {
@@ -16,4 +16,7 @@ const (
 	MIME_GZIP = "application/gzip"
 	MIME_TAR  = "application/x-tar"
 	MIME_TGZ  = "application/x-tgz"
+
+	MIME_SPDX_JSON      = "application/spdx+json"
+	MIME_CYCLONEDX_JSON = "application/vnd.cyclonedx+json"
 )
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const CYCLONEDX_BOM_FORMAT = "CycloneDX"
+
+func validateCycloneDX(content map[string]interface{}) error {
+	err := checkFields("CycloneDX document", content, "bomFormat", "specVersion")
+	if err != nil {
+		return err
+	}
+	if f := getString(content, "bomFormat"); f != CYCLONEDX_BOM_FORMAT {
+		return errors.Newf("CycloneDX document: invalid bomFormat %q", f)
+	}
+	return checkListFields("CycloneDX document", "components", content, "type", "name")
+}
+
+// mergeCycloneDX merges CycloneDX documents. Components with the same
+// bom-ref are considered identical and included only once. The main
+// components of the merged documents are added as components the new
+// product component depends on.
+func mergeCycloneDX(info Info, docs []*Document) (*Document, error) {
+	product := map[string]interface{}{
+		"type":    "application",
+		"name":    info.Name,
+		"bom-ref": info.Name,
+	}
+	if info.Version != "" {
+		product["version"] = info.Version
+		product["bom-ref"] = info.Name + "@" + info.Version
+	}
+	content := map[string]interface{}{
+		"bomFormat":    CYCLONEDX_BOM_FORMAT,
+		"specVersion":  getString(docs[0].Content, "specVersion"),
+		"serialNumber": "urn:uuid:" + uuid.New().String(),
+		"version":      1,
+		"metadata": map[string]interface{}{
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+			"tools": []interface{}{
+				map[string]interface{}{"name": "ocm"},
+			},
+			"component": product,
+		},
+	}
+
+	var components []interface{}
+	refs := map[string]bool{}
+	addComponent := func(c interface{}) {
+		if m, ok := c.(map[string]interface{}); ok {
+			if ref := getString(m, "bom-ref"); ref != "" {
+				if refs[ref] {
+					return
+				}
+				refs[ref] = true
+			}
+		}
+		components = append(components, c)
+	}
+
+	var order []string
+	deps := map[string][]interface{}{}
+	addDependency := func(ref string, dependsOn ...interface{}) {
+		list, ok := deps[ref]
+		if !ok {
+			order = append(order, ref)
+		}
+	outer:
+		for _, d := range dependsOn {
+			for _, o := range list {
+				if o == d {
+					continue outer
+				}
+			}
+			list = append(list, d)
+		}
+		deps[ref] = list
+	}
+
+	var mains []interface{}
+	for _, d := range docs {
+		if meta, ok := d.Content["metadata"].(map[string]interface{}); ok {
+			if main, ok := meta["component"].(map[string]interface{}); ok {
+				addComponent(main)
+				if ref := getString(main, "bom-ref"); ref != "" {
+					mains = append(mains, ref)
+				}
+			}
+		}
+		for _, c := range getList(d.Content, "components") {
+			addComponent(c)
+		}
+		for _, e := range getList(d.Content, "dependencies") {
+			if m, ok := e.(map[string]interface{}); ok {
+				addDependency(getString(m, "ref"), getList(m, "dependsOn")...)
+			}
+		}
+	}
+	if len(mains) > 0 {
+		order = append([]string{getString(product, "bom-ref")}, order...)
+		deps[getString(product, "bom-ref")] = mains
+	}
+
+	if len(components) > 0 {
+		content["components"] = components
+	}
+	if len(order) > 0 {
+		var list []interface{}
+		for _, ref := range order {
+			e := map[string]interface{}{"ref": ref}
+			if len(deps[ref]) > 0 {
+				e["dependsOn"] = deps[ref]
+			}
+			list = append(list, e)
+		}
+		content["dependencies"] = list
+	}
+	return &Document{Format: FORMAT_CYCLONEDX, Content: content}, nil
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const KIND_SBOM_FORMAT = "sbom format"
+
+// Format describes the format of a software bill of materials.
+type Format string
+
+const (
+	FORMAT_SPDX      Format = "SPDX"
+	FORMAT_CYCLONEDX Format = "CycloneDX"
+)
+
+// MediaType returns the JSON media type used for documents of the format.
+func (f Format) MediaType() string {
+	switch f {
+	case FORMAT_SPDX:
+		return mime.MIME_SPDX_JSON
+	case FORMAT_CYCLONEDX:
+		return mime.MIME_CYCLONEDX_JSON
+	}
+	return mime.MIME_JSON
+}
+
+// FormatForMediaType provides the format for a (JSON) SBOM media type.
+// An empty format is returned for unknown media types.
+func FormatForMediaType(mediatype string) Format {
+	switch mediatype {
+	case mime.MIME_SPDX_JSON:
+		return FORMAT_SPDX
+	case mime.MIME_CYCLONEDX_JSON:
+		return FORMAT_CYCLONEDX
+	}
+	return ""
+}
+
+// Document is a parsed SBOM document. The content is kept generically
+// to preserve all information found in the original document.
+type Document struct {
+	Format  Format
+	Content map[string]interface{}
+}
+
+// Parse parses and validates a JSON SBOM document and determines its format.
+func Parse(data []byte) (*Document, error) {
+	var content map[string]interface{}
+	err := json.Unmarshal(data, &content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid SBOM document")
+	}
+	doc := &Document{Content: content}
+	switch {
+	case content["spdxVersion"] != nil:
+		doc.Format = FORMAT_SPDX
+	case content["bomFormat"] != nil:
+		doc.Format = FORMAT_CYCLONEDX
+	default:
+		return nil, errors.Newf("unknown SBOM format: neither SPDX nor CycloneDX document")
+	}
+	return doc, doc.Validate()
+}
+
+// MediaType returns the media type of the document.
+func (d *Document) MediaType() string {
+	return d.Format.MediaType()
+}
+
+// Validate checks the required fields of the document.
+func (d *Document) Validate() error {
+	switch d.Format {
+	case FORMAT_SPDX:
+		return validateSPDX(d.Content)
+	case FORMAT_CYCLONEDX:
+		return validateCycloneDX(d.Content)
+	}
+	return errors.ErrUnknown(KIND_SBOM_FORMAT, string(d.Format))
+}
+
+// Data returns the JSON representation of the document.
+func (d *Document) Data() ([]byte, error) {
+	return json.MarshalIndent(d.Content, "", "  ")
+}
+
+// Info describes the product the merged SBOM document is created for.
+type Info struct {
+	Name    string
+	Version string
+}
+
+// Merge merges a list of SBOM documents of the same format into a single
+// document for the given product.
+func Merge(info Info, docs ...*Document) (*Document, error) {
+	if len(docs) == 0 {
+		return nil, errors.Newf("no SBOM documents to merge")
+	}
+	format := docs[0].Format
+	for i, d := range docs {
+		if d.Format != format {
+			return nil, errors.Newf("cannot merge SBOM documents of different formats (%s and %s for document %d)", format, d.Format, i)
+		}
+	}
+	switch format {
+	case FORMAT_SPDX:
+		return mergeSPDX(info, docs)
+	case FORMAT_CYCLONEDX:
+		return mergeCycloneDX(info, docs)
+	}
+	return nil, errors.ErrUnknown(KIND_SBOM_FORMAT, string(format))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func getString(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+func getList(m map[string]interface{}, key string) []interface{} {
+	l, _ := m[key].([]interface{})
+	return l
+}
+
+func checkFields(kind string, m map[string]interface{}, fields ...string) error {
+	for _, f := range fields {
+		if getString(m, f) == "" {
+			return errors.Newf("%s: required field %q missing", kind, f)
+		}
+	}
+	return nil
+}
+
+func checkListFields(kind, key string, m map[string]interface{}, fields ...string) error {
+	for i, e := range getList(m, key) {
+		entry, ok := e.(map[string]interface{})
+		if !ok {
+			return errors.Newf("%s: invalid %s entry %d", kind, key, i)
+		}
+		if err := checkFields(fmt.Sprintf("%s %s entry %d", kind, key, i), entry, fields...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/sbom"
+)
+
+func read(path string) *sbom.Document {
+	data := Must(os.ReadFile(path))
+	return Must(sbom.Parse(data))
+}
+
+var _ = Describe("sbom", func() {
+	Context("parse", func() {
+		It("detects SPDX", func() {
+			doc := read("testdata/spdx1.json")
+			Expect(doc.Format).To(Equal(sbom.FORMAT_SPDX))
+			Expect(doc.MediaType()).To(Equal(mime.MIME_SPDX_JSON))
+		})
+
+		It("detects CycloneDX", func() {
+			doc := read("testdata/cdx1.json")
+			Expect(doc.Format).To(Equal(sbom.FORMAT_CYCLONEDX))
+			Expect(doc.MediaType()).To(Equal(mime.MIME_CYCLONEDX_JSON))
+		})
+
+		It("rejects unknown documents", func() {
+			_, err := sbom.Parse([]byte(`{"name": "test"}`))
+			Expect(err).To(MatchError("unknown SBOM format: neither SPDX nor CycloneDX document"))
+		})
+
+		It("rejects invalid SPDX documents", func() {
+			_, err := sbom.Parse([]byte(`{"spdxVersion": "SPDX-2.3", "SPDXID": "SPDXRef-DOCUMENT", "name": "test", "dataLicense": "CC0-1.0"}`))
+			Expect(err).To(MatchError(`SPDX document: required field "documentNamespace" missing`))
+		})
+
+		It("rejects invalid CycloneDX documents", func() {
+			_, err := sbom.Parse([]byte(`{"bomFormat": "CycloneDX", "specVersion": "1.4", "components": [{"type": "library"}]}`))
+			Expect(err).To(MatchError(`CycloneDX document components entry 0: required field "name" missing`))
+		})
+	})
+
+	Context("merge", func() {
+		It("merges SPDX documents", func() {
+			doc := Must(sbom.Merge(sbom.Info{Name: "acme.org/product", Version: "v1"}, read("testdata/spdx1.json"), read("testdata/spdx2.json")))
+			Expect(doc.Format).To(Equal(sbom.FORMAT_SPDX))
+			Expect(doc.Content["name"]).To(Equal("acme.org/product-v1"))
+			Expect(doc.Content["documentDescribes"]).To(Equal([]interface{}{"SPDXRef-0-Package-app"}))
+
+			var ids []interface{}
+			for _, p := range doc.Content["packages"].([]interface{}) {
+				ids = append(ids, p.(map[string]interface{})["SPDXID"])
+			}
+			Expect(ids).To(Equal([]interface{}{"SPDXRef-0-Package-app", "SPDXRef-0-Package-lib", "SPDXRef-1-Package-lib"}))
+			Expect(doc.Content["relationships"]).To(ContainElement(map[string]interface{}{
+				"spdxElementId":      "SPDXRef-DOCUMENT",
+				"relationshipType":   "DESCRIBES",
+				"relatedSpdxElement": "SPDXRef-1-Package-lib",
+			}))
+			Expect(doc.Validate()).To(Succeed())
+		})
+
+		It("merges CycloneDX documents", func() {
+			doc := Must(sbom.Merge(sbom.Info{Name: "acme.org/product", Version: "v1"}, read("testdata/cdx1.json"), read("testdata/cdx2.json")))
+			Expect(doc.Format).To(Equal(sbom.FORMAT_CYCLONEDX))
+
+			var refs []interface{}
+			for _, p := range doc.Content["components"].([]interface{}) {
+				refs = append(refs, p.(map[string]interface{})["bom-ref"])
+			}
+			Expect(refs).To(Equal([]interface{}{"pkg:generic/app@1.0.0", "pkg:generic/lib@2.0.0", "pkg:generic/tool@0.1.0"}))
+			Expect(doc.Content["dependencies"]).To(Equal([]interface{}{
+				map[string]interface{}{"ref": "acme.org/product@v1", "dependsOn": []interface{}{"pkg:generic/app@1.0.0", "pkg:generic/tool@0.1.0"}},
+				map[string]interface{}{"ref": "pkg:generic/app@1.0.0", "dependsOn": []interface{}{"pkg:generic/lib@2.0.0"}},
+				map[string]interface{}{"ref": "pkg:generic/tool@0.1.0", "dependsOn": []interface{}{"pkg:generic/lib@2.0.0"}},
+			}))
+			Expect(doc.Validate()).To(Succeed())
+		})
+
+		It("rejects mixed formats", func() {
+			_, err := sbom.Merge(sbom.Info{Name: "acme.org/product"}, read("testdata/cdx1.json"), read("testdata/spdx2.json"))
+			Expect(err).To(MatchError("cannot merge SBOM documents of different formats (CycloneDX and SPDX for document 1)"))
+		})
+	})
+})
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const (
+	SPDX_DOCUMENT_ID   = "SPDXRef-DOCUMENT"
+	SPDX_REF_PREFIX    = "SPDXRef-"
+	SPDX_DOCREF_PREFIX = "DocumentRef-"
+)
+
+func validateSPDX(content map[string]interface{}) error {
+	err := checkFields("SPDX document", content, "spdxVersion", "SPDXID", "name", "dataLicense", "documentNamespace")
+	if err != nil {
+		return err
+	}
+	if v := getString(content, "spdxVersion"); !strings.HasPrefix(v, "SPDX-") {
+		return errors.Newf("SPDX document: invalid spdxVersion %q", v)
+	}
+	if id := getString(content, "SPDXID"); id != SPDX_DOCUMENT_ID {
+		return errors.Newf("SPDX document: invalid document SPDXID %q", id)
+	}
+	if _, ok := content["creationInfo"].(map[string]interface{}); !ok {
+		return errors.Newf("SPDX document: required field %q missing", "creationInfo")
+	}
+	if err := checkListFields("SPDX document", "packages", content, "SPDXID", "name"); err != nil {
+		return err
+	}
+	return checkListFields("SPDX document", "files", content, "SPDXID", "fileName")
+}
+
+// mergeSPDX merges SPDX documents. The element ids of the merged documents
+// are prefixed with the document index to avoid conflicts, the document
+// itself is replaced by the new merged document.
+func mergeSPDX(info Info, docs []*Document) (*Document, error) {
+	name := info.Name
+	if info.Version != "" {
+		name += "-" + info.Version
+	}
+	content := map[string]interface{}{
+		"spdxVersion":       getString(docs[0].Content, "spdxVersion"),
+		"dataLicense":       "CC0-1.0",
+		"SPDXID":            SPDX_DOCUMENT_ID,
+		"name":              name,
+		"documentNamespace": "https://ocm.software/spdx/" + strings.ReplaceAll(name, "/", "-") + "-" + uuid.New().String(),
+		"creationInfo": map[string]interface{}{
+			"created":  time.Now().UTC().Format(time.RFC3339),
+			"creators": []interface{}{"Tool: ocm"},
+		},
+	}
+
+	lists := []string{"packages", "files", "snippets", "relationships", "externalDocumentRefs", "documentDescribes"}
+	merged := map[string][]interface{}{}
+	licenses := []interface{}{}
+	licenseIds := map[string]bool{}
+	for i, d := range docs {
+		c := rewriteSPDXIds(d.Content, fmt.Sprintf("%d-", i)).(map[string]interface{})
+		for _, l := range lists {
+			merged[l] = append(merged[l], getList(c, l)...)
+		}
+		for _, e := range getList(c, "hasExtractedLicensingInfos") {
+			if m, ok := e.(map[string]interface{}); ok {
+				id := getString(m, "licenseId")
+				if licenseIds[id] {
+					continue
+				}
+				licenseIds[id] = true
+			}
+			licenses = append(licenses, e)
+		}
+	}
+	for _, l := range lists {
+		if len(merged[l]) > 0 {
+			content[l] = merged[l]
+		}
+	}
+	if len(licenses) > 0 {
+		content["hasExtractedLicensingInfos"] = licenses
+	}
+	return &Document{Format: FORMAT_SPDX, Content: content}, nil
+}
+
+func rewriteSPDXIds(v interface{}, prefix string) interface{} {
+	switch e := v.(type) {
+	case map[string]interface{}:
+		r := map[string]interface{}{}
+		for k, f := range e {
+			r[k] = rewriteSPDXIds(f, prefix)
+		}
+		return r
+	case []interface{}:
+		r := make([]interface{}, len(e))
+		for i, f := range e {
+			r[i] = rewriteSPDXIds(f, prefix)
+		}
+		return r
+	case string:
+		switch {
+		case e == SPDX_DOCUMENT_ID:
+			return e
+		case strings.HasPrefix(e, SPDX_REF_PREFIX):
+			return SPDX_REF_PREFIX + prefix + e[len(SPDX_REF_PREFIX):]
+		case strings.HasPrefix(e, SPDX_DOCREF_PREFIX):
+			return SPDX_DOCREF_PREFIX + prefix + e[len(SPDX_DOCREF_PREFIX):]
+		}
+		return e
+	default:
+		return v
+	}
+}
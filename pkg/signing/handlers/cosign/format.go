@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// GetPublicKey provides the public key (ECDSA or RSA) for a key
+// specification, which might be a PEM encoded public key, a
+// certificate or a private key.
+func GetPublicKey(key interface{}) (crypto.PublicKey, error) {
+	var err error
+	if data, ok := key.([]byte); ok {
+		key, err = ParseKey(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch k := key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey, nil
+	case *rsa.PrivateKey:
+		return &k.PublicKey, nil
+	case *x509.Certificate:
+		return GetPublicKey(k.PublicKey)
+	default:
+		return nil, fmt.Errorf("unknown key specification %T", k)
+	}
+}
+
+// GetPrivateKey provides the private key (ECDSA or RSA) for a key
+// specification, which might be a PEM encoded unencrypted private key.
+func GetPrivateKey(key interface{}) (crypto.PrivateKey, error) {
+	var err error
+	if data, ok := key.([]byte); ok {
+		key, err = ParseKey(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey, *rsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unknown key specification %T", k)
+	}
+}
+
+// ParseKey parses a PEM encoded key or certificate.
+// Encrypted cosign private keys are not supported.
+func ParseKey(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid key format (expected pem block)")
+	}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "CERTIFICATE":
+		return x509.ParseCertificate(block.Bytes)
+	case "ENCRYPTED COSIGN PRIVATE KEY", "ENCRYPTED SIGSTORE PRIVATE KEY":
+		return nil, fmt.Errorf("encrypted cosign private keys are not supported")
+	default:
+		return nil, fmt.Errorf("unsupported pem block type %q", block.Type)
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/signing"
+)
+
+// Algorithm defines the type for signatures compatible with cosign
+// (sigstore) key based signatures. Depending on the used key, an ECDSA
+// (ASN.1 encoded) or an RSA PKCS #1 v1.5 signature is used.
+const Algorithm = "cosign"
+
+// MediaType defines the media type for a base64 encoded cosign signature.
+const MediaType = "application/vnd.dev.cosign.signature.base64"
+
+func init() {
+	signing.DefaultHandlerRegistry().RegisterSignatureHandler(Handler{})
+}
+
+// Handler is a signatures.Signer compatible struct to create cosign
+// compatible signatures and a signatures.Verifier compatible struct
+// to verify such signatures.
+type Handler struct{}
+
+var _ signing.SignatureHandler = Handler{}
+
+func (h Handler) Algorithm() string {
+	return Algorithm
+}
+
+// Sign creates a cosign compatible signature for the given hex encoded digest.
+func (h Handler) Sign(cctx credentials.Context, digest string, hash crypto.Hash, issuer string, key interface{}) (*signing.Signature, error) {
+	privateKey, err := GetPrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cosign private key")
+	}
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding hash to bytes")
+	}
+	var sig []byte
+	switch k := privateKey.(type) {
+	case *ecdsa.PrivateKey:
+		sig, err = ecdsa.SignASN1(rand.Reader, k, decodedHash)
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, hash, decodedHash)
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", k)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed signing hash, %w", err)
+	}
+	return &signing.Signature{
+		Value:     base64.StdEncoding.EncodeToString(sig),
+		MediaType: MediaType,
+		Algorithm: Algorithm,
+		Issuer:    issuer,
+	}, nil
+}
+
+// Verify checks a base64 encoded cosign compatible signature for the
+// given hex encoded digest.
+func (h Handler) Verify(digest string, hash crypto.Hash, sig *signing.Signature, key interface{}) error {
+	publicKey, err := GetPublicKey(key)
+	if err != nil {
+		return errors.Wrapf(err, "invalid cosign public key")
+	}
+	signature, err := base64.StdEncoding.DecodeString(sig.Value)
+	if err != nil {
+		return fmt.Errorf("unable to decode signature: %w", err)
+	}
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return fmt.Errorf("failed decoding hash %s: %w", digest, err)
+	}
+	switch k := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, decodedHash, signature) {
+			return fmt.Errorf("signature verification failed")
+		}
+	case *rsa.PublicKey:
+		err = rsa.VerifyPKCS1v15(k, hash, decodedHash, signature)
+		if err != nil {
+			return fmt.Errorf("signature verification failed, %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", k)
+	}
+	return nil
+}
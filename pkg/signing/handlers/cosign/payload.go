@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"encoding/json"
+)
+
+// PayloadType is the type of the simple signing payload signed by cosign.
+const PayloadType = "cosign container image signature"
+
+// Payload is the simple signing payload signed by cosign for an OCI image.
+type Payload struct {
+	Critical Critical               `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+type Critical struct {
+	Identity Identity `json:"identity"`
+	Image    Image    `json:"image"`
+	Type     string   `json:"type"`
+}
+
+type Identity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+type Image struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+// NewPayload creates the simple signing payload for an image reference
+// and the digest of its manifest.
+func NewPayload(ref string, digest string) *Payload {
+	return &Payload{
+		Critical: Critical{
+			Identity: Identity{DockerReference: ref},
+			Image:    Image{DockerManifestDigest: digest},
+			Type:     PayloadType,
+		},
+	}
+}
+
+func (p *Payload) Data() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// ParsePayload parses a simple signing payload.
+func ParsePayload(data []byte) (*Payload, error) {
+	var p Payload
+	err := json.Unmarshal(data, &p)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ecdsa
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+func GetPublicKey(key interface{}) (*ecdsa.PublicKey, []string, error) {
+	var err error
+	if data, ok := key.([]byte); ok {
+		key, err = ParseKey(data)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return k, nil, nil
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey, nil, nil
+	case *x509.Certificate:
+		if p, ok := k.PublicKey.(*ecdsa.PublicKey); ok {
+			names := append(k.DNSNames[:0:0], k.DNSNames...) //nolint: gocritic // yes
+			if k.Issuer.CommonName != "" {
+				names = append(names, k.Issuer.CommonName)
+			}
+			return p, names, nil
+		}
+		return nil, nil, fmt.Errorf("unknown key public key %T in certificate", k)
+	default:
+		return nil, nil, fmt.Errorf("unknown key specification %T", k)
+	}
+}
+
+func GetPrivateKey(key interface{}) (*ecdsa.PrivateKey, error) {
+	if data, ok := key.([]byte); ok {
+		return ParsePrivateKey(data)
+	}
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unknown key specification %T", k)
+	}
+}
+
+func WriteKeyData(key interface{}, w io.Writer) error {
+	block, err := PemBlockForKey(key)
+	if err != nil {
+		return err
+	}
+	return pem.Encode(w, block)
+}
+
+func KeyData(key interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	err := WriteKeyData(key, buf)
+	return buf.Bytes(), err
+}
+
+func PemBlockForKey(key interface{}) (*pem.Block, error) {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		bytes, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "PUBLIC KEY", Bytes: bytes}, nil
+	case *ecdsa.PrivateKey:
+		bytes, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "EC PRIVATE KEY", Bytes: bytes}, nil
+	default:
+		return nil, errors.ErrInvalid("key")
+	}
+}
+
+func ParseKey(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid key format (expected pem block)")
+	}
+	switch block.Type {
+	case "EC PRIVATE KEY", "PRIVATE KEY":
+		return ParsePrivateKey(data)
+	case "CERTIFICATE":
+		return x509.ParseCertificate(block.Bytes)
+	}
+	return ParsePublicKey(data)
+}
+
+func ParsePublicKey(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid public key format (expected pem block)")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DER encoded public key: %w", err)
+	}
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("unknown type of public key")
+	}
+}
+
+func ParsePrivateKey(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid private key format (expected pem block)")
+	}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	default:
+		untypedPrivateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing key %w", err)
+		}
+		key, ok := untypedPrivateKey.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("parsed key is not of type *ecdsa.PrivateKey: %T", untypedPrivateKey)
+		}
+		return key, nil
+	}
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ecdsa
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/signing"
+)
+
+// Algorithm defines the type for the ECDSA signature algorithm.
+// The curve is determined by the used key.
+const Algorithm = "ECDSA"
+
+// Logical signer names used to select the curve for key creation.
+const (
+	AlgorithmP256 = "ECDSA-P256"
+	AlgorithmP384 = "ECDSA-P384"
+)
+
+// MediaType defines the media type for a plain ASN.1 encoded ECDSA signature.
+const MediaType = "application/vnd.ocm.signature.ecdsa"
+
+func init() {
+	signing.DefaultHandlerRegistry().RegisterSignatureHandler(Handler{})
+	signing.DefaultHandlerRegistry().RegisterSigner(AlgorithmP256, NewHandler(elliptic.P256()))
+	signing.DefaultHandlerRegistry().RegisterSigner(AlgorithmP384, NewHandler(elliptic.P384()))
+}
+
+type (
+	PrivateKey = ecdsa.PrivateKey
+	PublicKey  = ecdsa.PublicKey
+)
+
+// Handler is a signatures.Signer compatible struct to sign with ECDSA
+// and a signatures.Verifier compatible struct to verify ECDSA signatures.
+// The curve is only used to create new key pairs, it defaults to P-256.
+type Handler struct {
+	curve elliptic.Curve
+}
+
+var (
+	_ signing.SignatureHandler = Handler{}
+	_ signing.KeyPairHandler   = Handler{}
+)
+
+// NewHandler creates a handler creating key pairs for the given curve.
+func NewHandler(curve elliptic.Curve) Handler {
+	return Handler{curve: curve}
+}
+
+func (h Handler) Algorithm() string {
+	return Algorithm
+}
+
+func (h Handler) Sign(cctx credentials.Context, digest string, hash crypto.Hash, issuer string, key interface{}) (signature *signing.Signature, err error) {
+	privateKey, err := GetPrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ecdsa private key")
+	}
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding hash to bytes")
+	}
+	sig, err := ecdsa.SignASN1(rand.Reader, privateKey, decodedHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed signing hash, %w", err)
+	}
+	return &signing.Signature{
+		Value:     hex.EncodeToString(sig),
+		MediaType: MediaType,
+		Algorithm: Algorithm,
+		Issuer:    issuer,
+	}, nil
+}
+
+// Verify checks the signature, returns an error on verification failure.
+func (h Handler) Verify(digest string, hash crypto.Hash, signature *signing.Signature, key interface{}) (err error) {
+	publicKey, names, err := GetPublicKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+	if signature.MediaType != MediaType {
+		return fmt.Errorf("invalid signature mediaType %s", signature.MediaType)
+	}
+	signatureBytes, err := hex.DecodeString(signature.Value)
+	if err != nil {
+		return fmt.Errorf("unable to get signature value: failed decoding hash %s: %w", digest, err)
+	}
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return fmt.Errorf("failed decoding hash %s: %w", digest, err)
+	}
+	err = signing.CheckIssuer(signature.Issuer, names)
+	if err != nil {
+		return err
+	}
+	if !ecdsa.VerifyASN1(publicKey, decodedHash, signatureBytes) {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
+
+func (h Handler) CreateKeyPair() (priv interface{}, pub interface{}, err error) {
+	curve := h.curve
+	if curve == nil {
+		curve = elliptic.P256()
+	}
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, &key.PublicKey, nil
+}
+
+func (h Handler) WriteKeyData(key interface{}, w io.Writer) error {
+	return WriteKeyData(key, w)
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ed25519
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/signing"
+)
+
+// Algorithm defines the type for the Ed25519 signature algorithm.
+// The digest is signed as message, the hash function is not used
+// by the algorithm itself.
+const Algorithm = "Ed25519"
+
+// MediaType defines the media type for a plain Ed25519 signature.
+const MediaType = "application/vnd.ocm.signature.ed25519"
+
+func init() {
+	signing.DefaultHandlerRegistry().RegisterSignatureHandler(Handler{})
+}
+
+type (
+	PrivateKey = ed25519.PrivateKey
+	PublicKey  = ed25519.PublicKey
+)
+
+// Handler is a signatures.Signer compatible struct to sign with Ed25519
+// and a signatures.Verifier compatible struct to verify Ed25519 signatures.
+type Handler struct{}
+
+var (
+	_ signing.SignatureHandler = Handler{}
+	_ signing.KeyPairHandler   = Handler{}
+)
+
+func (h Handler) Algorithm() string {
+	return Algorithm
+}
+
+func (h Handler) Sign(cctx credentials.Context, digest string, hash crypto.Hash, issuer string, key interface{}) (signature *signing.Signature, err error) {
+	privateKey, err := GetPrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ed25519 private key")
+	}
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding hash to bytes")
+	}
+	return &signing.Signature{
+		Value:     hex.EncodeToString(ed25519.Sign(privateKey, decodedHash)),
+		MediaType: MediaType,
+		Algorithm: Algorithm,
+		Issuer:    issuer,
+	}, nil
+}
+
+// Verify checks the signature, returns an error on verification failure.
+func (h Handler) Verify(digest string, hash crypto.Hash, signature *signing.Signature, key interface{}) (err error) {
+	publicKey, names, err := GetPublicKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+	if signature.MediaType != MediaType {
+		return fmt.Errorf("invalid signature mediaType %s", signature.MediaType)
+	}
+	signatureBytes, err := hex.DecodeString(signature.Value)
+	if err != nil {
+		return fmt.Errorf("unable to get signature value: failed decoding hash %s: %w", digest, err)
+	}
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return fmt.Errorf("failed decoding hash %s: %w", digest, err)
+	}
+	err = signing.CheckIssuer(signature.Issuer, names)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, decodedHash, signatureBytes) {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
+
+func (h Handler) CreateKeyPair() (priv interface{}, pub interface{}, err error) {
+	pubkey, privkey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return privkey, pubkey, nil
+}
+
+func (h Handler) WriteKeyData(key interface{}, w io.Writer) error {
+	return WriteKeyData(key, w)
+}
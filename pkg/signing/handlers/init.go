@@ -5,6 +5,10 @@
 package handlers
 
 import (
+	_ "github.com/open-component-model/ocm/pkg/signing/handlers/cosign"
+	_ "github.com/open-component-model/ocm/pkg/signing/handlers/ecdsa"
+	_ "github.com/open-component-model/ocm/pkg/signing/handlers/ed25519"
+	_ "github.com/open-component-model/ocm/pkg/signing/handlers/pgp"
 	_ "github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
 	_ "github.com/open-component-model/ocm/pkg/signing/handlers/rsa-signingservice"
 )
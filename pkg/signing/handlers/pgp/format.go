@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// Fingerprint provides the upper case hex encoded fingerprint of
+// the primary key of an entity.
+func Fingerprint(e *openpgp.Entity) string {
+	return fmt.Sprintf("%X", e.PrimaryKey.Fingerprint)
+}
+
+// ParseKeyRing parses an ASCII-armored key (ring) or a binary keyring file.
+func ParseKeyRing(data []byte) (openpgp.EntityList, error) {
+	list, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err == nil {
+		return list, nil
+	}
+	list, err2 := openpgp.ReadKeyRing(bytes.NewReader(data))
+	if err2 != nil {
+		return nil, errors.Wrapf(err, "invalid pgp key ring")
+	}
+	return list, nil
+}
+
+// GetKeyRing provides the key ring for a key specification, which might
+// be an entity, an entity list or the armored or binary key ring data.
+func GetKeyRing(key interface{}) (openpgp.EntityList, error) {
+	switch k := key.(type) {
+	case []byte:
+		return ParseKeyRing(k)
+	case openpgp.EntityList:
+		return k, nil
+	case *openpgp.Entity:
+		return openpgp.EntityList{k}, nil
+	default:
+		return nil, fmt.Errorf("unknown key specification %T", k)
+	}
+}
+
+// GetPrivateKey provides the first entity of a key specification
+// providing an unencrypted private signing key.
+func GetPrivateKey(key interface{}) (*openpgp.Entity, error) {
+	list, err := GetKeyRing(key)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := false
+	for _, e := range list {
+		if e.PrivateKey == nil {
+			continue
+		}
+		if e.PrivateKey.Encrypted {
+			encrypted = true
+			continue
+		}
+		return e, nil
+	}
+	if encrypted {
+		return nil, fmt.Errorf("encrypted pgp private keys are not supported")
+	}
+	return nil, fmt.Errorf("no pgp private key found")
+}
+
+// WriteKeyData writes an ASCII-armored key. For an entity the private
+// key is written, for an entity list the public keys of all entities.
+func WriteKeyData(key interface{}, w io.Writer) error {
+	var (
+		typ       string
+		serialize func(io.Writer) error
+	)
+	switch k := key.(type) {
+	case *openpgp.Entity:
+		if k.PrivateKey == nil {
+			return errors.ErrInvalid("private key")
+		}
+		typ = openpgp.PrivateKeyType
+		serialize = func(w io.Writer) error { return k.SerializePrivate(w, nil) }
+	case openpgp.EntityList:
+		typ = openpgp.PublicKeyType
+		serialize = func(w io.Writer) error {
+			for _, e := range k {
+				if err := e.Serialize(w); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	default:
+		return errors.ErrInvalid("key")
+	}
+	aw, err := armor.Encode(w, typ, nil)
+	if err != nil {
+		return err
+	}
+	err = serialize(aw)
+	if err != nil {
+		aw.Close()
+		return err
+	}
+	return aw.Close()
+}
+
+func KeyData(key interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	err := WriteKeyData(key, buf)
+	return buf.Bytes(), err
+}
+
+// NewEntity creates a new pgp entity with an RSA key.
+func NewEntity(name, comment, email string) (*openpgp.Entity, error) {
+	return openpgp.NewEntity(name, comment, email, &packet.Config{RSABits: 2048})
+}
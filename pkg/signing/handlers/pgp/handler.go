@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgp
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/signing"
+)
+
+// Algorithm defines the type for OpenPGP signatures.
+// The signature is an ASCII-armored detached signature for the
+// hex encoded digest.
+const Algorithm = "pgp"
+
+// MediaType defines the media type for an ASCII-armored OpenPGP signature.
+const MediaType = "application/pgp-signature"
+
+func init() {
+	signing.DefaultHandlerRegistry().RegisterSignatureHandler(Handler{})
+}
+
+// Handler is a signatures.Signer compatible struct to sign with OpenPGP keys
+// and a signatures.Verifier compatible struct to verify OpenPGP signatures.
+// The fingerprint of the signing key is used as issuer.
+type Handler struct{}
+
+var (
+	_ signing.SignatureHandler = Handler{}
+	_ signing.KeyPairHandler   = Handler{}
+)
+
+func (h Handler) Algorithm() string {
+	return Algorithm
+}
+
+func (h Handler) Sign(cctx credentials.Context, digest string, hash crypto.Hash, issuer string, key interface{}) (signature *signing.Signature, err error) {
+	signer, err := GetPrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid pgp private key")
+	}
+	fingerprint := Fingerprint(signer)
+	if issuer != "" && !strings.EqualFold(issuer, fingerprint) {
+		return nil, fmt.Errorf("issuer %q does not match key fingerprint %s", issuer, fingerprint)
+	}
+	buf := bytes.NewBuffer(nil)
+	err = openpgp.ArmoredDetachSign(buf, signer, strings.NewReader(digest), &packet.Config{DefaultHash: hash})
+	if err != nil {
+		return nil, fmt.Errorf("failed signing hash, %w", err)
+	}
+	return &signing.Signature{
+		Value:     buf.String(),
+		MediaType: MediaType,
+		Algorithm: Algorithm,
+		Issuer:    fingerprint,
+	}, nil
+}
+
+// Verify checks the signature, returns an error on verification failure.
+func (h Handler) Verify(digest string, hash crypto.Hash, signature *signing.Signature, key interface{}) (err error) {
+	keyring, err := GetKeyRing(key)
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+	if signature.MediaType != MediaType {
+		return fmt.Errorf("invalid signature mediaType %s", signature.MediaType)
+	}
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring, strings.NewReader(digest), strings.NewReader(signature.Value), nil)
+	if err != nil {
+		return fmt.Errorf("signature verification failed, %w", err)
+	}
+	if signature.Issuer != "" && !strings.EqualFold(signature.Issuer, Fingerprint(signer)) {
+		return fmt.Errorf("issuer %q does not match key fingerprint %s", signature.Issuer, Fingerprint(signer))
+	}
+	return nil
+}
+
+func (h Handler) CreateKeyPair() (priv interface{}, pub interface{}, err error) {
+	e, err := NewEntity("", "", "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return e, openpgp.EntityList{e}, nil
+}
+
+func (h Handler) WriteKeyData(key interface{}, w io.Writer) error {
+	return WriteKeyData(key, w)
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/hex"
 	"encoding/pem"
 	"fmt"
+	"io"
 
 	"github.com/open-component-model/ocm/pkg/contexts/credentials"
 	"github.com/open-component-model/ocm/pkg/errors"
@@ -45,7 +46,10 @@ type (
 // and a signatures.Verifier compatible struct to verify RSASSA-PKCS1-V1_5 signatures.
 type Handler struct{}
 
-var _ Handler = Handler{}
+var (
+	_ signing.SignatureHandler = Handler{}
+	_ signing.KeyPairHandler   = Handler{}
+)
 
 func (h Handler) Algorithm() string {
 	return Algorithm
@@ -105,21 +109,9 @@ func (h Handler) Verify(digest string, hash crypto.Hash, signature *signing.Sign
 		return fmt.Errorf("failed decoding hash %s: %w", digest, err)
 	}
 
-	if names != nil {
-		if signature.Issuer != "" {
-			found := false
-
-			for _, n := range names {
-				if n == signature.Issuer {
-					found = true
-					break
-				}
-			}
-
-			if !found {
-				return fmt.Errorf("issuer %q does not match %v", signature.Issuer, names)
-			}
-		}
+	err = signing.CheckIssuer(signature.Issuer, names)
+	if err != nil {
+		return err
 	}
 	if err := rsa.VerifyPKCS1v15(publicKey, hash, decodedHash, signatureBytes); err != nil {
 		return fmt.Errorf("signature verification failed, %w", err)
@@ -154,6 +146,10 @@ func GetSignaturePEMBlocks(pemData []byte) ([]*pem.Block, error) {
 	return signatureBlocks, nil
 }
 
+func (_ Handler) WriteKeyData(key interface{}, w io.Writer) error {
+	return WriteKeyData(key, w)
+}
+
 func (_ Handler) CreateKeyPair() (priv interface{}, pub interface{}, err error) {
 	key, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"crypto"
 	"encoding/json"
 	"hash"
+	"io"
 
 	"github.com/sirupsen/logrus"
 
@@ -56,6 +57,13 @@ type SignatureHandler interface {
 	Verifier
 }
 
+// KeyPairHandler is an optional interface of a SignatureHandler able
+// to create new key pairs and to write them in PEM format.
+type KeyPairHandler interface {
+	CreateKeyPair() (priv interface{}, pub interface{}, err error)
+	WriteKeyData(key interface{}, w io.Writer) error
+}
+
 // Hasher creates a new hash.Hash interface.
 type Hasher interface {
 	Algorithm() string
@@ -19,3 +19,18 @@ func Hash(hash hash.Hash, data []byte) (string, error) {
 
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
+
+// CheckIssuer checks whether the issuer of a signature matches one of
+// the names provided by a certificate. If no names are given or the
+// signature has no issuer, the check succeeds.
+func CheckIssuer(issuer string, names []string) error {
+	if names == nil || issuer == "" {
+		return nil
+	}
+	for _, n := range names {
+		if n == issuer {
+			return nil
+		}
+	}
+	return errors.Newf("issuer %q does not match %v", issuer, names)
+}
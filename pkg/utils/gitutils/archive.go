@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gitutils
+
+import (
+	"archive/tar"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// OpenRepository opens the git repository of a local checkout
+// or a bare repository found at the given path of a virtual filesystem.
+func OpenRepository(fs vfs.FileSystem, path string) (*git.Repository, error) {
+	dot := vfs.Join(fs, path, git.GitDirName)
+	if ok, err := vfs.DirExists(fs, dot); err != nil {
+		return nil, err
+	} else if !ok {
+		dot = path
+	}
+	storage := filesystem.NewStorage(NewFileSystem(fs, dot), cache.NewObjectLRUDefault())
+	repo, err := git.Open(storage, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open git repository %q", path)
+	}
+	return repo, nil
+}
+
+// ResolveCommit resolves a revision (commit hash, branch, tag or
+// any other revision expression) of a repository. An empty revision
+// refers to HEAD.
+func ResolveCommit(repo *git.Repository, rev string) (*object.Commit, error) {
+	if rev == "" {
+		rev = plumbing.HEAD.String()
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot resolve revision %q", rev)
+	}
+	return repo.CommitObject(*hash)
+}
+
+// Archive writes a tar archive of the file tree of a commit.
+// If a path is given, only the file or the content of the directory
+// denoted by this path is archived, relative to this path.
+// The modification time of all entries is the commit time to
+// provide reproducible archives.
+func Archive(commit *object.Commit, p string, w io.Writer) error {
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	tw := tar.NewWriter(w)
+
+	p = strings.Trim(path.Clean("/"+p), "/")
+	if p != "" {
+		sub, err := tree.Tree(p)
+		if err == nil {
+			tree = sub
+		} else {
+			if err != object.ErrDirectoryNotFound {
+				return errors.Wrapf(err, "path %q", p)
+			}
+			file, err := tree.File(p)
+			if err != nil {
+				return errors.Wrapf(err, "path %q", p)
+			}
+			if err := addFile(tw, commit, path.Base(p), file); err != nil {
+				return err
+			}
+			return tw.Close()
+		}
+	}
+	err = tree.Files().ForEach(func(file *object.File) error {
+		return addFile(tw, commit, file.Name, file)
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+func addFile(tw *tar.Writer, commit *object.Commit, name string, file *object.File) error {
+	header := &tar.Header{
+		Name:    name,
+		ModTime: commit.Committer.When,
+		Format:  tar.FormatPAX,
+	}
+	switch file.Mode {
+	case filemode.Symlink:
+		target, err := file.Contents()
+		if err != nil {
+			return errors.Wrapf(err, "file %q", name)
+		}
+		header.Typeflag = tar.TypeSymlink
+		header.Linkname = target
+		header.Mode = 0o777
+		return tw.WriteHeader(header)
+	case filemode.Executable:
+		header.Mode = 0o755
+	default:
+		header.Mode = 0o644
+	}
+	header.Typeflag = tar.TypeReg
+	header.Size = file.Size
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	r, err := file.Reader()
+	if err != nil {
+		return errors.Wrapf(err, "file %q", name)
+	}
+	defer r.Close()
+	_, err = io.Copy(tw, r)
+	return err
+}
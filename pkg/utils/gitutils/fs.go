@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gitutils
+
+import (
+	"os"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/helper/chroot"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// fileSystem maps a virtual filesystem to the billy filesystem
+// interface used by the git library.
+type fileSystem struct {
+	fs vfs.FileSystem
+}
+
+var _ billy.Filesystem = (*fileSystem)(nil)
+
+// NewFileSystem provides a billy filesystem for the given path of
+// a virtual filesystem.
+func NewFileSystem(fs vfs.FileSystem, path string) billy.Filesystem {
+	return chroot.New(&fileSystem{fs}, path)
+}
+
+func (f *fileSystem) Create(filename string) (billy.File, error) {
+	return f.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o666)
+}
+
+func (f *fileSystem) Open(filename string) (billy.File, error) {
+	return f.OpenFile(filename, os.O_RDONLY, 0)
+}
+
+func (f *fileSystem) OpenFile(filename string, flag int, perm os.FileMode) (billy.File, error) {
+	if flag&os.O_CREATE != 0 {
+		if err := f.fs.MkdirAll(vfs.Dir(f.fs, filename), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	file, err := f.fs.OpenFile(filename, flag, perm)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &fileWrapper{file}, nil
+}
+
+func (f *fileSystem) Stat(filename string) (os.FileInfo, error) {
+	fi, err := f.fs.Stat(filename)
+	return fi, mapError(err)
+}
+
+func (f *fileSystem) Rename(oldpath, newpath string) error {
+	if err := f.fs.MkdirAll(vfs.Dir(f.fs, newpath), 0o755); err != nil {
+		return err
+	}
+	return f.fs.Rename(oldpath, newpath)
+}
+
+func (f *fileSystem) Remove(filename string) error {
+	return mapError(f.fs.Remove(filename))
+}
+
+func (f *fileSystem) Join(elem ...string) string {
+	return vfs.Join(f.fs, elem...)
+}
+
+func (f *fileSystem) TempFile(dir, prefix string) (billy.File, error) {
+	if err := f.fs.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	file, err := vfs.TempFile(f.fs, dir, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return &fileWrapper{file}, nil
+}
+
+func (f *fileSystem) ReadDir(path string) ([]os.FileInfo, error) {
+	list, err := vfs.ReadDir(f.fs, path)
+	return list, mapError(err)
+}
+
+func (f *fileSystem) MkdirAll(filename string, perm os.FileMode) error {
+	return f.fs.MkdirAll(filename, perm)
+}
+
+func (f *fileSystem) Lstat(filename string) (os.FileInfo, error) {
+	fi, err := f.fs.Lstat(filename)
+	return fi, mapError(err)
+}
+
+func (f *fileSystem) Symlink(target, link string) error {
+	return f.fs.Symlink(target, link)
+}
+
+func (f *fileSystem) Readlink(link string) (string, error) {
+	target, err := f.fs.Readlink(link)
+	return target, mapError(err)
+}
+
+func (f *fileSystem) Chroot(path string) (billy.Filesystem, error) {
+	return chroot.New(f, path), nil
+}
+
+func (f *fileSystem) Root() string {
+	return vfs.PathSeparatorString
+}
+
+// mapError maps nested errors of virtual filesystems to plain
+// path errors, because the git library uses os.IsNotExist and
+// os.IsExist, which do not unwrap nested errors.
+func mapError(err error) error {
+	if err == nil || os.IsNotExist(err) || os.IsExist(err) {
+		return err
+	}
+	var perr *os.PathError
+	if !errors.As(err, &perr) {
+		return err
+	}
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return &os.PathError{Op: perr.Op, Path: perr.Path, Err: os.ErrNotExist}
+	case errors.Is(err, os.ErrExist):
+		return &os.PathError{Op: perr.Op, Path: perr.Path, Err: os.ErrExist}
+	}
+	return err
+}
+
+type fileWrapper struct {
+	vfs.File
+}
+
+var _ billy.File = (*fileWrapper)(nil)
+
+// Lock is not supported by virtual filesystems, the git library
+// only uses it for local index updates.
+func (f *fileWrapper) Lock() error {
+	return nil
+}
+
+func (f *fileWrapper) Unlock() error {
+	return nil
+}
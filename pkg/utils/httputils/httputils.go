@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package httputils
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// NewRequest creates an HTTP request authorized with the given credentials.
+func NewRequest(method, u string, body io.Reader, creds credentials.Credentials) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	SetAuthorization(req, creds)
+	return req, nil
+}
+
+// NewRequestForOrigin creates an HTTP request, which is authorized with the
+// given credentials only if the request URL has the same scheme and host
+// as the origin URL the credentials are intended for. This prevents
+// credentials from being sent to foreign hosts referenced by the origin.
+func NewRequestForOrigin(method, u string, body io.Reader, origin string, creds credentials.Credentials) (*http.Request, error) {
+	if !SameOrigin(u, origin) {
+		creds = nil
+	}
+	return NewRequest(method, u, body, creds)
+}
+
+// SameOrigin checks whether two URLs use the same scheme and host.
+func SameOrigin(u, origin string) bool {
+	a, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+	b, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
+}
+
+// SetAuthorization sets the authorization header of a request for the
+// given credentials. A token is used as bearer token, otherwise
+// username and password are used for basic authentication.
+func SetAuthorization(req *http.Request, creds credentials.Credentials) {
+	if creds == nil {
+		return
+	}
+	if token := creds.GetProperty(credentials.ATTR_TOKEN); token != "" {
+		SetBearerToken(req, token)
+	} else if user := creds.GetProperty(credentials.ATTR_USERNAME); user != "" {
+		req.SetBasicAuth(user, creds.GetProperty(credentials.ATTR_PASSWORD))
+	}
+}
+
+// SetBearerToken sets a bearer token as authorization header of a request.
+func SetBearerToken(req *http.Request, token string) {
+	req.Header.Set("Authorization", "Bearer "+token)
+}
+
+// ResponseError provides an error describing a failed HTTP request.
+// It includes the beginning of the response body, if present.
+func ResponseError(u string, resp *http.Response) error {
+	buf := &bytes.Buffer{}
+	_, err := io.Copy(buf, io.LimitReader(resp.Body, 2000))
+	if err != nil || buf.Len() == 0 {
+		return errors.Newf("http request %s provides %s", u, resp.Status)
+	}
+	return errors.Newf("http request %s provides %s: %s", u, resp.Status, buf.String())
+}
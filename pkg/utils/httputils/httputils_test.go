@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package httputils_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/utils/httputils"
+)
+
+var _ = Describe("http utils", func() {
+	It("uses tokens as bearer token", func() {
+		req := Must(httputils.NewRequest(http.MethodGet, "https://acme.org/file", nil, credentials.DirectCredentials{
+			credentials.ATTR_TOKEN:    "token",
+			credentials.ATTR_USERNAME: "user",
+		}))
+		Expect(req.Header.Get("Authorization")).To(Equal("Bearer token"))
+	})
+
+	It("uses basic auth", func() {
+		req := Must(httputils.NewRequest(http.MethodGet, "https://acme.org/file", nil, credentials.DirectCredentials{
+			credentials.ATTR_USERNAME: "user",
+			credentials.ATTR_PASSWORD: "pass",
+		}))
+		user, pass, ok := req.BasicAuth()
+		Expect(ok).To(BeTrue())
+		Expect(user).To(Equal("user"))
+		Expect(pass).To(Equal("pass"))
+	})
+
+	It("omits authorization without credentials", func() {
+		req := Must(httputils.NewRequest(http.MethodGet, "https://acme.org/file", nil, nil))
+		Expect(req.Header.Get("Authorization")).To(Equal(""))
+	})
+
+	It("authorizes requests only for the origin", func() {
+		creds := credentials.DirectCredentials{
+			credentials.ATTR_TOKEN: "token",
+		}
+		req := Must(httputils.NewRequestForOrigin(http.MethodGet, "https://acme.org/files/file", nil, "https://acme.org/simple", creds))
+		Expect(req.Header.Get("Authorization")).To(Equal("Bearer token"))
+		req = Must(httputils.NewRequestForOrigin(http.MethodGet, "https://files.acme.org/file", nil, "https://acme.org/simple", creds))
+		Expect(req.Header.Get("Authorization")).To(Equal(""))
+		req = Must(httputils.NewRequestForOrigin(http.MethodGet, "http://acme.org/file", nil, "https://acme.org/simple", creds))
+		Expect(req.Header.Get("Authorization")).To(Equal(""))
+		req = Must(httputils.NewRequestForOrigin(http.MethodGet, "https://acme.org:8443/file", nil, "https://acme.org/simple", creds))
+		Expect(req.Header.Get("Authorization")).To(Equal(""))
+	})
+
+	It("describes failed requests", func() {
+		resp := &http.Response{Status: "404 Not Found", Body: io.NopCloser(strings.NewReader("not there"))}
+		Expect(httputils.ResponseError("https://acme.org/file", resp)).To(MatchError("http request https://acme.org/file provides 404 Not Found: not there"))
+		resp = &http.Response{Status: "404 Not Found", Body: io.NopCloser(strings.NewReader(""))}
+		Expect(httputils.ResponseError("https://acme.org/file", resp)).To(MatchError("http request https://acme.org/file provides 404 Not Found"))
+	})
+})